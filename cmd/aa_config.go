@@ -16,10 +16,15 @@ var aaConfigCmd = &cobra.Command{
 		fmt.Println("Access Analyzer Configuration")
 		fmt.Println("Available options:")
 		fmt.Println("  --endpoint    Set the Access Analyzer API endpoint")
+		fmt.Println("  --profile     Save --endpoint as a named profile (see 'nwx aa use')")
 		fmt.Println("  --show        Show current configuration")
 		fmt.Println()
+		fmt.Println("Commands:")
+		fmt.Println("  migrate       Move the configuration from ~/.nwx to the XDG location")
+		fmt.Println()
 		fmt.Println("Examples:")
 		fmt.Println("  nwx aa config --endpoint=\"http://localhost:3020\"")
+		fmt.Println("  nwx aa config --profile staging --endpoint=\"https://staging.example.com\"")
 		fmt.Println("  nwx aa config --show")
 	},
 }
@@ -27,21 +32,46 @@ var aaConfigCmd = &cobra.Command{
 var (
 	endpointFlag string
 	showFlag     bool
+	profileFlag  string
 )
 
 func init() {
 	aaConfigCmd.Flags().StringVar(&endpointFlag, "endpoint", "", "Set the Access Analyzer API endpoint")
 	aaConfigCmd.Flags().BoolVar(&showFlag, "show", false, "Show current configuration")
+	aaConfigCmd.Flags().StringVar(&profileFlag, "profile", "", "Save --endpoint as this named profile instead of the active endpoint")
 	
 	aaConfigCmd.PreRun = func(cmd *cobra.Command, args []string) {
 		if endpointFlag != "" {
+			endpoint, err := cleanEndpoint(endpointFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			endpointFlag = endpoint
+		}
+		if profileFlag != "" {
+			if endpointFlag == "" {
+				fmt.Fprintln(os.Stderr, "Error: --profile requires --endpoint")
+				os.Exit(1)
+			}
+			if err := setAAProfileEndpoint(profileFlag, endpointFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving profile: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ Profile '%s' set to: %s\n"), profileFlag, endpointFlag)
+			fmt.Printf("   Switch to it with: nwx aa use %s\n", profileFlag)
+		} else if endpointFlag != "" {
 			if err := setAAEndpoint(endpointFlag); err != nil {
 				fmt.Fprintf(os.Stderr, "Error setting endpoint: %v\n", err)
 				os.Exit(1)
 			}
-			fmt.Printf("✅ Access Analyzer endpoint set to: %s\n", endpointFlag)
+			if err := clearActiveAAProfile(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting endpoint: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ Access Analyzer endpoint set to: %s\n"), endpointFlag)
 			// TODO: Test connection
-			fmt.Println("⚠️  Connection test not implemented yet")
+			fmt.Println(glyphs("⚠️  Connection test not implemented yet"))
 		}
 		
 		if showFlag {
@@ -58,18 +88,16 @@ func init() {
 }
 
 func setAAEndpoint(endpoint string) error {
-	configDir, err := getAAConfigDir()
+	endpoint, _, err := normalizeEndpoint(endpoint)
 	if err != nil {
 		return err
 	}
-	
-	// Create config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	configDir, err := getAAConfigDir()
+	if err != nil {
 		return err
 	}
 	
-	configFile := filepath.Join(configDir, "endpoint")
-	return os.WriteFile(configFile, []byte(endpoint), 0644)
+	return writeConfigFile(configDir, "endpoint", []byte(endpoint))
 }
 
 func getAAEndpoint() (string, error) {
@@ -91,22 +119,34 @@ func getAAEndpoint() (string, error) {
 }
 
 func getAAConfigDir() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	configDir, err := getConfigDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(homeDir, ".nwx", "access-analyzer"), nil
+	return filepath.Join(configDir, "access-analyzer"), nil
 }
 
 func showAAConfig() {
 	fmt.Println("Access Analyzer Configuration:")
 	
-	endpoint, err := getAAEndpoint()
+	resolved, err := ResolveEndpoint(globalEndpointFlags())
 	if err != nil {
 		fmt.Printf("  endpoint: <error: %v>\n", err)
-	} else if endpoint == "" {
+	} else if resolved.Endpoint == "" {
 		fmt.Println("  endpoint: <not configured>")
 	} else {
-		fmt.Printf("  endpoint: %s\n", endpoint)
+		fmt.Printf("  endpoint: %s\n", resolved.Endpoint)
+		fmt.Printf("  endpoint from: %s\n", resolved.Source)
+	}
+	
+	if active, err := getActiveAAProfile(); err == nil && active != "" {
+		fmt.Printf("  profile:  %s\n", active)
+	}
+	if profiles, err := listAAProfiles(); err == nil && len(profiles) > 0 {
+		fmt.Println("  profiles:")
+		for _, name := range profiles {
+			profileEndpoint, _ := getAAProfileEndpoint(name)
+			fmt.Printf("    %-12s %s\n", name, profileEndpoint)
+		}
 	}
 }
\ No newline at end of file
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var configMigrateDryRun bool
+
+// configMigrationNote is written to the backup of a migrated configuration
+// directory, recording where and when it was moved
+const configMigrationNote = ".migrated"
+
+var aaConfigMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move the configuration from ~/.nwx to the XDG location",
+	Long: `Move the configuration from the legacy ~/.nwx directory to
+$XDG_CONFIG_HOME/nwx, or ~/.config/nwx when XDG_CONFIG_HOME is not set.
+
+The command shows the files it will copy and asks before changing
+anything. Once they are copied, ~/.nwx is renamed to a timestamped backup
+(~/.nwx.backup-<time>) recording where it was moved, so nothing is deleted.
+Running it again after a migration does nothing, and an interrupted
+migration can be rerun to finish it.
+
+Use --dry-run to only show what would be done.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if configDirFlag != "" {
+			return fmt.Errorf("--config selects the configuration directory explicitly; there is nothing to migrate")
+		}
+
+		plan, err := planConfigMigration(time.Now())
+		if err != nil {
+			return err
+		}
+		if plan == nil {
+			dir, err := getConfigDir()
+			if err != nil {
+				return err
+			}
+			fmt.Printf(glyphs("✅ No legacy configuration to migrate; using %s\n"), dir)
+			return nil
+		}
+
+		printConfigMigrationPlan(plan)
+		if configMigrateDryRun {
+			return nil
+		}
+		fmt.Println()
+		confirmed, err := askConfirm(&survey.Confirm{
+			Message: "Migrate the configuration?",
+			Default: true,
+		})
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println(glyphs("❌ Migration cancelled; nothing was changed"))
+			return nil
+		}
+
+		if err := runConfigMigration(plan); err != nil {
+			return err
+		}
+		fmt.Printf(glyphs("✅ Configuration migrated to %s\n"), plan.To)
+		fmt.Printf("   The old directory was kept as %s\n", plan.Backup)
+		return nil
+	},
+}
+
+// configMigration is a planned move of the legacy configuration directory
+type configMigration struct {
+	From   string
+	To     string
+	Backup string
+	Files  []string // relative to From
+	Copied int      // files already identical in To, from an interrupted run
+}
+
+// planConfigMigration plans moving ~/.nwx to the XDG location, returning
+// nil when there is no legacy directory. Files in the target that differ
+// from their legacy counterparts are an error rather than being replaced.
+func planConfigMigration(now time.Time) (*configMigration, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine home directory: %w", err)
+	}
+	legacyDir := filepath.Join(homeDir, ".nwx")
+	if _, err := os.Stat(legacyDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	target := filepath.Join(homeDir, ".config", "nwx")
+	if xdgDir := os.Getenv("XDG_CONFIG_HOME"); xdgDir != "" {
+		target = filepath.Join(xdgDir, "nwx")
+	}
+	plan := &configMigration{
+		From:   legacyDir,
+		To:     target,
+		Backup: legacyDir + ".backup-" + now.Format("20060102-150405"),
+	}
+
+	var conflicts []string
+	err = filepath.WalkDir(legacyDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() || d.Name() == configMigrationNote {
+			return err
+		}
+		rel, err := filepath.Rel(legacyDir, path)
+		if err != nil {
+			return err
+		}
+		plan.Files = append(plan.Files, rel)
+
+		existing, err := os.ReadFile(filepath.Join(target, rel))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(data, existing) {
+			plan.Copied++
+		} else {
+			conflicts = append(conflicts, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("cannot migrate: %s already has different values for %v\nMerge the two directories by hand, then remove %s", target, conflicts, legacyDir)
+	}
+	return plan, nil
+}
+
+// printConfigMigrationPlan shows what a migration will change
+func printConfigMigrationPlan(plan *configMigration) {
+	fmt.Println("Configuration migration:")
+	fmt.Printf("  From:    %s\n", plan.From)
+	fmt.Printf("  To:      %s\n", plan.To)
+	fmt.Printf("  Backup:  %s (the old directory, renamed)\n", plan.Backup)
+	fmt.Println()
+	fmt.Printf("Files to copy (%d):\n", len(plan.Files))
+	for _, file := range plan.Files {
+		fmt.Printf("  %s\n", file)
+	}
+	if plan.Copied > 0 {
+		fmt.Printf(glyphs("ℹ️  %d file(s) were already copied by an earlier run\n"), plan.Copied)
+	}
+}
+
+// runConfigMigration copies the legacy files, keeping their permissions
+// except that secret keys are tightened to 0600, then renames the legacy
+// directory to the backup with a note recording the migration
+func runConfigMigration(plan *configMigration) error {
+	for _, rel := range plan.Files {
+		src := filepath.Join(plan.From, rel)
+		info, err := os.Stat(src)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(plan.To, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("cannot create %s: %w", filepath.Dir(dst), err)
+		}
+		perm := info.Mode().Perm()
+		if secretConfigKeys[rel] {
+			perm = 0600
+		}
+		if err := writeFileAtomic(dst, data, perm); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", rel, err)
+		}
+	}
+
+	note := fmt.Sprintf("Migrated to %s on %s\n", plan.To, time.Now().Format(time.RFC3339))
+	if err := writeFileAtomic(filepath.Join(plan.From, configMigrationNote), []byte(note), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(plan.From, plan.Backup); err != nil {
+		return fmt.Errorf("configuration copied to %s, but %s could not be renamed (rename it yourself to finish): %w", plan.To, plan.From, err)
+	}
+	return nil
+}
+
+func init() {
+	aaConfigMigrateCmd.Flags().BoolVar(&configMigrateDryRun, "dry-run", false, "Show the migration without changing anything")
+
+	aaConfigCmd.AddCommand(aaConfigMigrateCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunConfigMigrationTightensSecrets(t *testing.T) {
+	root := t.TempDir()
+	from := filepath.Join(root, ".nwx")
+	if err := os.MkdirAll(from, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]os.FileMode{
+		registryTokenKey: 0644,
+		tokenKey:         0644,
+		namePrefixKey:    0644,
+	}
+	for name, mode := range files {
+		if err := os.WriteFile(filepath.Join(from, name), []byte("value"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plan := &configMigration{
+		From:   from,
+		To:     filepath.Join(root, "xdg", "nwx"),
+		Backup: filepath.Join(root, ".nwx.bak"),
+		Files:  []string{registryTokenKey, tokenKey, namePrefixKey},
+	}
+	if err := runConfigMigration(plan); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]os.FileMode{registryTokenKey: 0600, tokenKey: 0600, namePrefixKey: 0644}
+	for name, mode := range want {
+		info, err := os.Stat(filepath.Join(plan.To, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != mode {
+			t.Errorf("%s mode = %v, want %v", name, got, mode)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// errPingFailed makes 'aa ping' exit non-zero without printing an error,
+// since "fail" has already been printed
+var errPingFailed = errors.New("ping failed")
+
+var (
+	pingQuiet    bool
+	pingEndpoint string
+)
+
+var pingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Check that Access Analyzer is reachable (for probes and scripts)",
+	Long: `Make a single connection test and print "ok" or "fail", exiting 0 or 1.
+With --quiet nothing is printed and only the exit status tells the result.
+
+This is the probe-friendly check for CI gates and liveness probes; use
+'nwx aa status' for latency and details, or --debug to see why a ping
+fails. --endpoint checks another endpoint without changing the
+configuration, and the global --timeout bounds the check:
+
+  nwx aa ping --quiet --timeout 5s`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var client *APIClient
+		var err error
+		if pingEndpoint != "" {
+			client, err = newConfiguredAPIClient(pingEndpoint)
+		} else {
+			client, err = getAPIClient()
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := client.TestConnection(cmd.Context()); err != nil {
+			if !pingQuiet {
+				fmt.Println("fail")
+			}
+			return fmt.Errorf("%w: %w", errPingFailed, err)
+		}
+		if !pingQuiet {
+			fmt.Println("ok")
+		}
+		return nil
+	},
+}
+
+func init() {
+	pingCmd.Flags().BoolVarP(&pingQuiet, "quiet", "q", false, "Print nothing; only set the exit status")
+	pingCmd.Flags().StringVar(&pingEndpoint, "endpoint", "", "Ping this endpoint instead of the configured one")
+
+	accessAnalyzerCmd.AddCommand(pingCmd)
+}
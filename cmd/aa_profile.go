@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// profileNamePattern restricts profile names to safe file names
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+var aaUseCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "Switch to a named endpoint profile",
+	Long: `Make a named endpoint profile (e.g. dev, staging, prod) the active
+Access Analyzer endpoint, then test the connection to it.
+
+Profiles are saved with:
+  nwx aa config --profile <name> --endpoint="<url>"`,
+	Args: cobra.ExactArgs(1),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		profiles, _ := listAAProfiles()
+		return profiles, cobra.ShellCompDirectiveNoFileComp
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		endpoint, err := getAAProfileEndpoint(name)
+		if err != nil {
+			return err
+		}
+
+		if err := setAAEndpoint(endpoint); err != nil {
+			return err
+		}
+		if err := setActiveAAProfile(name); err != nil {
+			return err
+		}
+		fmt.Printf(glyphs("✅ Using profile '%s': %s\n"), name, redactSecrets(endpoint))
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		result := checkStatus(cmd.Context(), client)
+		if result.Err != nil {
+			fmt.Println(errorStyle.Render(fmt.Sprintf(glyphs("⚠️  Connection failed: %v"), redactSecrets(result.Err.Error()))))
+			return nil
+		}
+		fmt.Println(successStyle.Render(fmt.Sprintf(glyphs("✅ Connection successful (%s)"), formatLatency(result.Latency))))
+		return nil
+	},
+}
+
+// getAAProfilesDir returns the directory holding one endpoint file per profile
+func getAAProfilesDir() (string, error) {
+	configDir, err := getAAConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "profiles"), nil
+}
+
+// validateProfileName checks that a profile name can be used as a file name
+func validateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s' (use letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+// setAAProfileEndpoint saves the endpoint of a named profile
+func setAAProfileEndpoint(name, endpoint string) error {
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	endpoint, _, err := normalizeEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+	dir, err := getAAProfilesDir()
+	if err != nil {
+		return err
+	}
+	return writeConfigFile(dir, name, []byte(endpoint))
+}
+
+// getAAProfileEndpoint returns the endpoint of a named profile, listing the
+// available profiles when it does not exist
+func getAAProfileEndpoint(name string) (string, error) {
+	if err := validateProfileName(name); err != nil {
+		return "", err
+	}
+	dir, err := getAAProfilesDir()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if os.IsNotExist(err) {
+		profiles, _ := listAAProfiles()
+		if len(profiles) == 0 {
+			return "", fmt.Errorf("profile '%s' not found; no profiles are configured - add one with 'nwx aa config --profile %s --endpoint=\"<url>\"'", name, name)
+		}
+		return "", fmt.Errorf("profile '%s' not found (available: %s)", name, strings.Join(profiles, ", "))
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// listAAProfiles returns the names of the saved profiles in sorted order
+func listAAProfiles() ([]string, error) {
+	dir, err := getAAProfilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			profiles = append(profiles, entry.Name())
+		}
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// setActiveAAProfile records which profile the active endpoint came from
+func setActiveAAProfile(name string) error {
+	configDir, err := getAAConfigDir()
+	if err != nil {
+		return err
+	}
+	return writeConfigFile(configDir, "profile", []byte(name))
+}
+
+// clearActiveAAProfile forgets the active profile after the endpoint is set directly
+func clearActiveAAProfile() error {
+	configDir, err := getAAConfigDir()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(configDir, "profile"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// getActiveAAProfile returns the profile last selected with 'aa use', or ""
+func getActiveAAProfile() (string, error) {
+	configDir, err := getAAConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, "profile"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func init() {
+	accessAnalyzerCmd.AddCommand(aaUseCmd)
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// errSourcesUnreachable makes 'aa reachability' exit non-zero without
+// printing an error, since the table already shows the failures
+var errSourcesUnreachable = errors.New("some sources are unreachable")
+
+var (
+	reachabilityOutput      string
+	reachabilityWorkers     int
+	reachabilityHostTimeout time.Duration
+)
+
+// Reachability outcomes of a source
+const (
+	sourceReachable   = "reachable"
+	sourceUnreachable = "unreachable"
+	sourceSkipped     = "skipped"
+)
+
+// sourceReachability is the outcome of checking one source's target
+type sourceReachability struct {
+	SourceID  string `json:"sourceId"`
+	Name      string `json:"name"`
+	Target    string `json:"target,omitempty"`
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latencyMs,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+var reachabilityCmd = &cobra.Command{
+	Use:   "reachability",
+	Short: "Check that the data sources' hosts are reachable",
+	Long: `Open a TCP connection to the host and port of every configured data source
+and report which ones are reachable. Unlike 'nwx aa status', which checks
+the Access Analyzer API, this checks the targets the scanners connect to,
+as seen from this machine.
+
+The host and port are read from each source's configuration (host, server
+or url, and port). Sources without them are reported as skipped. Up to
+--workers hosts are checked at once, each bounded by --host-timeout. The
+command exits non-zero when a source is unreachable.
+
+If the API does not expose sources, a warning is printed and the command
+succeeds without checking anything.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(reachabilityOutput, outputTable, outputJSON); err != nil {
+			return err
+		}
+		if reachabilityWorkers < 1 {
+			return fmt.Errorf("invalid --workers %d (must be at least 1)", reachabilityWorkers)
+		}
+		if reachabilityHostTimeout <= 0 {
+			return fmt.Errorf("invalid --host-timeout %s (must be positive)", reachabilityHostTimeout)
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		sources, err := client.GetAllSources(cmd.Context())
+		if isEndpointUnavailable(err) {
+			fmt.Fprintln(os.Stderr, glyphs("⚠️  The API does not expose sources, so their hosts can't be checked"))
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		results := checkSourcesReachable(cmd.Context(), sources, reachabilityWorkers, reachabilityHostTimeout)
+
+		failed := 0
+		for _, result := range results {
+			if result.Status == sourceUnreachable {
+				failed++
+			}
+		}
+
+		if reachabilityOutput == outputJSON {
+			if err := printJSON(results); err != nil {
+				return err
+			}
+		} else {
+			printReachability(results, failed)
+		}
+		if failed > 0 {
+			return errSourcesUnreachable
+		}
+		return nil
+	},
+}
+
+// checkSourcesReachable checks the target of each source with a pool of
+// workers, keeping the results in source order
+func checkSourcesReachable(ctx context.Context, sources []SourceWithConfig, workers int, timeout time.Duration) []sourceReachability {
+	results := make([]sourceReachability, len(sources))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(sources); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = checkSourceReachable(ctx, sources[i], timeout)
+			}
+		}()
+	}
+	for i := range sources {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// checkSourceReachable dials the target of one source
+func checkSourceReachable(ctx context.Context, source SourceWithConfig, timeout time.Duration) sourceReachability {
+	result := sourceReachability{SourceID: source.SourceID, Name: source.Name}
+	target, err := sourceTarget(source.Config)
+	if err != nil {
+		result.Status = sourceSkipped
+		result.Error = err.Error()
+		return result
+	}
+	result.Target = target
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", target)
+	if err != nil {
+		result.Status = sourceUnreachable
+		result.Error = err.Error()
+		return result
+	}
+	conn.Close()
+	result.Status = sourceReachable
+	result.LatencyMs = time.Since(start).Milliseconds()
+	return result
+}
+
+// sourceTarget returns the host:port a source's scanner connects to. The
+// host comes from the host, hostname, server or address key, or from a
+// url, whose scheme gives the port when there is no port key.
+func sourceTarget(config map[string]interface{}) (string, error) {
+	host := configString(config, "host", "hostname", "server", "address")
+	port := configString(config, "port")
+
+	if host == "" {
+		if raw := configString(config, "url", "baseUrl", "endpoint"); raw != "" {
+			u, err := url.Parse(raw)
+			if err != nil || u.Hostname() == "" {
+				return "", fmt.Errorf("invalid url '%s'", raw)
+			}
+			host = u.Hostname()
+			if port == "" {
+				port = u.Port()
+			}
+			if port == "" {
+				switch u.Scheme {
+				case "https":
+					port = "443"
+				case "http":
+					port = "80"
+				}
+			}
+		}
+	} else if h, p, err := net.SplitHostPort(host); err == nil {
+		host = h
+		if port == "" {
+			port = p
+		}
+	}
+
+	if host == "" {
+		return "", fmt.Errorf("no host in the source configuration")
+	}
+	if port == "" {
+		return "", fmt.Errorf("no port for %s in the source configuration", host)
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// configString returns the first of keys set in a source configuration,
+// formatting numbers such as ports without a fraction
+func configString(config map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		switch v := config[key].(type) {
+		case string:
+			if v = strings.TrimSpace(v); v != "" {
+				return v
+			}
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return ""
+}
+
+// printReachability prints the results as a table with a summary line
+func printReachability(results []sourceReachability, failed int) {
+	if len(results) == 0 {
+		fmt.Println("No sources are configured")
+		return
+	}
+
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		detail := result.Error
+		if result.Status == sourceReachable {
+			detail = formatLatency(time.Duration(result.LatencyMs) * time.Millisecond)
+		}
+		rows = append(rows, []string{result.Name, valueOr(result.Target, "-"), result.Status, detail})
+	}
+	printTable([]string{"SOURCE", "TARGET", "STATUS", "DETAIL"}, rows)
+	fmt.Println()
+
+	skipped := 0
+	for _, result := range results {
+		if result.Status == sourceSkipped {
+			skipped++
+		}
+	}
+	switch {
+	case failed > 0:
+		fmt.Printf(glyphs("❌ %d of %d source(s) unreachable, %d skipped\n"), failed, len(results)-skipped, skipped)
+	case skipped == len(results):
+		fmt.Printf(glyphs("⚠️  No source has a host and port to check (%d skipped)\n"), skipped)
+	default:
+		fmt.Printf(glyphs("✅ %d source(s) reachable, %d skipped\n"), len(results)-skipped, skipped)
+	}
+}
+
+func init() {
+	reachabilityCmd.Flags().StringVarP(&reachabilityOutput, "output", "o", outputTable, "Output format (table|json)")
+	reachabilityCmd.Flags().IntVar(&reachabilityWorkers, "workers", 8, "Number of hosts checked at once")
+	reachabilityCmd.Flags().DurationVar(&reachabilityHostTimeout, "host-timeout", 5*time.Second, "Time allowed for each connection")
+
+	accessAnalyzerCmd.AddCommand(reachabilityCmd)
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Scan management",
+	Long:  "Manage Access Analyzer scans",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Scan Management")
+		fmt.Println("Available commands:")
+		fmt.Println("  nwx aa scan list    - List scans")
+		fmt.Println("  nwx aa scan run     - Start a scan, optionally waiting for it")
+		fmt.Println("  nwx aa scan cancel  - Cancel a running scan")
+		fmt.Println("  nwx aa scan summary - Summarize recent scan outcomes")
+		fmt.Println()
+		fmt.Println("Use 'nwx aa scan <command> --help' for more information.")
+	},
+}
+
+var (
+	scanListOutput   string
+	scanListStatus   string
+	scanListSource   string
+	scanListSince    string
+	scanListTemplate string
+)
+
+var scanListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scans",
+	Long: `List scans. Use --output jsonl to stream one JSON object per line as
+pages arrive, which keeps memory bounded for large scan histories.
+
+--output template prints each scan with the Go template given in
+--template, e.g. --template '{{.ScanID}} {{.Status}}'. The fields are
+ScanID, SourceID, SourceTypeID, ScanType, Status, StartedAt, CompletedAt,
+ErrorMessage and RecordCount.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(scanListOutput, outputTable, outputJSON, outputJSONL, outputCSV, outputTemplate); err != nil {
+			return err
+		}
+		tmpl, err := parseOutputTemplate(scanListOutput, scanListTemplate, Scan{})
+		if err != nil {
+			return err
+		}
+
+		query := ScanQuery{
+			PageSize: 100,
+			Status:   scanListStatus,
+			SourceID: scanListSource,
+		}
+		if scanListSince != "" {
+			since, err := parseSince(scanListSince, time.Now())
+			if err != nil {
+				return err
+			}
+			query.Since = since
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+
+		// Stop fetching cleanly on Ctrl+C
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		if scanListOutput == outputJSONL {
+			out := newJSONLinesWriter()
+			err := client.WalkScans(ctx, query, func(scans []Scan) error {
+				for _, scan := range scans {
+					if err := out.Write(scan); err != nil {
+						return err
+					}
+				}
+				return out.Flush()
+			})
+			out.Flush()
+			if errors.Is(err, context.Canceled) {
+				return nil
+			}
+			return err
+		}
+
+		var scans []Scan
+		err = client.WalkScans(ctx, query, func(page []Scan) error {
+			scans = append(scans, page...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if scanListOutput == outputJSON {
+			if scans == nil {
+				scans = []Scan{}
+			}
+			return printJSON(scans)
+		}
+
+		if tmpl != nil {
+			records := make([]interface{}, len(scans))
+			for i := range scans {
+				records[i] = &scans[i]
+			}
+			return printTemplate(tmpl, records)
+		}
+
+		if scanListOutput == outputCSV {
+			rows := make([][]string, 0, len(scans))
+			for i := range scans {
+				rows = append(rows, scanFields.row(&scans[i], scanFields.names))
+			}
+			return printRows(outputCSV, scanFields.names, rows)
+		}
+
+		if len(scans) == 0 {
+			fmt.Println("No scans found")
+			return nil
+		}
+		printScanTable(scans)
+		return nil
+	},
+}
+
+// parseSince parses a --since value given either as an RFC3339 timestamp or
+// as a duration relative to now such as "24h", "90m" or "7d"
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	duration, err := parseRelativeDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since value '%s' (expected an RFC3339 timestamp or a duration like 24h or 7d)", value)
+	}
+	return now.Add(-duration), nil
+}
+
+// parseRelativeDuration parses a Go duration, additionally accepting a day suffix ("7d")
+func parseRelativeDuration(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration '%s'", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid duration '%s'", value)
+	}
+	return duration, nil
+}
+
+// scanFields are the columns of csv output, one per Scan field
+var scanFields = newRecordFields(reflect.TypeOf(Scan{}))
+
+// printScanTable prints scans as a table
+func printScanTable(scans []Scan) {
+	rows := make([][]string, 0, len(scans))
+	for _, scan := range scans {
+		rows = append(rows, []string{
+			scan.ScanID,
+			scan.SourceID,
+			scan.ScanType,
+			scan.Status,
+			scan.StartedAt,
+			valueOr(scan.CompletedAt, "-"),
+		})
+	}
+	printTable([]string{"SCAN ID", "SOURCE", "TYPE", "STATUS", "STARTED", "COMPLETED"}, rows)
+}
+
+func init() {
+	scanListCmd.Flags().StringVarP(&scanListOutput, "output", "o", outputTable, "Output format (table|json|jsonl|csv|template)")
+	scanListCmd.Flags().StringVar(&scanListTemplate, "template", "", "Go template printed for each scan with --output template")
+	scanListCmd.Flags().StringVar(&scanListStatus, "status", "", "Only show scans with this status (e.g. failed, running, completed)")
+	scanListCmd.Flags().StringVar(&scanListSource, "source", "", "Only show scans of this source ID")
+	scanListCmd.Flags().StringVar(&scanListSince, "since", "", "Only show scans started since an RFC3339 timestamp or a duration ago (e.g. 24h, 7d)")
+
+	scanCmd.AddCommand(scanListCmd)
+	accessAnalyzerCmd.AddCommand(scanCmd)
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var scanCancelOutput string
+
+var scanCancelCmd = &cobra.Command{
+	Use:   "cancel <scanId>",
+	Short: "Cancel a running scan",
+	Long: `Stop a running scan and report its resulting state. You are asked to
+confirm unless --yes is given; --output json requires --yes so the output
+stays machine-readable. Cancelling a scan that has already finished is not
+an error.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(scanCancelOutput, outputText, outputJSON); err != nil {
+			return err
+		}
+		if scanCancelOutput == outputJSON && !assumeYesFlag {
+			return fmt.Errorf("--output json requires --yes")
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		scanID := args[0]
+
+		scan, err := client.GetScan(cmd.Context(), scanID)
+		if err != nil {
+			return err
+		}
+		if isTerminalScanStatus(scan.Status) {
+			return reportScanCancel(scan, false)
+		}
+
+		if scanCancelOutput == outputText {
+			confirmed, err := askConfirm(&survey.Confirm{
+				Message: fmt.Sprintf("Cancel scan %s (%s, started %s)?", scan.ScanID, scan.Status, scan.StartedAt),
+				Default: false,
+			})
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println(glyphs("❌ Scan not cancelled"))
+				return nil
+			}
+		}
+
+		cancelErr := client.CancelScan(cmd.Context(), scanID)
+		var apiErr *APIError
+		if cancelErr != nil && !(errors.As(cancelErr, &apiErr) && apiErr.StatusCode == http.StatusConflict) {
+			return cancelErr
+		}
+
+		// The scan may have finished between the lookup and the cancel
+		// request, which the API reports as a conflict
+		scan, err = client.GetScan(cmd.Context(), scanID)
+		if err != nil {
+			return err
+		}
+		return reportScanCancel(scan, cancelErr == nil)
+	},
+}
+
+// terminalScanStatuses are the statuses of scans that are no longer running
+var terminalScanStatuses = []string{"completed", "failed", "cancelled"}
+
+// isTerminalScanStatus reports whether a scan with status has finished
+func isTerminalScanStatus(status string) bool {
+	return contains(terminalScanStatuses, status)
+}
+
+// reportScanCancel prints the state of a scan after a cancel attempt
+func reportScanCancel(scan *Scan, cancelled bool) error {
+	if scanCancelOutput == outputJSON {
+		return printJSON(struct {
+			*Scan
+			Cancelled bool `json:"cancelled"`
+		}{scan, cancelled})
+	}
+
+	if !cancelled {
+		fmt.Printf(glyphs("ℹ️  Scan %s is already %s; nothing to cancel\n"), scan.ScanID, scan.Status)
+		return nil
+	}
+	fmt.Printf(glyphs("✅ Cancelled scan %s (status: %s)\n"), scan.ScanID, scan.Status)
+	return nil
+}
+
+func init() {
+	scanCancelCmd.Flags().StringVarP(&scanCancelOutput, "output", "o", outputText, "Output format (text|json)")
+
+	scanCmd.AddCommand(scanCancelCmd)
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scanRunType         string
+	scanRunWait         bool
+	scanRunPollInterval time.Duration
+	scanRunOutput       string
+)
+
+var scanRunCmd = &cobra.Command{
+	Use:     "run <sourceId>",
+	Aliases: []string{"start"},
+	Short:   "Start a scan of a source",
+	Long: `Start a scan of a source and print the new scan.
+
+With --wait the scan is polled every --poll-interval until it completes,
+fails or is cancelled, and the command exits non-zero unless it completed.
+Use it as a single CI gate together with --output json and the global
+--timeout:
+
+  nwx aa scan run src-1 --wait --timeout 30m --output json
+
+Stopping the wait with Ctrl+C or --timeout leaves the scan running.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(scanRunOutput, outputText, outputJSON); err != nil {
+			return err
+		}
+		if scanRunPollInterval <= 0 {
+			return fmt.Errorf("invalid --poll-interval %s (must be positive)", scanRunPollInterval)
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		started := time.Now()
+		scan, err := client.StartScan(ctx, StartScanRequest{SourceID: args[0], ScanType: scanRunType})
+		if err != nil {
+			return err
+		}
+		if !scanRunWait {
+			if scanRunOutput == outputJSON {
+				return printJSON(scan)
+			}
+			fmt.Printf(glyphs("🚀 Started scan %s of %s (status: %s)\n"), scan.ScanID, scan.SourceID, scan.Status)
+			return nil
+		}
+
+		if scanRunOutput == outputText {
+			fmt.Printf(glyphs("🚀 Started scan %s of %s, waiting for it to finish...\n"), scan.ScanID, scan.SourceID)
+		}
+		scan, err = waitForScan(ctx, client, scan, scanRunPollInterval)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return fmt.Errorf("stopped waiting for scan %s (still %s); it keeps running", scan.ScanID, scan.Status)
+			}
+			return err
+		}
+		return reportScanRun(scan, scanDuration(scan, time.Since(started)))
+	},
+}
+
+// waitForScan polls a scan until it reaches a terminal status or ctx is
+// done, returning the last state seen. Transient polling errors are retried.
+func waitForScan(ctx context.Context, client *APIClient, scan *Scan, interval time.Duration) (*Scan, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for !isTerminalScanStatus(scan.Status) {
+		select {
+		case <-ctx.Done():
+			return scan, fmt.Errorf("waiting for scan %s: %w", scan.ScanID, ctx.Err())
+		case <-ticker.C:
+		}
+
+		var latest *Scan
+		err := retryWithBackoff(ctx, defaultRetryAttempts, defaultRetryDelay, func() error {
+			var err error
+			latest, err = client.GetScan(ctx, scan.ScanID)
+			return err
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return scan, fmt.Errorf("waiting for scan %s: %w", scan.ScanID, ctx.Err())
+			}
+			return scan, err
+		}
+		scan = latest
+	}
+	return scan, nil
+}
+
+// scanDuration returns how long a finished scan ran according to the API,
+// or elapsed when its timestamps are missing or invalid
+func scanDuration(scan *Scan, elapsed time.Duration) time.Duration {
+	startedAt, err := time.Parse(time.RFC3339, scan.StartedAt)
+	if err != nil {
+		return elapsed
+	}
+	completedAt, err := time.Parse(time.RFC3339, scan.CompletedAt)
+	if err != nil || completedAt.Before(startedAt) {
+		return elapsed
+	}
+	return completedAt.Sub(startedAt)
+}
+
+// reportScanRun prints the outcome of a scan waited for with --wait and
+// returns an error unless it completed
+func reportScanRun(scan *Scan, duration time.Duration) error {
+	if scanRunOutput == outputJSON {
+		if err := printJSON(struct {
+			*Scan
+			DurationSeconds float64 `json:"durationSeconds"`
+		}{scan, duration.Round(time.Millisecond).Seconds()}); err != nil {
+			return err
+		}
+	} else if scan.Status == "completed" {
+		fmt.Printf(glyphs("✅ Scan %s completed in %s (%d records)\n"), scan.ScanID, duration.Round(time.Second), scan.RecordCount)
+	}
+
+	if scan.Status != "completed" {
+		if scan.ErrorMessage != "" {
+			return fmt.Errorf("scan %s %s after %s: %s", scan.ScanID, scan.Status, duration.Round(time.Second), scan.ErrorMessage)
+		}
+		return fmt.Errorf("scan %s %s after %s", scan.ScanID, scan.Status, duration.Round(time.Second))
+	}
+	return nil
+}
+
+func init() {
+	scanRunCmd.Flags().StringVar(&scanRunType, "scan-type", "", "Scan type to run (e.g. access, sensitive_data); the server default when empty")
+	scanRunCmd.Flags().BoolVar(&scanRunWait, "wait", false, "Wait for the scan to finish and exit non-zero unless it completed")
+	scanRunCmd.Flags().DurationVar(&scanRunPollInterval, "poll-interval", 5*time.Second, "Time between status checks with --wait")
+	scanRunCmd.Flags().StringVarP(&scanRunOutput, "output", "o", outputText, "Output format (text|json)")
+
+	scanCmd.AddCommand(scanRunCmd)
+}
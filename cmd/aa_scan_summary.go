@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scanSummarySince  string
+	scanSummaryOutput string
+)
+
+// summaryStatusOrder lists the statuses always shown, in display order;
+// any other status follows alphabetically
+var summaryStatusOrder = []string{"completed", "failed", "running"}
+
+var scanSummaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Summarize recent scan outcomes",
+	Long: `Summarize the scans started since --since: the number of scans by status,
+the average duration of finished scans and the most recent failure with
+its error.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(scanSummaryOutput, outputText, outputJSON); err != nil {
+			return err
+		}
+		since, err := parseSince(scanSummarySince, time.Now())
+		if err != nil {
+			return err
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		var scans []Scan
+		err = client.WalkScans(ctx, ScanQuery{PageSize: 100, Since: since}, func(page []Scan) error {
+			scans = append(scans, page...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		summary := summarizeScans(scans, since)
+		if scanSummaryOutput == outputJSON {
+			return printJSON(summary)
+		}
+		printScanSummary(summary)
+		return nil
+	},
+}
+
+// ScanSummary aggregates the outcomes of a set of scans
+type ScanSummary struct {
+	Since    time.Time      `json:"since"`
+	Total    int            `json:"total"`
+	ByStatus map[string]int `json:"byStatus"`
+
+	// AverageDurationSeconds covers the finished scans with valid timestamps
+	AverageDurationSeconds float64 `json:"averageDurationSeconds"`
+	FinishedScans          int     `json:"finishedScans"`
+
+	LastFailure *Scan `json:"lastFailure"`
+}
+
+// summarizeScans counts scans by status, averages the duration of finished
+// scans and finds the most recently started failed scan
+func summarizeScans(scans []Scan, since time.Time) ScanSummary {
+	summary := ScanSummary{Since: since, Total: len(scans), ByStatus: map[string]int{}}
+	for _, status := range summaryStatusOrder {
+		summary.ByStatus[status] = 0
+	}
+
+	var total time.Duration
+	var lastFailureAt time.Time
+	for i, scan := range scans {
+		summary.ByStatus[scan.Status]++
+
+		startedAt, err := time.Parse(time.RFC3339, scan.StartedAt)
+		if isTerminalScanStatus(scan.Status) && err == nil {
+			if completedAt, err := time.Parse(time.RFC3339, scan.CompletedAt); err == nil && !completedAt.Before(startedAt) {
+				total += completedAt.Sub(startedAt)
+				summary.FinishedScans++
+			}
+		}
+		if scan.Status == "failed" && (summary.LastFailure == nil || startedAt.After(lastFailureAt)) {
+			summary.LastFailure = &scans[i]
+			lastFailureAt = startedAt
+		}
+	}
+	if summary.FinishedScans > 0 {
+		average := total / time.Duration(summary.FinishedScans)
+		summary.AverageDurationSeconds = average.Round(time.Millisecond).Seconds()
+	}
+	return summary
+}
+
+// summaryStatuses returns the statuses of summary in display order
+func summaryStatuses(summary ScanSummary) []string {
+	var others []string
+	for status := range summary.ByStatus {
+		if !contains(summaryStatusOrder, status) {
+			others = append(others, status)
+		}
+	}
+	sort.Strings(others)
+	return append(append([]string{}, summaryStatusOrder...), others...)
+}
+
+// printScanSummary prints a scan summary as text
+func printScanSummary(summary ScanSummary) {
+	fmt.Printf(glyphs("📊 %d scan(s) since %s\n"), summary.Total, summary.Since.Local().Format(time.RFC3339))
+	for _, status := range summaryStatuses(summary) {
+		fmt.Printf("  %-10s %d\n", status+":", summary.ByStatus[status])
+	}
+
+	if summary.FinishedScans > 0 {
+		average := time.Duration(summary.AverageDurationSeconds * float64(time.Second))
+		fmt.Printf("Average duration: %s (%d finished scan(s))\n", average.Round(time.Second), summary.FinishedScans)
+	} else {
+		fmt.Println("Average duration: -")
+	}
+
+	if summary.LastFailure == nil {
+		fmt.Println(glyphs("✅ No failed scans"))
+		return
+	}
+	failure := summary.LastFailure
+	fmt.Printf(glyphs("❌ Most recent failure: %s of %s started %s\n"), failure.ScanID, failure.SourceID, failure.StartedAt)
+	fmt.Printf("   %s\n", valueOr(failure.ErrorMessage, "no error message"))
+}
+
+func init() {
+	scanSummaryCmd.Flags().StringVar(&scanSummarySince, "since", "24h", "Summarize scans started since an RFC3339 timestamp or a duration ago (e.g. 24h, 7d)")
+	scanSummaryCmd.Flags().StringVarP(&scanSummaryOutput, "output", "o", outputText, "Output format (text|json)")
+
+	scanCmd.AddCommand(scanSummaryCmd)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2025-06-01T08:30:00Z", want: time.Date(2025, 6, 1, 8, 30, 0, 0, time.UTC)},
+		{value: "2025-06-01T08:30:00+02:00", want: time.Date(2025, 6, 1, 6, 30, 0, 0, time.UTC)},
+		{value: "24h", want: now.Add(-24 * time.Hour)},
+		{value: "90m", want: now.Add(-90 * time.Minute)},
+		{value: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{value: "0d", want: now},
+		{value: "yesterday", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "-2d", wantErr: true},
+		{value: "2025-06-01", wantErr: true},
+		{value: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseSince(%q) = %v, want an error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSince(%q) error: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestScanQueryValues(t *testing.T) {
+	since := time.Date(2025, 6, 1, 10, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	tests := []struct {
+		name  string
+		query ScanQuery
+		want  url.Values
+	}{
+		{
+			name:  "no filters",
+			query: ScanQuery{Page: 1, PageSize: 20},
+			want:  url.Values{"page": {"1"}, "pageSize": {"20"}},
+		},
+		{
+			name:  "all filters",
+			query: ScanQuery{Page: 2, PageSize: 50, Status: "failed", SourceID: "src 1", Since: since},
+			want: url.Values{
+				"page":     {"2"},
+				"pageSize": {"50"},
+				"status":   {"failed"},
+				"sourceId": {"src 1"},
+				"since":    {"2025-06-01T08:00:00Z"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.Query()
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"data":[],"pagination":{"page":1,"pageSize":20,"totalPages":1,"totalItems":0}}`))
+			}))
+			defer server.Close()
+
+			if _, err := NewAPIClient(server.URL).GetScans(context.Background(), tt.query); err != nil {
+				t.Fatal(err)
+			}
+			if got.Encode() != tt.want.Encode() {
+				t.Errorf("query = %s, want %s", got.Encode(), tt.want.Encode())
+			}
+		})
+	}
+}
+
+func TestScanQueryMatches(t *testing.T) {
+	since := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	scan := Scan{Status: "Failed", SourceID: "src-1", StartedAt: "2025-06-02T00:00:00Z"}
+	tests := []struct {
+		name  string
+		query ScanQuery
+		want  bool
+	}{
+		{"no filters", ScanQuery{}, true},
+		{"status is case-insensitive", ScanQuery{Status: "failed"}, true},
+		{"other status", ScanQuery{Status: "completed"}, false},
+		{"source", ScanQuery{SourceID: "src-1"}, true},
+		{"other source", ScanQuery{SourceID: "src-2"}, false},
+		{"started after since", ScanQuery{Since: since}, true},
+		{"started before since", ScanQuery{Since: since.AddDate(0, 0, 2)}, false},
+		{"all filters combine with AND", ScanQuery{Status: "failed", SourceID: "src-2", Since: since}, false},
+		{"all filters match", ScanQuery{Status: "failed", SourceID: "src-1", Since: since}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.query.matches(scan); got != tt.want {
+			t.Errorf("%s: matches = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
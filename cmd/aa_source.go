@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var sourceCmd = &cobra.Command{
+	Use:   "source",
+	Short: "Source management",
+	Long:  "Manage Access Analyzer source types",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Source Management")
+		fmt.Println("Available commands:")
+		fmt.Println("  nwx aa source list                      - List registered source types")
+		fmt.Println("  nwx aa source validate-remote <name>    - Validate a registered source type's specification")
+		fmt.Println("  nwx aa source count                     - Show source type totals")
+		fmt.Println("  nwx aa source get <name>                - Print a source type as JSON (--no-spec for metadata only)")
+		fmt.Println("  nwx aa source search <term>             - Search source types by name and description")
+		fmt.Println("  nwx aa source describe <name>           - Describe a source type (--markdown for docs)")
+		fmt.Println("  nwx aa source tags <name> [key=value]   - Show or set a source type's tags")
+		fmt.Println("  nwx aa source used-by <name>            - List the data sources that use a source type")
+		fmt.Println("  nwx aa source import <file>...          - Register source types from JSON files")
+		fmt.Println("  nwx aa source export <file>             - Export source types to a JSON file (--resume)")
+		fmt.Println("  nwx aa source logs-table-ddl <name>     - Print ClickHouse DDL for a source type's output tables")
+		fmt.Println()
+		fmt.Println("Use 'nwx aa source <command> --help' for more information.")
+	},
+}
+
+var validateRemoteAll bool
+
+var sourceValidateRemoteCmd = &cobra.Command{
+	Use:   "validate-remote [name]",
+	Short: "Validate the specification of a registered source type",
+	Long:  "Fetch the scanner specification embedded in a registered source type and run the same validation as 'nwx aa scanner validate'",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if validateRemoteAll && len(args) > 0 {
+			return fmt.Errorf("cannot combine a source type name with --all")
+		}
+		if !validateRemoteAll && len(args) != 1 {
+			return fmt.Errorf("requires a source type name or --all")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+
+		if !validateRemoteAll {
+			sourceType, err := client.FindSourceType(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if !validateRemoteSourceType(sourceType) {
+				return fmt.Errorf("source type '%s' failed validation", sourceType.TypeName)
+			}
+			return nil
+		}
+
+		sourceTypes, err := client.GetAllSourceTypes(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		passed, failed := 0, 0
+		for _, st := range sourceTypes {
+			sourceType, err := client.GetSourceType(cmd.Context(), st.SourceTypeID)
+			if err != nil {
+				fmt.Printf(glyphs("❌ %s: %v\n"), st.TypeName, err)
+				failed++
+				continue
+			}
+			if validateRemoteSourceType(sourceType) {
+				passed++
+			} else {
+				failed++
+			}
+		}
+
+		fmt.Println()
+		fmt.Printf("Validated %d source types: %d passed, %d failed\n", len(sourceTypes), passed, failed)
+		if failed > 0 {
+			return fmt.Errorf("%d source type(s) failed validation", failed)
+		}
+		return nil
+	},
+}
+
+// validateRemoteSourceType validates the embedded specification of a source
+// type, prints the findings and reports whether it passed
+func validateRemoteSourceType(sourceType *SourceType) bool {
+	spec, err := sourceType.embeddedSpec()
+	if err != nil {
+		fmt.Printf(glyphs("❌ %s: %v\n"), sourceType.TypeName, err)
+		return false
+	}
+
+	findings := validateSpec(spec)
+	errors, warnings := countFindings(findings)
+	if errors > 0 {
+		fmt.Printf(glyphs("❌ %s (%s): %d error(s), %d warning(s)\n"), sourceType.TypeName, sourceType.Version, errors, warnings)
+	} else {
+		fmt.Printf(glyphs("✅ %s (%s): valid, %d warning(s)\n"), sourceType.TypeName, sourceType.Version, warnings)
+	}
+	printFindings(findings)
+
+	return errors == 0
+}
+
+var (
+	sourceCountOutput string
+	sourceCountFilter sourceTypeFilter
+)
+
+var sourceCountCmd = &cobra.Command{
+	Use:   "count",
+	Short: "Show source type totals",
+	Long: `Count registered source types: total, active, inactive, built-in, custom
+and per supported scan type. --active, --inactive, --built-in and --custom
+count only the matching source types, as with 'nwx aa source list'.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(sourceCountOutput, outputTable, outputJSON); err != nil {
+			return err
+		}
+		if err := sourceCountFilter.validate(); err != nil {
+			return err
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		sourceTypes, err := client.GetAllSourceTypes(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		counts := countSourceTypes(sourceCountFilter.apply(sourceTypes))
+		if sourceCountOutput == outputJSON {
+			return printJSON(counts)
+		}
+
+		rows := [][]string{
+			{"Total", strconv.Itoa(counts.Total)},
+			{"Active", strconv.Itoa(counts.Active)},
+			{"Inactive", strconv.Itoa(counts.Inactive)},
+			{"Built-in", strconv.Itoa(counts.BuiltIn)},
+			{"Custom", strconv.Itoa(counts.Custom)},
+		}
+		for _, scanType := range sortedCountKeys(counts.ByScanType) {
+			rows = append(rows, []string{"Scan type: " + scanType, strconv.Itoa(counts.ByScanType[scanType])})
+		}
+		printTable([]string{"SOURCE TYPES", "COUNT"}, rows)
+		return nil
+	},
+}
+
+// sourceTypeCounts are summary totals over all registered source types
+type sourceTypeCounts struct {
+	Total      int            `json:"total"`
+	Active     int            `json:"active"`
+	Inactive   int            `json:"inactive"`
+	BuiltIn    int            `json:"builtIn"`
+	Custom     int            `json:"custom"`
+	ByScanType map[string]int `json:"byScanType"`
+}
+
+// countSourceTypes computes the totals shown by 'aa source count'
+func countSourceTypes(sourceTypes []SourceType) sourceTypeCounts {
+	counts := sourceTypeCounts{Total: len(sourceTypes), ByScanType: make(map[string]int)}
+	for _, st := range sourceTypes {
+		if st.IsActive {
+			counts.Active++
+		} else {
+			counts.Inactive++
+		}
+		if st.IsBuiltIn {
+			counts.BuiltIn++
+		} else {
+			counts.Custom++
+		}
+		for _, scanType := range st.SupportedScans {
+			counts.ByScanType[scanType]++
+		}
+	}
+	return counts
+}
+
+// sortedCountKeys returns the keys of a count map in a stable order
+func sortedCountKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	sourceValidateRemoteCmd.Flags().BoolVar(&validateRemoteAll, "all", false, "Validate every registered source type")
+	sourceCountCmd.Flags().StringVarP(&sourceCountOutput, "output", "o", outputTable, "Output format (table|json)")
+	sourceCountFilter.addFlags(sourceCountCmd)
+
+	sourceCmd.AddCommand(sourceValidateRemoteCmd)
+	sourceCmd.AddCommand(sourceCountCmd)
+	accessAnalyzerCmd.AddCommand(sourceCmd)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	ddlOutFile   string
+	ddlScanTypes []string
+)
+
+var sourceLogsTableDDLCmd = &cobra.Command{
+	Use:   "logs-table-ddl <name>",
+	Short: "Print ClickHouse DDL for a registered source type's output tables",
+	Long: `Fetch the scanner specification registered for a source type and print a
+ClickHouse CREATE TABLE statement for each scan type's output table, named
+as the scanner names them (see 'nwx aa scanner names'). Because the spec is
+the one deployed, the tables match what the scanner writes rather than a
+local copy that may have drifted.
+
+Every scan type with an output schema is included; use --scan-type to pick
+some. Primary key columns become the MergeTree sorting key.
+
+  nwx aa source logs-table-ddl my-scanner --out my-scanner.sql`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, scanType := range ddlScanTypes {
+			if !contains(scanTypeOptions, scanType) {
+				return fmt.Errorf("invalid --scan-type '%s' (expected one of: %s)", scanType, strings.Join(scanTypeOptions, ", "))
+			}
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		sourceType, err := client.FindSourceType(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		spec, err := sourceType.embeddedSpec()
+		if err != nil {
+			return err
+		}
+
+		tables := specClickHouseTables(spec, ddlScanTypes)
+		if len(tables) == 0 {
+			if len(ddlScanTypes) > 0 {
+				return fmt.Errorf("source type '%s' has no output schema for scan type(s) %s", sourceType.TypeName, strings.Join(ddlScanTypes, ", "))
+			}
+			return fmt.Errorf("source type '%s' has no output schema", sourceType.TypeName)
+		}
+
+		var w io.Writer = os.Stdout
+		if ddlOutFile != "" {
+			f, err := os.Create(ddlOutFile)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		writeClickHouseDDL(w, spec, tables)
+
+		if ddlOutFile != "" {
+			fmt.Printf(glyphs("✅ Wrote %d table(s) to %s\n"), len(tables), ddlOutFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	sourceLogsTableDDLCmd.Flags().StringVar(&ddlOutFile, "out", "", "Write the DDL to a file instead of stdout")
+	sourceLogsTableDDLCmd.Flags().StringSliceVar(&ddlScanTypes, "scan-type", nil, "Only include these scan types (access, sensitive_data)")
+
+	sourceCmd.AddCommand(sourceLogsTableDDLCmd)
+}
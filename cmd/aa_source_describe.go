@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	describeMarkdown bool
+	describeOutFile  string
+)
+
+var sourceDescribeCmd = &cobra.Command{
+	Use:   "describe <name>",
+	Short: "Describe a registered source type",
+	Long: `Show a registered source type and its scanner specification. With
+--markdown the description is rendered as a Markdown document (configuration
+and output schema tables) suitable for a documentation site.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		sourceType, err := client.FindSourceType(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		// The spec is optional; a source type without one is still described
+		spec, _ := sourceType.embeddedSpec()
+
+		var w io.Writer = os.Stdout
+		if describeOutFile != "" {
+			f, err := os.Create(describeOutFile)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if describeMarkdown {
+			writeSourceTypeMarkdown(w, sourceType, spec)
+		} else {
+			writeSourceTypeText(w, sourceType, spec)
+		}
+
+		if describeOutFile != "" {
+			fmt.Printf(glyphs("✅ Wrote %s\n"), describeOutFile)
+		}
+		return nil
+	},
+}
+
+// specConfigSections returns the configuration sections of a spec with
+// their headings, skipping sections that are not defined
+func specConfigSections(spec *ScannerSpec) []struct {
+	Title   string
+	Section *SpecConfigSection
+} {
+	all := []struct {
+		Title   string
+		Section *SpecConfigSection
+	}{
+		{"Connection configuration", spec.ConnectionConfig},
+		{"Access scan configuration", spec.AccessScanConfig},
+		{"Sensitive data scan configuration", spec.SensitiveDataScanConfig},
+	}
+
+	sections := all[:0]
+	for _, s := range all {
+		if s.Section != nil && len(s.Section.Items) > 0 {
+			sections = append(sections, s)
+		}
+	}
+	return sections
+}
+
+// writeSourceTypeMarkdown renders a source type as a Markdown document
+func writeSourceTypeMarkdown(w io.Writer, st *SourceType, spec *ScannerSpec) {
+	fmt.Fprintf(w, "# %s\n\n", valueOr(st.DisplayName, st.TypeName))
+	if st.Description != "" {
+		fmt.Fprintf(w, "%s\n\n", st.Description)
+	}
+
+	writeMarkdownTable(w, []string{"Property", "Value"}, [][]string{
+		{"Type name", "`" + st.TypeName + "`"},
+		{"Version", st.Version},
+		{"Scanner image", "`" + st.ScannerImage + "`"},
+		{"Scan types", strings.Join(st.SupportedScans, ", ")},
+		{"Active", yesNo(st.IsActive)},
+		{"Built-in", yesNo(st.IsBuiltIn)},
+	})
+
+	if spec == nil {
+		fmt.Fprintln(w, "_No scanner specification is registered for this source type._")
+		return
+	}
+
+	for _, s := range specConfigSections(spec) {
+		fmt.Fprintf(w, "## %s\n\n", s.Title)
+		var rows [][]string
+		for _, item := range s.Section.Items {
+			rows = append(rows, []string{"`" + item.Key + "`", item.Label, item.Type, yesNo(item.Required), formatDefault(item.Default), item.Description})
+		}
+		writeMarkdownTable(w, []string{"Key", "Label", "Type", "Required", "Default", "Description"}, rows)
+	}
+
+	if len(spec.OutputSchema) > 0 {
+		fmt.Fprintf(w, "## Output schema\n\n")
+		for _, key := range sortedKeys(spec.OutputSchema) {
+			fmt.Fprintf(w, "### %s\n\n", key)
+			var rows [][]string
+			for _, col := range spec.OutputSchema[key].Columns {
+				rows = append(rows, []string{"`" + col.Name + "`", col.Type, yesNo(col.Nullable), yesNo(col.PrimaryKey), col.Description})
+			}
+			writeMarkdownTable(w, []string{"Column", "Type", "Nullable", "Primary key", "Description"}, rows)
+		}
+	}
+}
+
+// writeMarkdownTable writes a GitHub-flavored Markdown table followed by a blank line
+func writeMarkdownTable(w io.Writer, headers []string, rows [][]string) {
+	fmt.Fprintf(w, "| %s |\n", strings.Join(headers, " | "))
+	fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(headers)))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.ReplaceAll(strings.ReplaceAll(cell, "|", "\\|"), "\n", " ")
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	}
+	fmt.Fprintln(w)
+}
+
+// writeSourceTypeText renders a source type for the terminal
+func writeSourceTypeText(w io.Writer, st *SourceType, spec *ScannerSpec) {
+	fmt.Fprintf(w, "%s (%s)\n", valueOr(st.DisplayName, st.TypeName), st.TypeName)
+	if st.Description != "" {
+		fmt.Fprintf(w, "%s\n", st.Description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Version:       %s\n", st.Version)
+	fmt.Fprintf(w, "Image:         %s\n", st.ScannerImage)
+	fmt.Fprintf(w, "Scan Types:    %s\n", strings.Join(st.SupportedScans, ", "))
+	fmt.Fprintf(w, "Active:        %s\n", yesNo(st.IsActive))
+	fmt.Fprintf(w, "Built-in:      %s\n", yesNo(st.IsBuiltIn))
+	if st.Maintainer != "" {
+		fmt.Fprintf(w, "Maintainer:    %s\n", st.Maintainer)
+	}
+
+	if spec == nil {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "No scanner specification registered.")
+		return
+	}
+
+	for _, s := range specConfigSections(spec) {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s:\n", s.Title)
+		for _, item := range s.Section.Items {
+			required := ""
+			if item.Required {
+				required = " (required)"
+			}
+			fmt.Fprintf(w, "  %-20s %-10s%s\n", item.Key, item.Type, required)
+		}
+	}
+
+	for _, key := range sortedKeys(spec.OutputSchema) {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Output schema %s:\n", key)
+		for _, col := range spec.OutputSchema[key].Columns {
+			flags := ""
+			if col.PrimaryKey {
+				flags = " (primary key)"
+			} else if col.Nullable {
+				flags = " (nullable)"
+			}
+			fmt.Fprintf(w, "  %-20s %-10s%s\n", col.Name, col.Type, flags)
+		}
+	}
+}
+
+// yesNo formats a boolean for display
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// formatDefault formats a config item default value, or "" if it has none
+func formatDefault(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func init() {
+	sourceDescribeCmd.Flags().BoolVar(&describeMarkdown, "markdown", false, "Render the description as Markdown")
+	sourceDescribeCmd.Flags().StringVar(&describeOutFile, "out", "", "Write the description to a file instead of stdout")
+
+	sourceCmd.AddCommand(sourceDescribeCmd)
+}
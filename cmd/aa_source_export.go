@@ -0,0 +1,307 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sourceExportResume bool
+	sourceExportTags   []string
+	sourceExportSearch string
+	sourceExportFilter sourceTypeFilter
+)
+
+// exportProgressSuffix names the sidecar file recording the entries of an
+// unfinished export
+const exportProgressSuffix = ".progress"
+
+var sourceExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Export source types with their specifications to a JSON file",
+	Long: `Export every registered source type, including its scanner specification,
+to a JSON array that 'nwx aa source import' reads back.
+
+Entries are written to the file as they are fetched, and each one is
+recorded in <file>.progress. If the export is interrupted, by a failing
+connection or Ctrl+C, rerun it with --resume to skip the source types
+already exported and finish the file. The progress file is removed once
+the export completes.
+
+To export a subset, such as one team's scanners, use the filters of
+'nwx aa source list' and 'nwx aa source search': --tag key=value
+(repeatable), --active, --inactive, --built-in, --custom and --search
+<term>. Nothing is written when no source type matches.
+
+  nwx aa source export team-a.json --tag team=a --custom`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+		progressPath := path + exportProgressSuffix
+
+		if err := sourceExportFilter.validate(); err != nil {
+			return err
+		}
+		tagFilter, err := parseTagAssignments(sourceExportTags)
+		if err != nil {
+			return err
+		}
+		filtered := len(tagFilter) > 0 || sourceExportSearch != "" || sourceExportFilter != (sourceTypeFilter{})
+
+		state, err := readExportProgress(progressPath)
+		if err != nil {
+			return err
+		}
+		switch {
+		case state != nil && !sourceExportResume:
+			return fmt.Errorf("an interrupted export to %s exists; rerun with --resume to continue it, or delete %s to start over", path, progressPath)
+		case state == nil && sourceExportResume:
+			fmt.Printf(glyphs("ℹ️  No interrupted export to %s; starting a new one\n"), path)
+		}
+		if state == nil {
+			if _, err := os.Stat(path); err == nil {
+				confirmed, err := askConfirm(&survey.Confirm{
+					Message: fmt.Sprintf("Overwrite %s?", path),
+					Default: false,
+				})
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					fmt.Println(glyphs("❌ Export cancelled"))
+					return nil
+				}
+			}
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		var sourceTypes []SourceType
+		err = retryWithBackoff(ctx, defaultRetryAttempts, defaultRetryDelay, func() error {
+			var err error
+			sourceTypes, err = client.GetAllSourceTypes(ctx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if filtered {
+			if err := applyLocalSourceTags(sourceTypes); err != nil {
+				return err
+			}
+			sourceTypes = filterByTags(sourceExportFilter.apply(sourceTypes), tagFilter)
+			if sourceExportSearch != "" {
+				matching := make([]SourceType, 0, len(sourceTypes))
+				for _, st := range sourceTypes {
+					if sourceTypeMatches(&st, sourceExportSearch) {
+						matching = append(matching, st)
+					}
+				}
+				sourceTypes = matching
+			}
+			if len(sourceTypes) == 0 {
+				fmt.Println(glyphs("⚠️  No source types match the filters; nothing exported"))
+				return nil
+			}
+		}
+
+		export, err := openSourceExport(path, state)
+		if err != nil {
+			return err
+		}
+		defer export.close()
+
+		exported, err := exportSourceTypes(ctx, client, export, sourceTypes)
+		if err != nil {
+			return fmt.Errorf("export interrupted after %d of %d source type(s): %w\nRerun with --resume to continue", exported, len(sourceTypes), err)
+		}
+		if err := export.finish(); err != nil {
+			return err
+		}
+		fmt.Printf(glyphs("✅ Exported %d source type(s) to %s\n"), len(sourceTypes), path)
+		return nil
+	},
+}
+
+// exportProgress is the state of an unfinished export: the IDs of the
+// source types written so far and the size of the file after the last one
+type exportProgress struct {
+	done   map[string]bool
+	offset int64
+}
+
+// readExportProgress reads a progress file of "<sourceTypeId> <offset>"
+// lines, returning nil when there is none
+func readExportProgress(path string) (*exportProgress, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The file starts with the opening bracket of the array. Only complete
+	// lines count; an entry whose line was cut short is written again.
+	state := &exportProgress{done: make(map[string]bool), offset: 1}
+	lines := strings.Split(string(data), "\n")
+	for _, line := range lines[:len(lines)-1] {
+		id, offset, ok := strings.Cut(line, " ")
+		n, err := strconv.ParseInt(offset, 10, 64)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("invalid export progress file %s: %q", path, line)
+		}
+		state.done[id] = true
+		state.offset = n
+	}
+	return state, nil
+}
+
+// sourceExport is an export file being written together with its progress file
+type sourceExport struct {
+	file     *os.File
+	progress *os.File
+	offset   int64
+	state    *exportProgress
+}
+
+// openSourceExport starts a new export to path, or continues the one
+// described by state, dropping anything written after its last entry
+func openSourceExport(path string, state *exportProgress) (*sourceExport, error) {
+	e := &sourceExport{state: state}
+	var err error
+	if state == nil {
+		e.state = &exportProgress{done: make(map[string]bool), offset: 1}
+		if e.file, err = os.Create(path); err != nil {
+			return nil, err
+		}
+		if _, err := e.file.WriteString("["); err != nil {
+			e.file.Close()
+			return nil, err
+		}
+	} else {
+		if e.file, err = os.OpenFile(path, os.O_RDWR, 0); err != nil {
+			return nil, fmt.Errorf("cannot resume export: %w", err)
+		}
+		if err := e.file.Truncate(state.offset); err != nil {
+			e.file.Close()
+			return nil, err
+		}
+		if _, err := e.file.Seek(state.offset, 0); err != nil {
+			e.file.Close()
+			return nil, err
+		}
+	}
+	e.offset = e.state.offset
+
+	e.progress, err = os.OpenFile(path+exportProgressSuffix, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		e.file.Close()
+		return nil, err
+	}
+	return e, nil
+}
+
+// write appends a source type to the array and records it as exported
+// once it is on disk
+func (e *sourceExport) write(st *SourceType) error {
+	data, err := json.MarshalIndent(st, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	separator := ",\n  "
+	if e.offset == 1 {
+		separator = "\n  "
+	}
+	n, err := e.file.WriteString(separator + string(data))
+	if err != nil {
+		return err
+	}
+	if err := e.file.Sync(); err != nil {
+		return err
+	}
+	e.offset += int64(n)
+
+	if _, err := fmt.Fprintf(e.progress, "%s %d\n", st.SourceTypeID, e.offset); err != nil {
+		return err
+	}
+	e.state.done[st.SourceTypeID] = true
+	return e.progress.Sync()
+}
+
+// finish closes the array and removes the progress file
+func (e *sourceExport) finish() error {
+	if _, err := e.file.WriteString("\n]\n"); err != nil {
+		return err
+	}
+	if err := e.file.Close(); err != nil {
+		return err
+	}
+	e.progress.Close()
+	return os.Remove(e.progress.Name())
+}
+
+// close releases the files of an export that did not finish
+func (e *sourceExport) close() {
+	e.file.Close()
+	e.progress.Close()
+}
+
+// exportSourceTypes fetches and writes the source types not yet exported,
+// retrying transient failures, and returns how many are in the file
+func exportSourceTypes(ctx context.Context, client *APIClient, export *sourceExport, sourceTypes []SourceType) (int, error) {
+	var remaining []SourceType
+	for _, st := range sourceTypes {
+		if !export.state.done[st.SourceTypeID] {
+			remaining = append(remaining, st)
+		}
+	}
+	exported := len(sourceTypes) - len(remaining)
+	if exported > 0 {
+		fmt.Printf("Resuming: %d of %d source type(s) already exported\n", exported, len(sourceTypes))
+	}
+
+	progress := newBatchProgress(len(remaining))
+	defer progress.finish()
+	for _, st := range remaining {
+		var full *SourceType
+		err := retryWithBackoff(ctx, defaultRetryAttempts, defaultRetryDelay, func() error {
+			var err error
+			full, err = client.GetSourceType(ctx, st.SourceTypeID)
+			return err
+		})
+		if err == nil {
+			err = export.write(full)
+		}
+		progress.add(err == nil)
+		if err != nil {
+			return exported, fmt.Errorf("%s: %w", st.TypeName, err)
+		}
+		exported++
+	}
+	return exported, nil
+}
+
+func init() {
+	sourceExportCmd.Flags().BoolVar(&sourceExportResume, "resume", false, "Continue an interrupted export, skipping the source types already written")
+	sourceExportCmd.Flags().StringArrayVar(&sourceExportTags, "tag", nil, "Only export source types with this tag (key=value, repeatable)")
+	sourceExportCmd.Flags().StringVar(&sourceExportSearch, "search", "", "Only export source types whose name, display name or description contains this term")
+	sourceExportFilter.addFlags(sourceExportCmd)
+
+	sourceCmd.AddCommand(sourceExportCmd)
+}
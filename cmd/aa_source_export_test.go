@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestExportInterruptAndResume(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv(tokenEnvVar, "")
+
+	var mu sync.Mutex
+	var fetched []string
+	failing := "c"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := path.Base(r.URL.Path)
+		mu.Lock()
+		fetched = append(fetched, id)
+		fail := id == failing
+		mu.Unlock()
+		if fail {
+			// Not transient, so the export stops at once
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(SourceType{SourceTypeID: id, TypeName: "TYPE_" + id})
+	}))
+	defer server.Close()
+	client := NewAPIClient(server.URL)
+
+	var sourceTypes []SourceType
+	for _, id := range []string{"a", "b", "c", "d"} {
+		sourceTypes = append(sourceTypes, SourceType{SourceTypeID: id, TypeName: "TYPE_" + id})
+	}
+	file := filepath.Join(t.TempDir(), "export.json")
+	progressPath := file + exportProgressSuffix
+
+	export, err := openSourceExport(file, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := exportSourceTypes(context.Background(), client, export, sourceTypes)
+	export.close()
+	if err == nil || exported != 2 {
+		t.Fatalf("first run = %d, %v, want 2 exported and an error", exported, err)
+	}
+
+	// An interruption in the middle of a write leaves a partial entry and
+	// a partial progress line behind
+	appendTestFile(t, file, ",\n  {\"sourceTypeId\": \"c\"")
+	appendTestFile(t, progressPath, "c 999")
+
+	state, err := readExportProgress(progressPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.done) != 2 || !state.done["a"] || !state.done["b"] {
+		t.Fatalf("progress = %v, want a and b done", state.done)
+	}
+
+	mu.Lock()
+	failing = ""
+	fetched = nil
+	mu.Unlock()
+	export, err = openSourceExport(file, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err = exportSourceTypes(context.Background(), client, export, sourceTypes)
+	if err != nil || exported != 4 {
+		t.Fatalf("resumed run = %d, %v, want 4 exported", exported, err)
+	}
+	if err := export.finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fetched) != 2 || fetched[0] != "c" || fetched[1] != "d" {
+		t.Errorf("resumed run fetched %v, want only c and d", fetched)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result []SourceType
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("export is not a JSON array: %v\n%s", err, data)
+	}
+	if len(result) != 4 {
+		t.Fatalf("export holds %d source types, want 4:\n%s", len(result), data)
+	}
+	for i, id := range []string{"a", "b", "c", "d"} {
+		if result[i].SourceTypeID != id {
+			t.Errorf("entry %d = %s, want %s", i, result[i].SourceTypeID, id)
+		}
+	}
+	if _, err := os.Stat(progressPath); !os.IsNotExist(err) {
+		t.Errorf("progress file still exists after the export finished: %v", err)
+	}
+}
+
+func TestReadExportProgressInvalid(t *testing.T) {
+	progressPath := filepath.Join(t.TempDir(), "export.json"+exportProgressSuffix)
+	if state, err := readExportProgress(progressPath); state != nil || err != nil {
+		t.Errorf("readExportProgress(missing) = %v, %v, want nil, nil", state, err)
+	}
+	writeTestFile(t, progressPath, "a 10\nnot-an-offset\n")
+	if _, err := readExportProgress(progressPath); err == nil {
+		t.Error("readExportProgress() accepted a malformed line")
+	}
+}
+
+func appendTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+}
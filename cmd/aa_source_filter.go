@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// sourceTypeFilter selects source types by their active and built-in flags.
+// Flags on different properties combine, e.g. --active --custom.
+type sourceTypeFilter struct {
+	Active   bool
+	Inactive bool
+	BuiltIn  bool
+	Custom   bool
+}
+
+// addFlags registers the --active, --inactive, --built-in and --custom flags
+func (f *sourceTypeFilter) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.Active, "active", false, "Only active source types")
+	cmd.Flags().BoolVar(&f.Inactive, "inactive", false, "Only inactive source types")
+	cmd.Flags().BoolVar(&f.BuiltIn, "built-in", false, "Only built-in source types")
+	cmd.Flags().BoolVar(&f.Custom, "custom", false, "Only custom (not built-in) source types")
+}
+
+// validate rejects flags that exclude each other
+func (f *sourceTypeFilter) validate() error {
+	if f.Active && f.Inactive {
+		return fmt.Errorf("--active and --inactive cannot be combined")
+	}
+	if f.BuiltIn && f.Custom {
+		return fmt.Errorf("--built-in and --custom cannot be combined")
+	}
+	return nil
+}
+
+// matches reports whether a source type passes the filter
+func (f *sourceTypeFilter) matches(st *SourceType) bool {
+	return (!f.Active || st.IsActive) &&
+		(!f.Inactive || !st.IsActive) &&
+		(!f.BuiltIn || st.IsBuiltIn) &&
+		(!f.Custom || !st.IsBuiltIn)
+}
+
+// apply returns the source types that pass the filter
+func (f *sourceTypeFilter) apply(sourceTypes []SourceType) []SourceType {
+	if *f == (sourceTypeFilter{}) {
+		return sourceTypes
+	}
+	matching := make([]SourceType, 0, len(sourceTypes))
+	for i := range sourceTypes {
+		if f.matches(&sourceTypes[i]) {
+			matching = append(matching, sourceTypes[i])
+		}
+	}
+	return matching
+}
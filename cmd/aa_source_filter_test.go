@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSourceTypeFilterCombinations(t *testing.T) {
+	sourceTypes := []SourceType{
+		{TypeName: "ACTIVE_BUILTIN", IsActive: true, IsBuiltIn: true},
+		{TypeName: "ACTIVE_CUSTOM", IsActive: true},
+		{TypeName: "INACTIVE_BUILTIN", IsBuiltIn: true},
+		{TypeName: "INACTIVE_CUSTOM"},
+	}
+	tests := []struct {
+		flags []string
+		want  string
+	}{
+		{nil, "ACTIVE_BUILTIN ACTIVE_CUSTOM INACTIVE_BUILTIN INACTIVE_CUSTOM"},
+		{[]string{"--active"}, "ACTIVE_BUILTIN ACTIVE_CUSTOM"},
+		{[]string{"--inactive"}, "INACTIVE_BUILTIN INACTIVE_CUSTOM"},
+		{[]string{"--built-in"}, "ACTIVE_BUILTIN INACTIVE_BUILTIN"},
+		{[]string{"--custom"}, "ACTIVE_CUSTOM INACTIVE_CUSTOM"},
+		{[]string{"--active", "--built-in"}, "ACTIVE_BUILTIN"},
+		{[]string{"--active", "--custom"}, "ACTIVE_CUSTOM"},
+		{[]string{"--inactive", "--built-in"}, "INACTIVE_BUILTIN"},
+		{[]string{"--inactive", "--custom"}, "INACTIVE_CUSTOM"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.flags, " "), func(t *testing.T) {
+			var filter sourceTypeFilter
+			cmd := &cobra.Command{}
+			filter.addFlags(cmd)
+			if err := cmd.ParseFlags(tt.flags); err != nil {
+				t.Fatal(err)
+			}
+			if err := filter.validate(); err != nil {
+				t.Fatalf("validate() = %v", err)
+			}
+
+			var names []string
+			for _, st := range filter.apply(sourceTypes) {
+				names = append(names, st.TypeName)
+				if !filter.matches(&st) {
+					t.Errorf("apply() kept %s, which matches() rejects", st.TypeName)
+				}
+			}
+			if got := strings.Join(names, " "); got != tt.want {
+				t.Errorf("apply() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSourceTypeFilterContradictions(t *testing.T) {
+	tests := []struct {
+		filter sourceTypeFilter
+		want   string
+	}{
+		{sourceTypeFilter{Active: true, Inactive: true}, "--active and --inactive cannot be combined"},
+		{sourceTypeFilter{BuiltIn: true, Custom: true}, "--built-in and --custom cannot be combined"},
+		{sourceTypeFilter{Active: true, Inactive: true, BuiltIn: true, Custom: true}, "--active and --inactive"},
+	}
+	for _, tt := range tests {
+		if err := tt.filter.validate(); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("validate(%+v) = %v, want %q", tt.filter, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	sourceGetIncludeSpec bool
+	sourceGetNoSpec      bool
+)
+
+var sourceGetCmd = &cobra.Command{
+	Use:   "get <name>",
+	Short: "Print a registered source type as JSON",
+	Long: `Print a registered source type, by type name or ID, as JSON.
+
+The embedded scanner specification is the bulk of the response. When only
+the metadata is needed, --include-spec=false (or --no-spec) asks the API to
+leave it out; if the API sends it anyway it is dropped from the output.
+
+  nwx aa source get my-scanner --no-spec`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+
+		var sourceType *SourceType
+		if sourceGetIncludeSpec && !sourceGetNoSpec {
+			sourceType, err = client.FindSourceType(cmd.Context(), args[0])
+		} else {
+			sourceType, err = client.FindSourceTypeMetadata(cmd.Context(), args[0])
+		}
+		if err != nil {
+			return err
+		}
+		return printJSON(sourceType)
+	},
+}
+
+func init() {
+	sourceGetCmd.Flags().BoolVar(&sourceGetIncludeSpec, "include-spec", true, "Include the embedded scanner specification")
+	sourceGetCmd.Flags().BoolVar(&sourceGetNoSpec, "no-spec", false, "Leave out the embedded scanner specification (same as --include-spec=false)")
+
+	sourceCmd.AddCommand(sourceGetCmd)
+}
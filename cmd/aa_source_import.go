@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	sourceImportConcurrency   int
+	sourceImportContinueOnErr bool
+	sourceImportVerifyImage   bool
+	sourceImportMaintainer    string
+)
+
+// serverManagedSourceTypeFields are dropped from imported definitions since
+// the API assigns them
+var serverManagedSourceTypeFields = []string{"sourceTypeId", "createdAt", "updatedAt", "isBuiltIn"}
+
+var sourceImportCmd = &cobra.Command{
+	Use:   "import <file>...",
+	Short: "Register source types from JSON files",
+	Long: `Register the source types defined in JSON files. A file holds one source
+type definition, such as the <name>-source-type.json generated by
+'nwx aa scanner create', or an array of them, such as the output of
+'nwx aa source list -o json'. A scannerSpecification of the form
+{"$ref": "scannerSpecification.json"} is read relative to the file.
+
+Entries are registered --concurrency at a time with a progress bar. By
+default no new entry is started after the first failure, while requests
+already sent are left to finish; with --continue-on-error every entry is
+attempted. Failures are listed at the end and make the
+command exit non-zero.
+
+With --verify-image the scannerImage of every entry is looked up in its
+container registry (a registry v2 manifest HEAD) first, and nothing is
+registered if an image is missing. Images without a registry host are
+looked up in the registry key (default docker.io). The check is anonymous
+unless $NWX_REGISTRY_TOKEN or the registry-token key holds a token. If a
+registry cannot be checked, a warning is shown and the import goes on.
+
+--maintainer sets the maintainer of every entry, replacing the one in the
+file, e.g. --maintainer @org/team or --maintainer owner@example.com.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if sourceImportConcurrency < 1 {
+			return fmt.Errorf("invalid --concurrency %d (must be at least 1)", sourceImportConcurrency)
+		}
+		if err := validateMaintainer(sourceImportMaintainer); err != nil {
+			return err
+		}
+
+		var entries []importEntry
+		for _, path := range args {
+			fileEntries, err := readImportFile(path)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, fileEntries...)
+		}
+		if len(entries) == 0 {
+			fmt.Println("No source types to import")
+			return nil
+		}
+		if sourceImportMaintainer != "" {
+			maintainer, _ := json.Marshal(sourceImportMaintainer)
+			for _, entry := range entries {
+				entry.Definition["maintainer"] = maintainer
+			}
+		}
+
+		if sourceImportVerifyImage {
+			if err := verifyImportImages(cmd.Context(), entries); err != nil {
+				return err
+			}
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+
+		results := importSourceTypes(cmd.Context(), client, entries, sourceImportConcurrency, sourceImportContinueOnErr)
+		return reportImport(results)
+	},
+}
+
+// importEntry is a single source type definition read from an import file
+type importEntry struct {
+	Name       string
+	Definition map[string]json.RawMessage
+}
+
+// importResult is the outcome of registering one entry. Entries skipped
+// after a failure in fail-fast mode have neither Err nor Done set.
+type importResult struct {
+	Entry importEntry
+	Done  bool
+	Err   error
+}
+
+// readImportFile reads the source type definitions in path
+func readImportFile(path string) ([]importEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var definitions []map[string]json.RawMessage
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal(data, &definitions)
+	} else {
+		var definition map[string]json.RawMessage
+		err = json.Unmarshal(data, &definition)
+		definitions = append(definitions, definition)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid import file %s: %w", path, err)
+	}
+
+	entries := make([]importEntry, 0, len(definitions))
+	for i, definition := range definitions {
+		if definition == nil {
+			return nil, fmt.Errorf("invalid import file %s: entry %d is not an object", path, i+1)
+		}
+		for _, field := range serverManagedSourceTypeFields {
+			delete(definition, field)
+		}
+		if err := resolveSpecRef(definition, filepath.Dir(path)); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		entries = append(entries, importEntry{
+			Name:       importEntryName(definition, fmt.Sprintf("%s #%d", path, i+1)),
+			Definition: definition,
+		})
+	}
+	return entries, nil
+}
+
+// resolveSpecRef replaces a {"$ref": "<file>"} scanner specification with
+// the contents of that file, relative to dir
+func resolveSpecRef(definition map[string]json.RawMessage, dir string) error {
+	var ref struct {
+		Ref string `json:"$ref"`
+	}
+	raw, ok := definition["scannerSpecification"]
+	if !ok || json.Unmarshal(raw, &ref) != nil || ref.Ref == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, ref.Ref))
+	if err != nil {
+		return fmt.Errorf("scannerSpecification: %w", err)
+	}
+	if _, err := parseSpec(data); err != nil {
+		return err
+	}
+	definition["scannerSpecification"] = data
+	return nil
+}
+
+// importEntryName returns the name an entry is reported by
+func importEntryName(definition map[string]json.RawMessage, fallback string) string {
+	for _, field := range []string{"typeName", "displayName"} {
+		var name string
+		if json.Unmarshal(definition[field], &name) == nil && name != "" {
+			return name
+		}
+	}
+	return fallback
+}
+
+// verifyImportImages checks that the scanner image of every entry exists,
+// returning an error listing the missing ones
+func verifyImportImages(ctx context.Context, entries []importEntry) error {
+	registry, err := newRegistryClient()
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	checked := make(map[string]bool)
+	for _, entry := range entries {
+		var image string
+		if json.Unmarshal(entry.Definition["scannerImage"], &image) != nil || image == "" {
+			fmt.Printf(glyphs("⚠️  %s has no scannerImage to verify\n"), entry.Name)
+			continue
+		}
+		if checked[image] {
+			continue
+		}
+		checked[image] = true
+
+		exists, err := registry.imageExists(ctx, image)
+		switch {
+		case err != nil:
+			fmt.Printf(glyphs("⚠️  Could not verify image %s: %v\n"), image, err)
+		case exists:
+			fmt.Printf(glyphs("✅ Image %s exists\n"), image)
+		default:
+			fmt.Printf(glyphs("❌ Image %s not found (used by %s)\n"), image, entry.Name)
+			missing = append(missing, image)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%d scanner image(s) not found in the registry; push them before importing (e.g. 'nwx aa scanner build --push')", len(missing))
+	}
+	return nil
+}
+
+// importSourceTypes registers entries with up to concurrency requests in
+// flight, showing progress on stderr. Without continueOnError the first
+// failure stops new entries from being started; requests already in flight
+// are left to finish and their real outcome is reported.
+func importSourceTypes(ctx context.Context, client *APIClient, entries []importEntry, concurrency int, continueOnError bool) []importResult {
+	results := make([]importResult, len(entries))
+	for i := range entries {
+		results[i].Entry = entries[i]
+	}
+	progress := newBatchProgress(len(entries))
+	jobs := make(chan int)
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+
+	stopped := func() bool {
+		select {
+		case <-stop:
+			return true
+		default:
+			return ctx.Err() != nil
+		}
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Taken after the stop but not started yet: skipped
+				if stopped() {
+					continue
+				}
+				_, err := client.CreateSourceType(ctx, entries[i].Definition)
+				results[i].Done = err == nil
+				results[i].Err = err
+				progress.add(err == nil)
+				if err != nil && !continueOnError {
+					stopOnce.Do(func() { close(stop) })
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for i := range entries {
+		select {
+		case jobs <- i:
+		case <-stop:
+			break dispatch
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	progress.finish()
+
+	return results
+}
+
+// batchProgress draws a progress bar of a batch of API requests on stderr
+// when it is a terminal
+type batchProgress struct {
+	mu        sync.Mutex
+	total     int
+	succeeded int
+	failed    int
+	draw      bool
+}
+
+func newBatchProgress(total int) *batchProgress {
+	p := &batchProgress{total: total, draw: isTerminal(os.Stderr)}
+	p.render()
+	return p
+}
+
+// add records the outcome of one entry and redraws the bar
+func (p *batchProgress) add(ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ok {
+		p.succeeded++
+	} else {
+		p.failed++
+	}
+	p.render()
+}
+
+func (p *batchProgress) render() {
+	if !p.draw || p.total == 0 {
+		return
+	}
+	const width = 30
+	done := p.succeeded + p.failed
+	filled := width * done / p.total
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+	fmt.Fprintf(os.Stderr, glyphs("\r\033[2K[%s] %d/%d  ✅ %d  ❌ %d"), bar, done, p.total, p.succeeded, p.failed)
+}
+
+// finish ends the progress line
+func (p *batchProgress) finish() {
+	if p.draw {
+		fmt.Fprintln(os.Stderr)
+	}
+}
+
+// reportImport prints the import summary and returns an error if any entry
+// failed or was skipped
+func reportImport(results []importResult) error {
+	var succeeded, skipped int
+	var failures []importResult
+	for _, r := range results {
+		switch {
+		case r.Done:
+			succeeded++
+		case r.Err != nil:
+			failures = append(failures, r)
+		default:
+			skipped++
+		}
+	}
+
+	fmt.Printf(glyphs("✅ Imported %d of %d source type(s)\n"), succeeded, len(results))
+	for _, r := range failures {
+		fmt.Printf(glyphs("  ❌ %s: %v\n"), r.Entry.Name, r.Err)
+	}
+	if skipped > 0 {
+		fmt.Printf(glyphs("  ⚠️  %d skipped after the first failure (use --continue-on-error to attempt all)\n"), skipped)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to import %d of %d source type(s)", len(failures), len(results))
+	}
+	return nil
+}
+
+func init() {
+	sourceImportCmd.Flags().IntVar(&sourceImportConcurrency, "concurrency", 4, "Number of source types registered at the same time")
+	sourceImportCmd.Flags().BoolVar(&sourceImportContinueOnErr, "continue-on-error", false, "Attempt every entry instead of stopping at the first failure")
+	sourceImportCmd.Flags().StringVar(&sourceImportMaintainer, "maintainer", "", "Set the maintainer of every entry (an email address or a handle such as @org/team)")
+	sourceImportCmd.Flags().BoolVar(&sourceImportVerifyImage, "verify-image", false, "Check that each scanner image exists in its registry before registering anything")
+
+	sourceCmd.AddCommand(sourceImportCmd)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// importTestEntries returns entries whose definitions hold only their names
+func importTestEntries(names ...string) []importEntry {
+	entries := make([]importEntry, len(names))
+	for i, name := range names {
+		entries[i] = importEntry{
+			Name:       name,
+			Definition: map[string]json.RawMessage{"typeName": json.RawMessage(`"` + name + `"`)},
+		}
+	}
+	return entries
+}
+
+func TestImportFailFastLetsInFlightRequestsFinish(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv(tokenEnvVar, "")
+
+	failed := make(chan struct{})
+	var mu sync.Mutex
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			TypeName string `json:"typeName"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		posted = append(posted, body.TypeName)
+		mu.Unlock()
+
+		if body.TypeName == "BAD" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"invalid definition"}`))
+			close(failed)
+			return
+		}
+		// The slow requests are still in flight when BAD fails
+		<-failed
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"typeName":"` + body.TypeName + `"}`))
+	}))
+	defer server.Close()
+
+	entries := importTestEntries("SLOW_1", "SLOW_2", "BAD", "LATER_1", "LATER_2")
+	results := importSourceTypes(context.Background(), NewAPIClient(server.URL), entries, 3, false)
+
+	for _, r := range results {
+		switch r.Entry.Name {
+		case "SLOW_1", "SLOW_2":
+			if !r.Done || r.Err != nil {
+				t.Errorf("%s: done=%v err=%v, want the in-flight request to complete", r.Entry.Name, r.Done, r.Err)
+			}
+		case "BAD":
+			if r.Done || r.Err == nil {
+				t.Errorf("BAD: done=%v err=%v, want the API error", r.Done, r.Err)
+			}
+		default:
+			if r.Done || r.Err != nil {
+				t.Errorf("%s: done=%v err=%v, want it skipped", r.Entry.Name, r.Done, r.Err)
+			}
+		}
+	}
+	if len(posted) != 3 {
+		t.Errorf("posted %v, want only the first 3 entries", posted)
+	}
+	if err := reportImport(results); err == nil {
+		t.Error("reportImport() = nil after a failure")
+	}
+}
+
+func TestImportContinueOnError(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv(tokenEnvVar, "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			TypeName string `json:"typeName"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.TypeName == "BAD" {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	entries := importTestEntries("A", "BAD", "B", "C")
+	results := importSourceTypes(context.Background(), NewAPIClient(server.URL), entries, 2, true)
+	done := 0
+	for _, r := range results {
+		if r.Done {
+			done++
+		} else if r.Entry.Name != "BAD" || r.Err == nil {
+			t.Errorf("%s: done=%v err=%v", r.Entry.Name, r.Done, r.Err)
+		}
+	}
+	if done != 3 {
+		t.Errorf("%d entries imported, want 3", done)
+	}
+}
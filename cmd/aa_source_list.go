@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	sourceListOutput   string
+	sourceListFields   string
+	sourceListTags     []string
+	sourceListSort     string
+	sourceListTemplate string
+	sourceListFilter   sourceTypeFilter
+)
+
+// defaultSourceTypeFields are the columns shown by 'aa source list' without --fields
+var defaultSourceTypeFields = []string{"typeName", "displayName", "version", "isActive", "isBuiltIn", "maintainer"}
+
+// sourceTypeFields are the SourceType fields that can be listed. The
+// embedded specification is not listable.
+var sourceTypeFields = newRecordFields(reflect.TypeOf(SourceType{}), "scannerSpecification")
+
+var sourceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered source types",
+	Long: `List the source types registered in Access Analyzer.
+
+--fields selects the columns of table and csv output and their order, using
+the JSON field names of a source type, e.g. --fields typeName,version,isActive.
+Add the tags column with --fields typeName,version,tags.
+
+--tag key=value lists only the source types carrying that tag; repeat it to
+require several. Tags are set with 'nwx aa source tags'.
+
+--active, --inactive, --built-in and --custom list only the source types
+with those properties; combine them to narrow the list further, e.g.
+--active --custom.
+
+--sort orders the list by name, version or createdAt; prefix the key with
+'-' for descending order (e.g. --sort=-version). Versions are compared
+numerically, so 10.0.0 sorts after 9.0.0.
+
+--output template prints each source type with the Go template given in
+--template, e.g. --template '{{.TypeName}} {{.Version}}'. The fields are
+SourceTypeID, TypeName, DisplayName, Description, Version, ScannerImage,
+IsActive, IsBuiltIn, CreatedAt, UpdatedAt, SupportedScans, Icon,
+Maintainer and Tags
+(a map, e.g. {{index .Tags "team"}}). 'join' formats a list
+({{join .SupportedScans ","}}) and 'json' any value as JSON.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(sourceListOutput, outputTable, outputJSON, outputCSV, outputTemplate); err != nil {
+			return err
+		}
+		tmpl, err := parseOutputTemplate(sourceListOutput, sourceListTemplate, SourceType{})
+		if err != nil {
+			return err
+		}
+
+		fields := defaultSourceTypeFields
+		if sourceListFields != "" {
+			fields = parseFieldList(sourceListFields)
+		}
+		if err := sourceTypeFields.validate(fields); err != nil {
+			return err
+		}
+		if err := sourceListFilter.validate(); err != nil {
+			return err
+		}
+		tagFilter, err := parseTagAssignments(sourceListTags)
+		if err != nil {
+			return err
+		}
+		less, err := sourceTypeOrder(sourceListSort)
+		if err != nil {
+			return err
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		sourceTypes, err := client.GetAllSourceTypes(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if err := applyLocalSourceTags(sourceTypes); err != nil {
+			return err
+		}
+		sourceTypes = filterByTags(sourceListFilter.apply(sourceTypes), tagFilter)
+		if less != nil {
+			sort.SliceStable(sourceTypes, func(i, j int) bool {
+				return less(&sourceTypes[i], &sourceTypes[j])
+			})
+		}
+
+		return runPaged(func() error {
+			if tmpl != nil {
+				records := make([]interface{}, len(sourceTypes))
+				for i := range sourceTypes {
+					records[i] = &sourceTypes[i]
+				}
+				return printTemplate(tmpl, records)
+			}
+			return printSourceTypes(sourceTypes, fields)
+		})
+	},
+}
+
+// printSourceTypes prints source types in the --output format, with the
+// given columns for table and csv output
+func printSourceTypes(sourceTypes []SourceType, fields []string) error {
+	if sourceListOutput == outputJSON {
+		if sourceTypes == nil {
+			sourceTypes = []SourceType{}
+		}
+		return printJSON(sourceTypes)
+	}
+
+	rows := make([][]string, 0, len(sourceTypes))
+	for i := range sourceTypes {
+		rows = append(rows, sourceTypeFields.row(&sourceTypes[i], fields))
+	}
+
+	headers := fields
+	if sourceListOutput == outputTable {
+		headers = make([]string, len(fields))
+		for i, f := range fields {
+			headers[i] = strings.ToUpper(f)
+		}
+	}
+	return printRows(sourceListOutput, headers, rows)
+}
+
+// sourceTypeSortKeys are the --sort keys and how each orders two source types
+var sourceTypeSortKeys = map[string]func(a, b *SourceType) int{
+	"name":      func(a, b *SourceType) int { return strings.Compare(a.TypeName, b.TypeName) },
+	"version":   func(a, b *SourceType) int { return compareVersions(a.Version, b.Version) },
+	"createdAt": func(a, b *SourceType) int { return strings.Compare(a.CreatedAt, b.CreatedAt) },
+}
+
+// sourceTypeOrder parses a --sort value such as "version" or "-createdAt"
+// and returns the matching less function, or nil when value is empty
+func sourceTypeOrder(value string) (func(a, b *SourceType) bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	key := strings.TrimPrefix(value, "-")
+	descending := key != value
+
+	compare, ok := sourceTypeSortKeys[key]
+	if !ok {
+		return nil, fmt.Errorf("invalid sort key '%s' (expected name, version or createdAt, optionally prefixed with '-')", value)
+	}
+	if descending {
+		return func(a, b *SourceType) bool { return compare(a, b) > 0 }, nil
+	}
+	return func(a, b *SourceType) bool { return compare(a, b) < 0 }, nil
+}
+
+// compareVersions compares two dotted versions such as 1.10.0 and 1.9.2,
+// returning -1, 0 or 1. Numeric parts are compared as numbers and missing
+// parts count as zero. A pre-release (1.0.0-beta) sorts before its release.
+func compareVersions(a, b string) int {
+	aRelease, aPre, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	bRelease, bPre, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+
+	aParts := strings.Split(aRelease, ".")
+	bParts := strings.Split(bRelease, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		aPart, bPart := "0", "0"
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+
+		aNum, aErr := strconv.Atoi(aPart)
+		bNum, bErr := strconv.Atoi(bPart)
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				if aNum < bNum {
+					return -1
+				}
+				return 1
+			}
+		case aPart != bPart:
+			return strings.Compare(aPart, bPart)
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return strings.Compare(aPre, bPre)
+}
+
+func init() {
+	sourceListCmd.Flags().StringVarP(&sourceListOutput, "output", "o", outputTable, "Output format (table|json|csv|template)")
+	sourceListCmd.Flags().StringVar(&sourceListTemplate, "template", "", "Go template printed for each source type with --output template")
+	sourceListCmd.Flags().StringArrayVar(&sourceListTags, "tag", nil, "Only list source types with this tag (key=value, repeatable)")
+	sourceListCmd.Flags().StringVar(&sourceListSort, "sort", "", "Sort by name, version or createdAt; prefix with '-' for descending")
+	sourceListCmd.Flags().StringVar(&sourceListFields, "fields", "", "Comma-separated fields for table and csv output (default "+strings.Join(defaultSourceTypeFields, ",")+")")
+	sourceListFilter.addFlags(sourceListCmd)
+
+	sourceCmd.AddCommand(sourceListCmd)
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"10.0.0", "9.0.0", 1},
+		{"1.9.2", "1.10.0", -1},
+		{"1.2", "1.2.0", 0},
+		{"1.2.1", "1.2", 1},
+		{"v2.0.0", "2.0.0", 0},
+		{"1.0.0-beta", "1.0.0", -1},
+		{"1.0.0", "1.0.0-rc.1", 1},
+		{"1.0.0-alpha", "1.0.0-beta", -1},
+		{"1.x.0", "1.y.0", -1},
+		{"", "0.0.1", -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := compareVersions(tt.b, tt.a); got != -tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+	}
+}
+
+func TestSourceTypeOrder(t *testing.T) {
+	sourceTypes := []SourceType{
+		{TypeName: "B_SCANNER", Version: "10.0.0", CreatedAt: "2025-01-02T00:00:00Z"},
+		{TypeName: "A_SCANNER", Version: "9.1.0", CreatedAt: "2025-01-03T00:00:00Z"},
+		{TypeName: "C_SCANNER", Version: "9.1.0-beta", CreatedAt: "2025-01-01T00:00:00Z"},
+	}
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"name", []string{"A_SCANNER", "B_SCANNER", "C_SCANNER"}},
+		{"-name", []string{"C_SCANNER", "B_SCANNER", "A_SCANNER"}},
+		{"version", []string{"C_SCANNER", "A_SCANNER", "B_SCANNER"}},
+		{"-version", []string{"B_SCANNER", "A_SCANNER", "C_SCANNER"}},
+		{"createdAt", []string{"C_SCANNER", "B_SCANNER", "A_SCANNER"}},
+		{"-createdAt", []string{"A_SCANNER", "B_SCANNER", "C_SCANNER"}},
+	}
+	for _, tt := range tests {
+		less, err := sourceTypeOrder(tt.sort)
+		if err != nil {
+			t.Fatalf("sourceTypeOrder(%q): %v", tt.sort, err)
+		}
+		sorted := append([]SourceType(nil), sourceTypes...)
+		sort.SliceStable(sorted, func(i, j int) bool { return less(&sorted[i], &sorted[j]) })
+		var names []string
+		for _, st := range sorted {
+			names = append(names, st.TypeName)
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("--sort %s = %v, want %v", tt.sort, names, tt.want)
+		}
+	}
+
+	if less, err := sourceTypeOrder(""); less != nil || err != nil {
+		t.Errorf("sourceTypeOrder(\"\") = %v, %v, want no ordering", less != nil, err)
+	}
+	for _, value := range []string{"size", "--name", "Name"} {
+		if _, err := sourceTypeOrder(value); err == nil {
+			t.Errorf("sourceTypeOrder(%q) accepted an invalid key", value)
+		}
+	}
+}
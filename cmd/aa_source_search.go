@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var sourceSearchOutput string
+
+// searchMatchStyle highlights the matched text in search results
+var searchMatchStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F59E0B"))
+
+var sourceSearchCmd = &cobra.Command{
+	Use:   "search <term>",
+	Short: "Search source types by name, display name and description",
+	Long: `List the registered source types whose type name, display name or
+description contains term, ignoring case. In table output the matched text
+is highlighted when color is enabled.
+
+  nwx aa source search share
+  nwx aa source search "file server" -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(sourceSearchOutput, outputTable, outputJSON); err != nil {
+			return err
+		}
+		term := strings.TrimSpace(args[0])
+		if term == "" {
+			return fmt.Errorf("search term cannot be empty")
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		sourceTypes, err := client.GetAllSourceTypes(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		matches := []SourceType{}
+		for _, st := range sourceTypes {
+			if sourceTypeMatches(&st, term) {
+				matches = append(matches, st)
+			}
+		}
+
+		if sourceSearchOutput == outputJSON {
+			return printJSON(matches)
+		}
+		if len(matches) == 0 {
+			fmt.Printf("No source types match '%s'\n", term)
+			return nil
+		}
+
+		rows := make([][]string, 0, len(matches))
+		for _, st := range matches {
+			rows = append(rows, []string{st.TypeName, st.DisplayName, st.Version, truncateString(st.Description, 60)})
+		}
+		return runPaged(func() error {
+			printHighlightedTable([]string{"TYPENAME", "DISPLAYNAME", "VERSION", "DESCRIPTION"}, rows, term)
+			return nil
+		})
+	},
+}
+
+// sourceTypeMatches reports whether term occurs in the type name, display
+// name or description of st, ignoring case
+func sourceTypeMatches(st *SourceType, term string) bool {
+	term = strings.ToLower(term)
+	for _, s := range []string{st.TypeName, st.DisplayName, st.Description} {
+		if strings.Contains(strings.ToLower(s), term) {
+			return true
+		}
+	}
+	return false
+}
+
+// printHighlightedTable writes rows as aligned columns, highlighting each
+// occurrence of term. Widths are measured on the plain text so the escape
+// codes of the highlight don't break the alignment.
+func printHighlightedTable(headers []string, rows [][]string, term string) {
+	widths := make([]int, len(headers))
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	printRow := func(row []string, highlight bool) {
+		var b strings.Builder
+		for i, cell := range row {
+			text := cell
+			if highlight {
+				text = highlightMatches(cell, term)
+			}
+			b.WriteString(text)
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+			}
+		}
+		fmt.Println(b.String())
+	}
+
+	printRow(headers, false)
+	for _, row := range rows {
+		printRow(row, true)
+	}
+}
+
+// highlightMatches styles each case-insensitive occurrence of term in s.
+// Text whose length changes when lowercased is left as it is.
+func highlightMatches(s, term string) string {
+	lower, lowerTerm := strings.ToLower(s), strings.ToLower(term)
+	if len(lower) != len(s) || lowerTerm == "" {
+		return s
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, lowerTerm)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := i + len(lowerTerm)
+		b.WriteString(s[:i])
+		b.WriteString(searchMatchStyle.Render(s[i:end]))
+		s, lower = s[end:], lower[end:]
+	}
+}
+
+func init() {
+	sourceSearchCmd.Flags().StringVarP(&sourceSearchOutput, "output", "o", outputTable, "Output format (table|json)")
+
+	sourceCmd.AddCommand(sourceSearchCmd)
+}
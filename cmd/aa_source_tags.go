@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// sourceTagsFileName holds the client-side tags of every source type, keyed
+// by source type ID, in the Access Analyzer config directory
+const sourceTagsFileName = "source-tags.json"
+
+var (
+	sourceTagsRemove []string
+	sourceTagsOutput string
+)
+
+var sourceTagsCmd = &cobra.Command{
+	Use:   "tags <name> [key=value...]",
+	Short: "Show or set a source type's tags",
+	Long: `Show or set the tags of a source type, such as team or data domain, used
+to filter 'nwx aa source list' with --tag key=value.
+
+The API does not store tags, so they are kept in the local configuration,
+keyed by source type ID. Tags returned by the API, if any, are shown too;
+local tags take precedence.
+
+  nwx aa source tags file-scanner team=infra domain=files
+  nwx aa source tags file-scanner --remove domain
+  nwx aa source tags file-scanner`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(sourceTagsOutput, outputText, outputJSON); err != nil {
+			return err
+		}
+		assignments, err := parseTagAssignments(args[1:])
+		if err != nil {
+			return err
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		sourceType, err := client.FindSourceType(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		if len(assignments) > 0 || len(sourceTagsRemove) > 0 {
+			allTags, err := readLocalSourceTags()
+			if err != nil {
+				return err
+			}
+			tags := allTags[sourceType.SourceTypeID]
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			for key, value := range assignments {
+				tags[key] = value
+			}
+			for _, key := range sourceTagsRemove {
+				delete(tags, key)
+			}
+			if len(tags) == 0 {
+				delete(allTags, sourceType.SourceTypeID)
+			} else {
+				allTags[sourceType.SourceTypeID] = tags
+			}
+			if err := writeLocalSourceTags(allTags); err != nil {
+				return err
+			}
+		}
+
+		sourceTypes := []SourceType{*sourceType}
+		if err := applyLocalSourceTags(sourceTypes); err != nil {
+			return err
+		}
+		tags := sourceTypes[0].Tags
+
+		if sourceTagsOutput == outputJSON {
+			if tags == nil {
+				tags = map[string]string{}
+			}
+			return printJSON(tags)
+		}
+		if len(tags) == 0 {
+			fmt.Printf(glyphs("ℹ️  %s has no tags\n"), sourceType.TypeName)
+			return nil
+		}
+		fmt.Printf("Tags for %s (%s):\n", sourceType.TypeName, sourceType.SourceTypeID)
+		for _, key := range sortedStringKeys(tags) {
+			fmt.Printf("  %s=%s\n", key, tags[key])
+		}
+		return nil
+	},
+}
+
+// parseTagAssignments parses key=value arguments. Keys and values may not
+// contain commas, which separate tags in table and csv output.
+func parseTagAssignments(args []string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid tag '%s' (expected key=value)", arg)
+		}
+		if strings.Contains(arg, ",") {
+			return nil, fmt.Errorf("invalid tag '%s' (tags may not contain ',')", arg)
+		}
+		tags[key] = strings.TrimSpace(value)
+	}
+	return tags, nil
+}
+
+// matchesTags reports whether tags has every key=value pair in filter
+func matchesTags(tags, filter map[string]string) bool {
+	for key, value := range filter {
+		if actual, ok := tags[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// filterByTags returns the source types carrying every tag in filter
+func filterByTags(sourceTypes []SourceType, filter map[string]string) []SourceType {
+	if len(filter) == 0 {
+		return sourceTypes
+	}
+	matching := make([]SourceType, 0, len(sourceTypes))
+	for _, st := range sourceTypes {
+		if matchesTags(st.Tags, filter) {
+			matching = append(matching, st)
+		}
+	}
+	return matching
+}
+
+// getSourceTagsPath returns the path of the client-side tags file
+func getSourceTagsPath() (string, error) {
+	configDir, err := getAAConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, sourceTagsFileName), nil
+}
+
+// readLocalSourceTags returns the client-side tags of all source types
+func readLocalSourceTags() (map[string]map[string]string, error) {
+	path, err := getSourceTagsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	allTags := make(map[string]map[string]string)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return allTags, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &allTags); err != nil {
+		return nil, fmt.Errorf("invalid tags file %s: %w", path, err)
+	}
+	return allTags, nil
+}
+
+// writeLocalSourceTags saves the client-side tags of all source types
+func writeLocalSourceTags(allTags map[string]map[string]string) error {
+	configDir, err := getAAConfigDir()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(allTags, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeConfigFile(configDir, sourceTagsFileName, append(data, '\n'))
+}
+
+// applyLocalSourceTags merges the client-side tags into the tags returned
+// by the API, with local values taking precedence
+func applyLocalSourceTags(sourceTypes []SourceType) error {
+	allTags, err := readLocalSourceTags()
+	if err != nil {
+		return err
+	}
+	for i := range sourceTypes {
+		local := allTags[sourceTypes[i].SourceTypeID]
+		if len(local) == 0 {
+			continue
+		}
+		if sourceTypes[i].Tags == nil {
+			sourceTypes[i].Tags = make(map[string]string)
+		}
+		for key, value := range local {
+			sourceTypes[i].Tags[key] = value
+		}
+	}
+	return nil
+}
+
+func init() {
+	sourceTagsCmd.Flags().StringSliceVar(&sourceTagsRemove, "remove", nil, "Tag keys to remove (repeatable or comma-separated)")
+	sourceTagsCmd.Flags().StringVarP(&sourceTagsOutput, "output", "o", outputText, "Output format (text|json)")
+
+	sourceCmd.AddCommand(sourceTagsCmd)
+}
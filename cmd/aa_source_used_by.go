@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var sourceUsedByOutput string
+
+var sourceUsedByCmd = &cobra.Command{
+	Use:   "used-by <name>",
+	Short: "List the data sources that use a source type",
+	Long: `List the configured data sources that use a source type, by type name or
+ID. Check this before removing a source type so no live source is left
+without a scanner.
+
+If the API does not expose sources, a warning is printed and the command
+succeeds without a list.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(sourceUsedByOutput, outputTable, outputJSON, outputCSV); err != nil {
+			return err
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		sourceType, err := client.FindSourceType(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		sources, err := client.GetSourcesOfType(cmd.Context(), sourceType.SourceTypeID)
+		if isEndpointUnavailable(err) {
+			fmt.Fprintf(os.Stderr, glyphs("⚠️  The API does not expose sources, so the sources using %s can't be listed\n"), sourceType.TypeName)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch sourceUsedByOutput {
+		case outputJSON:
+			if sources == nil {
+				sources = []Source{}
+			}
+			return printJSON(sources)
+		case outputCSV:
+			return printRows(outputCSV, []string{"sourceId", "name"}, sourceRows(sources))
+		}
+
+		if len(sources) == 0 {
+			fmt.Printf(glyphs("✅ No sources use %s\n"), sourceType.TypeName)
+			return nil
+		}
+		fmt.Printf("%d source(s) use %s:\n", len(sources), sourceType.TypeName)
+		return printRows(outputTable, []string{"SOURCE ID", "NAME"}, sourceRows(sources))
+	},
+}
+
+// sourceRows returns the ID and name of each source as table rows
+func sourceRows(sources []Source) [][]string {
+	rows := make([][]string, 0, len(sources))
+	for _, source := range sources {
+		rows = append(rows, []string{source.SourceID, source.Name})
+	}
+	return rows
+}
+
+// isEndpointUnavailable reports whether a request failed because the API
+// has no such endpoint
+func isEndpointUnavailable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+func init() {
+	sourceUsedByCmd.Flags().StringVarP(&sourceUsedByOutput, "output", "o", outputTable, "Output format (table|json|csv)")
+
+	sourceCmd.AddCommand(sourceUsedByCmd)
+}
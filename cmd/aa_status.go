@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusWatch    bool
+	statusInterval time.Duration
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check the connection to Access Analyzer",
+	Long: `Check that the configured Access Analyzer endpoint is reachable.
+
+With --watch the check is repeated every --interval and a compact status
+line is redrawn until interrupted with Ctrl+C; the global --timeout does
+not apply. When stdout is not a terminal each check is appended as a new
+line instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+
+		if statusWatch {
+			if statusInterval <= 0 {
+				return fmt.Errorf("invalid --interval %s (must be positive)", statusInterval)
+			}
+			// Watching runs until interrupted, so --timeout does not apply
+			ctx, stop := signal.NotifyContext(context.WithoutCancel(cmd.Context()), os.Interrupt)
+			defer stop()
+			watchStatus(ctx, client, statusInterval)
+			return nil
+		}
+
+		fmt.Printf(glyphs("🔗 Endpoint: %s\n"), redactSecrets(client.BaseURL))
+		result := checkStatus(cmd.Context(), client)
+		if result.Err != nil {
+			return result.Err
+		}
+		fmt.Println(successStyle.Render(fmt.Sprintf(glyphs("✅ Connection successful (%s)"), formatLatency(result.Latency))))
+		return nil
+	},
+}
+
+// statusResult is the outcome of a single connection check
+type statusResult struct {
+	Endpoint  string
+	Latency   time.Duration
+	CheckedAt time.Time
+	Err       error
+}
+
+// checkStatus tests the connection and measures how long it took
+func checkStatus(ctx context.Context, client *APIClient) statusResult {
+	start := time.Now()
+	err := client.TestConnection(ctx)
+	return statusResult{
+		Endpoint:  client.BaseURL,
+		Latency:   time.Since(start),
+		CheckedAt: start,
+		Err:       err,
+	}
+}
+
+// watchStatus repeats the connection check every interval until ctx is done
+func watchStatus(ctx context.Context, client *APIClient, interval time.Duration) {
+	redraw := isTerminal(os.Stdout)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result := checkStatus(ctx, client)
+		if ctx.Err() != nil {
+			break
+		}
+		if redraw {
+			fmt.Print("\r\033[2K" + formatStatusLine(result))
+		} else {
+			fmt.Println(formatStatusLine(result))
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+			continue
+		}
+		break
+	}
+
+	if redraw {
+		fmt.Println()
+	}
+}
+
+// formatStatusLine renders a check as one compact line
+func formatStatusLine(r statusResult) string {
+	state := successStyle.Render(glyphs("● reachable"))
+	if r.Err != nil {
+		state = errorStyle.Render(glyphs("● unreachable"))
+	}
+	return fmt.Sprintf("%s  %s  %s  %s", r.CheckedAt.Format("15:04:05"), redactSecrets(r.Endpoint), state, formatLatency(r.Latency))
+}
+
+// formatLatency rounds a latency for display
+func formatLatency(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusWatch, "watch", false, "Repeat the check until interrupted")
+	statusCmd.Flags().DurationVar(&statusInterval, "interval", 10*time.Second, "Time between checks in --watch mode")
+
+	accessAnalyzerCmd.AddCommand(statusCmd)
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
@@ -11,15 +9,10 @@ var accessAnalyzerCmd = &cobra.Command{
 	Aliases: []string{"aa"},
 	Short:   "Access Analyzer commands",
 	Long:    "Commands for managing Access Analyzer scanners, sources, and scans",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Access Analyzer CLI")
-		fmt.Println("Available commands:")
-		fmt.Println("  nwx aa config     - Configuration management")
-		fmt.Println("  nwx aa scanner    - Scanner management")
-		fmt.Println("  nwx aa source     - Source management")
-		fmt.Println("  nwx aa scan       - Scan management")
-		fmt.Println()
-		fmt.Println("Use 'nwx aa <command> --help' for more information about a command.")
+	// Cobra lists the registered subcommands, so the help can't drift
+	// from the commands that actually exist
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
 	},
 }
 
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"strings"
 	"time"
 )
 
@@ -13,8 +18,29 @@ import (
 type APIClient struct {
 	BaseURL string
 	Client  *http.Client
+
+	// MaxBodySize caps how many bytes of a response body are read
+	MaxBodySize int64
+
+	// MaxPages caps how many pages a paginated fetch follows
+	MaxPages int
+
+	// TLSConfig is used for HTTPS requests (see SetTLSConfig)
+	TLSConfig *tls.Config
+
+	// TokenSource supplies the bearer token for each request; nil sends none
+	TokenSource TokenSource
 }
 
+// Limits used by NewAPIClient
+const (
+	defaultMaxBodySize = 32 << 20
+	defaultMaxPages    = 1000
+)
+
+// maxPagesFlag overrides the pagination cap (--max-pages)
+var maxPagesFlag int
+
 // SourceType represents a scanner/source type from the API
 type SourceType struct {
 	SourceTypeID     string `json:"sourceTypeId"`
@@ -29,6 +55,9 @@ type SourceType struct {
 	UpdatedAt        string `json:"updatedAt"`
 	SupportedScans   []string `json:"supportedScanTypes,omitempty"`
 	Icon             string `json:"icon,omitempty"`
+	Maintainer       string `json:"maintainer,omitempty"`
+	Tags             map[string]string `json:"tags,omitempty"`
+	ScannerSpecification json.RawMessage `json:"scannerSpecification,omitempty"`
 }
 
 // SourceTypeListResponse represents the API response for listing source types
@@ -45,63 +74,548 @@ type PaginationMetadata struct {
 	TotalPages int `json:"totalPages"`
 }
 
+// Scan represents a scan run from the API
+type Scan struct {
+	ScanID       string `json:"scanId"`
+	SourceID     string `json:"sourceId"`
+	SourceTypeID string `json:"sourceTypeId,omitempty"`
+	ScanType     string `json:"scanType"`
+	Status       string `json:"status"`
+	StartedAt    string `json:"startedAt"`
+	CompletedAt  string `json:"completedAt,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	RecordCount  int    `json:"recordCount,omitempty"`
+}
+
+// ScanListResponse represents the API response for listing scans
+type ScanListResponse struct {
+	Data       []Scan             `json:"data"`
+	Pagination PaginationMetadata `json:"pagination"`
+}
+
+// ScanQuery holds the query parameters for listing scans. Filters are
+// combined with AND semantics; zero values are ignored.
+type ScanQuery struct {
+	Page     int
+	PageSize int
+	Status   string
+	SourceID string
+	Since    time.Time
+}
+
+// matches reports whether a scan satisfies the query filters. It is applied
+// client-side in case the server ignores the filter parameters.
+func (q ScanQuery) matches(scan Scan) bool {
+	if q.Status != "" && !strings.EqualFold(scan.Status, q.Status) {
+		return false
+	}
+	if q.SourceID != "" && scan.SourceID != q.SourceID {
+		return false
+	}
+	if !q.Since.IsZero() {
+		if started, err := time.Parse(time.RFC3339, scan.StartedAt); err == nil && started.Before(q.Since) {
+			return false
+		}
+	}
+	return true
+}
+
+// values encodes the query as URL parameters
+func (q ScanQuery) values() url.Values {
+	params := url.Values{}
+	params.Set("page", fmt.Sprintf("%d", q.Page))
+	params.Set("pageSize", fmt.Sprintf("%d", q.PageSize))
+	if q.Status != "" {
+		params.Set("status", q.Status)
+	}
+	if q.SourceID != "" {
+		params.Set("sourceId", q.SourceID)
+	}
+	if !q.Since.IsZero() {
+		params.Set("since", q.Since.UTC().Format(time.RFC3339))
+	}
+	return params
+}
+
+// APIError represents a non-success response from the API
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, redactSecrets(e.Message))
+	}
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, redactSecrets(e.Body))
+}
+
 // NewAPIClient creates a new API client
 func NewAPIClient(baseURL string) *APIClient {
+	tlsConfig := &tls.Config{MinVersion: defaultTLSMinVersion}
 	return &APIClient{
 		BaseURL: baseURL,
 		Client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: newTransport(tlsConfig),
 		},
+		MaxBodySize: defaultMaxBodySize,
+		MaxPages:    defaultMaxPages,
+		TLSConfig:   tlsConfig,
 	}
 }
 
-// GetSourceTypes fetches all source types from the API
-func (c *APIClient) GetSourceTypes() (*SourceTypeListResponse, error) {
-	// Build URL with pagination
-	u, err := url.Parse(c.BaseURL + "/source-types")
+// SetTLSConfig replaces the TLS configuration used for HTTPS requests
+func (c *APIClient) SetTLSConfig(config *tls.Config) {
+	c.TLSConfig = config
+	c.Client.Transport = newTransport(config)
+}
+
+// pageLimitReached reports whether a paginated fetch should stop after page,
+// warning when the cap rather than the last page ends it. A server that
+// misreports its page count would otherwise keep the CLI looping forever.
+func (c *APIClient) pageLimitReached(page int) bool {
+	limit := c.MaxPages
+	if limit <= 0 {
+		limit = defaultMaxPages
+	}
+	if page < limit {
+		return false
+	}
+	fmt.Fprintf(os.Stderr, glyphs("⚠️  Stopped after %d pages; results may be incomplete (see --max-pages)\n"), limit)
+	return true
+}
+
+// readBody reads a response body, failing instead of buffering more than
+// MaxBodySize bytes
+func (c *APIClient) readBody(body io.Reader) ([]byte, error) {
+	limit := c.MaxBodySize
+	if limit <= 0 {
+		limit = defaultMaxBodySize
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
 	if err != nil {
-		return nil, fmt.Errorf("invalid base URL: %w", err)
+		return nil, fmt.Errorf("failed to read API response: %w", err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("API response exceeds the maximum size of %d bytes", limit)
 	}
+	return data, nil
+}
 
-	params := url.Values{}
-	params.Set("page", "1")
-	params.Set("pageSize", "100") // Get all scanners in one request
-	u.RawQuery = params.Encode()
+// getJSON performs a GET request against the API and decodes the JSON response into out
+func (c *APIClient) getJSON(ctx context.Context, path string, params url.Values, out interface{}) error {
+	return c.doJSON(ctx, http.MethodGet, path, params, nil, out)
+}
+
+// endpointURL joins an API path to the base URL, so a trailing slash on the
+// base URL or a path prefix such as /api is handled consistently
+func (c *APIClient) endpointURL(path string) (*url.URL, error) {
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	return base.JoinPath(path), nil
+}
+
+// doJSON performs a request against the API, sending in as the JSON request
+// body, and decodes the JSON response into out. in may be nil for requests
+// without a body and out may be nil when the response body is not needed.
+func (c *APIClient) doJSON(ctx context.Context, method, path string, params url.Values, in, out interface{}) error {
+	u, err := c.endpointURL(path)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	if params != nil {
+		u.RawQuery = params.Encode()
+	}
+
+	var reqBody io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode API request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
 
 	// Make HTTP request
-	resp, err := c.Client.Get(u.String())
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make API request: %w", err)
+		return fmt.Errorf("failed to create API request: %w", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if err := c.authorize(req); err != nil {
+		return err
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make API request: %w", explainTLSError(err, c.TLSConfig))
 	}
 	defer resp.Body.Close()
 
+	success := resp.StatusCode >= 200 && resp.StatusCode < 300
+	body, err := c.readBody(resp.Body)
+	if err != nil {
+		if !success {
+			return fmt.Errorf("API request failed with status %d: %w", resp.StatusCode, err)
+		}
+		return err
+	}
+
 	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	if !success {
+		return newAPIError(resp.StatusCode, body)
 	}
 
 	// Parse response
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	return nil
+}
+
+// newAPIError builds an APIError from a response status and body, picking up
+// the error code and message when the body is a JSON error document
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Body: string(body)}
+
+	var doc struct {
+		Error   json.RawMessage `json:"error"`
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+	}
+	if json.Unmarshal(body, &doc) != nil {
+		return apiErr
+	}
+	apiErr.Code = doc.Code
+	apiErr.Message = doc.Message
+
+	// The error field is either a nested object or a plain string
+	var nested struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	var plain string
+	if json.Unmarshal(doc.Error, &nested) == nil {
+		if nested.Code != "" {
+			apiErr.Code = nested.Code
+		}
+		if nested.Message != "" {
+			apiErr.Message = nested.Message
+		}
+	} else if json.Unmarshal(doc.Error, &plain) == nil && apiErr.Message == "" {
+		apiErr.Message = plain
+	}
+
+	return apiErr
+}
+
+// GetSourceTypes fetches all source types from the API
+func (c *APIClient) GetSourceTypes(ctx context.Context) (*SourceTypeListResponse, error) {
+	return c.getSourceTypesPage(ctx, 1, 100) // Get all scanners in one request
+}
+
+// getSourceTypesPage fetches a single page of source types
+func (c *APIClient) getSourceTypesPage(ctx context.Context, page, pageSize int) (*SourceTypeListResponse, error) {
+	params := url.Values{}
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("pageSize", fmt.Sprintf("%d", pageSize))
+
 	var result SourceTypeListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to parse API response: %w", err)
+	if err := c.getJSON(ctx, "/source-types", params, &result); err != nil {
+		return nil, err
 	}
 
 	return &result, nil
 }
 
+// GetAllSourceTypes fetches every source type, following pagination
+func (c *APIClient) GetAllSourceTypes(ctx context.Context) ([]SourceType, error) {
+	var all []SourceType
+	for page := 1; ; page++ {
+		result, err := c.getSourceTypesPage(ctx, page, 100)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, result.Data...)
+
+		if page >= result.Pagination.TotalPages || len(result.Data) == 0 {
+			break
+		}
+		if c.pageLimitReached(page) {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// GetSourceType fetches a single source type, including its embedded scanner specification
+func (c *APIClient) GetSourceType(ctx context.Context, sourceTypeID string) (*SourceType, error) {
+	var result SourceType
+	if err := c.getJSON(ctx, "/source-types/"+url.PathEscape(sourceTypeID), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetSourceTypeMetadata fetches a single source type without its scanner
+// specification. The API is asked to leave the spec out; if it ignores
+// includeSpec the spec is dropped here instead.
+func (c *APIClient) GetSourceTypeMetadata(ctx context.Context, sourceTypeID string) (*SourceType, error) {
+	params := url.Values{}
+	params.Set("includeSpec", "false")
+
+	var result SourceType
+	if err := c.getJSON(ctx, "/source-types/"+url.PathEscape(sourceTypeID), params, &result); err != nil {
+		return nil, err
+	}
+	result.ScannerSpecification = nil
+
+	return &result, nil
+}
+
+// FindSourceType looks up a registered source type by type name or ID and
+// fetches its full definition
+func (c *APIClient) FindSourceType(ctx context.Context, name string) (*SourceType, error) {
+	id, err := c.findSourceTypeID(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetSourceType(ctx, id)
+}
+
+// FindSourceTypeMetadata looks up a registered source type by type name or
+// ID and fetches it without its scanner specification
+func (c *APIClient) FindSourceTypeMetadata(ctx context.Context, name string) (*SourceType, error) {
+	id, err := c.findSourceTypeID(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetSourceTypeMetadata(ctx, id)
+}
+
+// findSourceTypeID returns the ID of the source type with the given type
+// name or ID
+func (c *APIClient) findSourceTypeID(ctx context.Context, name string) (string, error) {
+	sourceTypes, err := c.GetAllSourceTypes(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, st := range sourceTypes {
+		if st.TypeName == name || st.SourceTypeID == name {
+			return st.SourceTypeID, nil
+		}
+	}
+
+	return "", fmt.Errorf("source type '%s' not found", name)
+}
+
+// GetScans fetches a single page of scans
+func (c *APIClient) GetScans(ctx context.Context, query ScanQuery) (*ScanListResponse, error) {
+	var result ScanListResponse
+	if err := c.getJSON(ctx, "/scans", query.values(), &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetScan fetches a single scan
+func (c *APIClient) GetScan(ctx context.Context, scanID string) (*Scan, error) {
+	var result Scan
+	if err := c.getJSON(ctx, "/scans/"+url.PathEscape(scanID), nil, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CancelScan asks the API to stop a running scan. Cancelling a scan that
+// has already finished fails with an APIError (409 Conflict).
+func (c *APIClient) CancelScan(ctx context.Context, scanID string) error {
+	return c.doJSON(ctx, http.MethodPost, "/scans/"+url.PathEscape(scanID)+"/cancel", nil, nil, nil)
+}
+
+// CreateSourceType registers a new source type. definition is encoded as
+// the request body as is, so fields the CLI does not know are kept.
+func (c *APIClient) CreateSourceType(ctx context.Context, definition interface{}) (*SourceType, error) {
+	var result SourceType
+	if err := c.doJSON(ctx, http.MethodPost, "/source-types", nil, definition, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Source is a configured data source scanned by a source type. Its
+// connection config is not decoded since it may hold secrets.
+type Source struct {
+	SourceID     string `json:"sourceId"`
+	Name         string `json:"name"`
+	SourceTypeID string `json:"sourceTypeId"`
+	CreatedAt    string `json:"createdAt,omitempty"`
+}
+
+// SourceListResponse represents the API response for listing sources
+type SourceListResponse struct {
+	Data       []SourceWithConfig `json:"data"`
+	Pagination PaginationMetadata `json:"pagination"`
+}
+
+// SourceWithConfig is a source with its connection configuration, which
+// holds the target host for most source types
+type SourceWithConfig struct {
+	Source
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// GetSourcesOfType fetches every source of a source type, following
+// pagination. The filter is also applied client-side in case the server
+// ignores it.
+func (c *APIClient) GetSourcesOfType(ctx context.Context, sourceTypeID string) ([]Source, error) {
+	all, err := c.listSources(ctx, sourceTypeID)
+	if err != nil {
+		return nil, err
+	}
+	var sources []Source
+	for _, source := range all {
+		sources = append(sources, source.Source)
+	}
+	return sources, nil
+}
+
+// GetAllSources fetches every configured source with its configuration,
+// following pagination
+func (c *APIClient) GetAllSources(ctx context.Context) ([]SourceWithConfig, error) {
+	return c.listSources(ctx, "")
+}
+
+// listSources fetches the sources of a source type, or all sources when
+// sourceTypeID is empty
+func (c *APIClient) listSources(ctx context.Context, sourceTypeID string) ([]SourceWithConfig, error) {
+	var sources []SourceWithConfig
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("page", fmt.Sprintf("%d", page))
+		params.Set("pageSize", "100")
+		if sourceTypeID != "" {
+			params.Set("sourceTypeId", sourceTypeID)
+		}
+
+		var result SourceListResponse
+		if err := c.getJSON(ctx, "/sources", params, &result); err != nil {
+			return nil, err
+		}
+		for _, source := range result.Data {
+			if sourceTypeID == "" || source.SourceTypeID == sourceTypeID {
+				sources = append(sources, source)
+			}
+		}
+
+		if page >= result.Pagination.TotalPages || len(result.Data) == 0 {
+			break
+		}
+		if c.pageLimitReached(page) {
+			break
+		}
+	}
+
+	return sources, nil
+}
+
+// StartScanRequest is the body of a request to start a scan
+type StartScanRequest struct {
+	SourceID string `json:"sourceId"`
+	ScanType string `json:"scanType,omitempty"`
+}
+
+// StartScan asks the API to start a scan of a source and returns the new scan
+func (c *APIClient) StartScan(ctx context.Context, request StartScanRequest) (*Scan, error) {
+	var result Scan
+	if err := c.doJSON(ctx, http.MethodPost, "/scans", nil, request, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// WalkScans fetches scans page by page, calling fn with each page as it
+// arrives. It stops at the last page, when fn returns an error or when ctx
+// is cancelled.
+func (c *APIClient) WalkScans(ctx context.Context, query ScanQuery, fn func([]Scan) error) error {
+	if query.PageSize == 0 {
+		query.PageSize = 100
+	}
+	for query.Page = 1; ; query.Page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		result, err := c.GetScans(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		page := make([]Scan, 0, len(result.Data))
+		for _, scan := range result.Data {
+			if query.matches(scan) {
+				page = append(page, scan)
+			}
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+
+		if query.Page >= result.Pagination.TotalPages || len(result.Data) == 0 {
+			return nil
+		}
+		if c.pageLimitReached(query.Page) {
+			return nil
+		}
+	}
+}
+
 // TestConnection tests the connection to the API
-func (c *APIClient) TestConnection() error {
+func (c *APIClient) TestConnection(ctx context.Context) error {
 	// Try to get source types as a health check
-	resp, err := c.Client.Get(c.BaseURL + "/source-types?page=1&pageSize=1")
+	u, err := c.endpointURL("/source-types")
 	if err != nil {
 		return fmt.Errorf("connection failed: %w", err)
 	}
+	u.RawQuery = url.Values{"page": {"1"}, "pageSize": {"1"}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("connection failed: %w", err)
+	}
+	if err := c.authorize(req); err != nil {
+		return fmt.Errorf("connection failed: %w", err)
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		// Only a failed request pays for the layered checks
+		return c.diagnoseConnection(ctx, req, err)
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		body, err := c.readBody(resp.Body)
+		if err != nil {
+			return fmt.Errorf("HTTP %d from API: %w", resp.StatusCode, err)
+		}
+		return fmt.Errorf("HTTP %d from API: %s", resp.StatusCode, redactSecrets(string(body)))
 	}
 
 	return nil
@@ -109,14 +623,46 @@ func (c *APIClient) TestConnection() error {
 
 // Helper function to get API client with configured endpoint
 func getAPIClient() (*APIClient, error) {
-	endpoint, err := getAAEndpoint()
+	if err := checkConfigOnce(); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	resolved, err := ResolveEndpoint(globalEndpointFlags())
 	if err != nil {
 		return nil, err
 	}
 
-	if endpoint == "" {
-		return nil, fmt.Errorf("no endpoint configured - use 'nwx aa config --endpoint=\"<url>\"'")
+	if resolved.Endpoint == "" {
+		return nil, fmt.Errorf("no endpoint configured - use 'nwx aa config --endpoint=\"<url>\"', --api-endpoint or $%s", endpointEnvVar)
+	}
+
+	return newConfiguredAPIClient(resolved.Endpoint)
+}
+
+// newConfiguredAPIClient creates an API client for endpoint with the
+// settings from configuration and global flags applied
+func newConfiguredAPIClient(endpoint string) (*APIClient, error) {
+	endpoint, err := cleanEndpoint(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := loadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	tokenSource, err := loadTokenSource()
+	if err != nil {
+		return nil, err
 	}
 
-	return NewAPIClient(endpoint), nil
-}
\ No newline at end of file
+	client := NewAPIClient(endpoint)
+	client.SetTLSConfig(tlsConfig)
+	client.TokenSource = tokenSource
+	if maxPagesFlag > 0 {
+		client.MaxPages = maxPagesFlag
+	}
+	if debugFlag {
+		client.Client.Transport = &debugTransport{next: client.Client.Transport, out: os.Stderr}
+	}
+	return client, nil
+}
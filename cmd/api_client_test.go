@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadBodyLimit(t *testing.T) {
+	client := &APIClient{MaxBodySize: 10}
+	tests := []struct {
+		body    string
+		wantErr bool
+	}{
+		{"", false},
+		{"0123456789", false},
+		{"0123456789a", true},
+		{strings.Repeat("x", 1000), true},
+	}
+	for _, tt := range tests {
+		data, err := client.readBody(strings.NewReader(tt.body))
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "maximum size of 10 bytes") {
+				t.Errorf("readBody(%d bytes) error = %v, want the size error", len(tt.body), err)
+			}
+			continue
+		}
+		if err != nil || string(data) != tt.body {
+			t.Errorf("readBody(%d bytes) = %q, %v", len(tt.body), data, err)
+		}
+	}
+}
+
+func TestOversizedResponseIsRejected(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv(tokenEnvVar, "")
+	payload := `{"data":[],"pagination":{"totalPages":1},"padding":"` + strings.Repeat("x", 4096) + `"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL)
+	client.MaxBodySize = 1024
+	if _, err := client.GetSourceTypes(context.Background()); err == nil || !strings.Contains(err.Error(), "exceeds the maximum size") {
+		t.Fatalf("GetSourceTypes with a %d byte response = %v, want the size error", len(payload), err)
+	}
+
+	client.MaxBodySize = int64(len(payload))
+	if _, err := client.GetSourceTypes(context.Background()); err != nil {
+		t.Fatalf("GetSourceTypes with a response at the limit: %v", err)
+	}
+}
+
+func TestMaxPagesStopsEndlessPagination(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv(tokenEnvVar, "")
+	requests := map[string]int{}
+	// Every page claims there is one more, as a misbehaving server might
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"data":[{}],"pagination":{"page":%d,"totalPages":%d}}`, page, page+1)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(server.URL)
+	client.MaxPages = 3
+	ctx := context.Background()
+
+	sourceTypes, err := client.GetAllSourceTypes(ctx)
+	if err != nil || len(sourceTypes) != 3 {
+		t.Errorf("GetAllSourceTypes = %d items, %v, want 3", len(sourceTypes), err)
+	}
+	sources, err := client.GetAllSources(ctx)
+	if err != nil || len(sources) != 3 {
+		t.Errorf("GetAllSources = %d items, %v, want 3", len(sources), err)
+	}
+	scans := 0
+	err = client.WalkScans(ctx, ScanQuery{}, func(page []Scan) error {
+		scans += len(page)
+		return nil
+	})
+	if err != nil || scans != 3 {
+		t.Errorf("WalkScans = %d items, %v, want 3", scans, err)
+	}
+
+	for _, path := range []string{"/source-types", "/sources", "/scans"} {
+		if requests[path] != 3 {
+			t.Errorf("%d requests to %s, want 3", requests[path], path)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// diagnoseTimeout bounds each layered connection check
+const diagnoseTimeout = 5 * time.Second
+
+// diagnoseConnection works out which layer a failed request broke at by
+// repeating the DNS lookup, TCP dial and TLS handshake one at a time. It
+// returns an error naming the first layer that fails, or cause wrapped as a
+// generic connection failure when every layer succeeds.
+func (c *APIClient) diagnoseConnection(ctx context.Context, req *http.Request, cause error) error {
+	fallback := fmt.Errorf("connection failed: %w", cause)
+	if ctx.Err() != nil {
+		return fallback
+	}
+
+	// Through a proxy the endpoint is never dialed directly, so checking
+	// it would blame the wrong host
+	if proxy, err := http.ProxyFromEnvironment(req); err != nil || proxy != nil {
+		return fallback
+	}
+
+	host := req.URL.Hostname()
+	port := req.URL.Port()
+	if port == "" {
+		port = "80"
+		if req.URL.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	if net.ParseIP(host) == nil {
+		lookupCtx, cancel := context.WithTimeout(ctx, diagnoseTimeout)
+		_, err := net.DefaultResolver.LookupHost(lookupCtx, host)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("DNS lookup failed for %s: %w", host, err)
+		}
+	}
+
+	address := net.JoinHostPort(host, port)
+	dialer := &net.Dialer{Timeout: diagnoseTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		var netErr net.Error
+		switch {
+		case errors.Is(err, syscall.ECONNREFUSED):
+			return fmt.Errorf("connection refused by %s: %w", address, err)
+		case errors.As(err, &netErr) && netErr.Timeout():
+			return fmt.Errorf("TCP connection to %s timed out: %w", address, err)
+		}
+		return fmt.Errorf("TCP connection to %s failed: %w", address, err)
+	}
+	defer conn.Close()
+
+	if req.URL.Scheme == "https" {
+		config := &tls.Config{}
+		if c.TLSConfig != nil {
+			config = c.TLSConfig.Clone()
+		}
+		config.ServerName = host
+		tlsConn := tls.Client(conn, config)
+		tlsConn.SetDeadline(time.Now().Add(diagnoseTimeout))
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return fmt.Errorf("TLS handshake with %s failed: %w", address, explainTLSError(err, c.TLSConfig))
+		}
+	}
+
+	return fallback
+}
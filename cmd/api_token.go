@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Configuration keys for the API token, stored as files in the config directory
+const (
+	tokenKey     = "token"
+	tokenFileKey = "token-file"
+)
+
+// tokenEnvVar supplies the API token from the environment
+const tokenEnvVar = "NWX_TOKEN"
+
+// tokenFileFlag names a file holding the API token (--token-file)
+var tokenFileFlag string
+
+// TokenSource returns the token sent in the Authorization header. It is
+// called for every request so a rotated token file is picked up.
+type TokenSource func() (string, error)
+
+// loadTokenSource picks where the API token comes from, in order of
+// precedence: --token-file, $NWX_TOKEN, the token-file key, the token key.
+// It returns nil when no token is configured.
+func loadTokenSource() (TokenSource, error) {
+	if tokenFileFlag != "" {
+		return fileTokenSource(tokenFileFlag, "--token-file"), nil
+	}
+	if token := strings.TrimSpace(os.Getenv(tokenEnvVar)); token != "" {
+		return staticTokenSource(token), nil
+	}
+
+	path, err := readConfigValue(tokenFileKey)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		return fileTokenSource(path, tokenFileKey), nil
+	}
+
+	token, err := readConfigValue(tokenKey)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		return staticTokenSource(token), nil
+	}
+	return nil, nil
+}
+
+// staticTokenSource always returns token
+func staticTokenSource(token string) TokenSource {
+	return func() (string, error) {
+		return token, nil
+	}
+}
+
+// fileTokenSource reads the token from path on every call. origin names the
+// setting the path came from for error messages.
+func fileTokenSource(path, origin string) TokenSource {
+	return func() (string, error) {
+		return readTokenFile(path, origin)
+	}
+}
+
+// readTokenFile reads a token file, trimming surrounding whitespace
+func readTokenFile(path, origin string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("token file %s (from %s) does not exist", path, origin)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot read token file %s (from %s): %w", path, origin, err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s (from %s) is empty", path, origin)
+	}
+	return token, nil
+}
+
+// setTokenFile validates and stores the token-file key. The path is stored
+// absolute so it still resolves when nwx runs from another directory.
+func setTokenFile(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if _, err := readTokenFile(path, tokenFileKey); err != nil {
+		return err
+	}
+	return writeConfigValue(tokenFileKey, path)
+}
+
+// authorize sets the Authorization header on req when a token is configured
+func (c *APIClient) authorize(req *http.Request) error {
+	if c.TokenSource == nil {
+		return nil
+	}
+	token, err := c.TokenSource()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// tokenDescription describes a token or token-file value for display,
+// never showing the token itself
+func tokenDescription(key, value string) string {
+	switch {
+	case value == "":
+		return "<not configured>"
+	case key == tokenKey || key == registryTokenKey:
+		return "<set>"
+	}
+	return value
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Retry settings for short-lived API failures
+const (
+	defaultRetryAttempts = 3
+	defaultRetryDelay    = 500 * time.Millisecond
+)
+
+// retryWithBackoff calls fn up to attempts times, waiting delay before the
+// second attempt and doubling the wait after each failure. Only transient
+// errors are retried; the last error is returned. Waiting stops early when
+// ctx is done.
+func retryWithBackoff(ctx context.Context, attempts int, delay time.Duration, fn func() error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || attempt >= attempts || !isTransientError(err) {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// isTransientError reports whether a failed API request may succeed when
+// repeated: network errors, rate limiting and server errors
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
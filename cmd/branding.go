@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Branding modes for the intro banner
+const (
+	brandingNetwrix = "netwrix" // the Netwrix logo and contact details
+	brandingNeutral = "neutral" // a plain header with brandName
+	brandingNone    = "none"    // no banner at all
+)
+
+// branding selects the intro banner. It is set at build time for embedded
+// and white-label builds, which is why it is not a configuration key:
+//
+//	go build -ldflags "-X github.com/netwrix/nwx/cmd.branding=neutral -X github.com/netwrix/nwx/cmd.brandName=Acme"
+//
+// or 'make build BRANDING=neutral BRAND_NAME=Acme'. Unknown values keep the
+// default branding.
+var branding = brandingNetwrix
+
+// brandName is the product name shown by the neutral header
+var brandName = "nwx"
+
+// brandingMode returns the branding mode, falling back to the default for
+// an unknown build-time value
+func brandingMode() string {
+	switch branding {
+	case brandingNeutral, brandingNone:
+		return branding
+	}
+	return brandingNetwrix
+}
+
+// productTitle is the title of the interactive menu
+func productTitle() string {
+	if brandingMode() == brandingNetwrix {
+		return "NETWRIX CLI"
+	}
+	return brandName + " CLI"
+}
+
+// showNeutralHeader prints the white-label intro header: the product name
+// and a one-line subtitle, without logo or contact details
+func showNeutralHeader() {
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	subtitleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render(brandName + " CLI"))
+	fmt.Println(subtitleStyle.Render("Access Analyzer Scanner Management"))
+	fmt.Println()
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// clickHouseTable is the DDL input for one scan type's output table
+type clickHouseTable struct {
+	Name     string
+	ScanType string
+	Columns  []SpecColumn
+}
+
+// specClickHouseTables returns the output tables of a spec, named as the
+// generated scanner names them, limited to scanTypes when not empty
+func specClickHouseTables(spec *ScannerSpec, scanTypes []string) []clickHouseTable {
+	names := deriveScannerNames(spec.Name, spec.Version, specScanTypes(spec))
+	var tables []clickHouseTable
+	for _, key := range sortedKeys(spec.OutputSchema) {
+		scanType, ok := outputSchemaScanTypes[key]
+		if !ok || (len(scanTypes) > 0 && !contains(scanTypes, scanType)) {
+			continue
+		}
+		tables = append(tables, clickHouseTable{
+			Name:     names.Tables[scanType],
+			ScanType: scanType,
+			Columns:  spec.OutputSchema[key].Columns,
+		})
+	}
+	return tables
+}
+
+// writeClickHouseDDL writes a CREATE TABLE statement for each table.
+// Primary key columns become the MergeTree sorting key.
+func writeClickHouseDDL(w io.Writer, spec *ScannerSpec, tables []clickHouseTable) {
+	for i, table := range tables {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "-- %s %s, %s scan results\n", spec.Name, spec.Version, table.ScanType)
+		fmt.Fprintf(w, "CREATE TABLE IF NOT EXISTS %s\n(\n", quoteClickHouseIdent(table.Name))
+
+		var sortingKey []string
+		for j, col := range table.Columns {
+			def := "    " + quoteClickHouseIdent(col.Name) + " " + clickHouseColumnType(col)
+			if col.DefaultValue != nil {
+				def += " DEFAULT " + clickHouseDefault(col)
+			}
+			if col.Description != "" {
+				def += " COMMENT " + clickHouseLiteral(col.Description)
+			}
+			if j < len(table.Columns)-1 {
+				def += ","
+			}
+			fmt.Fprintln(w, def)
+			if col.PrimaryKey {
+				sortingKey = append(sortingKey, quoteClickHouseIdent(col.Name))
+			}
+		}
+
+		fmt.Fprintln(w, ")")
+		fmt.Fprintln(w, "ENGINE = MergeTree")
+		if len(sortingKey) > 0 {
+			fmt.Fprintf(w, "ORDER BY (%s);\n", strings.Join(sortingKey, ", "))
+		} else {
+			fmt.Fprintln(w, "ORDER BY tuple();")
+		}
+	}
+}
+
+// clickHouseColumnType maps a spec column type to a ClickHouse type. JSON
+// values are stored as strings; sorting key columns cannot be Nullable.
+func clickHouseColumnType(col SpecColumn) string {
+	var t string
+	switch col.Type {
+	case "integer":
+		t = "Int64"
+	case "number":
+		t = "Float64"
+	case "boolean":
+		t = "Bool"
+	case "timestamp":
+		t = "DateTime64(3, 'UTC')"
+	default: // string, json
+		t = "String"
+	}
+	if col.Nullable && !col.PrimaryKey {
+		t = "Nullable(" + t + ")"
+	}
+	return t
+}
+
+// clickHouseDefault formats the default of a column. The SQL
+// CURRENT_TIMESTAMP default of timestamp columns becomes now64().
+func clickHouseDefault(col SpecColumn) string {
+	if s, ok := col.DefaultValue.(string); ok && col.Type == "timestamp" {
+		switch strings.ToUpper(s) {
+		case "CURRENT_TIMESTAMP", "NOW()":
+			return "now64(3)"
+		}
+	}
+	return clickHouseLiteral(col.DefaultValue)
+}
+
+// clickHouseLiteral formats a JSON value as a ClickHouse literal
+func clickHouseLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+	case bool, float64:
+		return fmt.Sprint(v)
+	default:
+		data, _ := json.Marshal(v)
+		return clickHouseLiteral(string(data))
+	}
+}
+
+// quoteClickHouseIdent quotes an identifier with backticks
+func quoteClickHouseIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "\\`") + "`"
+}
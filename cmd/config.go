@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"syscall"
 
 	"github.com/spf13/cobra"
 )
@@ -17,7 +20,7 @@ var configCmd = &cobra.Command{
 var configSetCmd = &cobra.Command{
 	Use:   "set <key> <value>",
 	Short: "Set a configuration value",
-	Long:  "Set a configuration value. Available keys: endpoint",
+	Long:  "Set a configuration value. Available keys: endpoint, tls-min-version, tls-ciphers, pin-sha256, token, token-file, noIntro, source-types-cache, name-prefix, scanner.default-output-dir, registry, registry-token",
 	Args:  cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
@@ -25,16 +28,84 @@ var configSetCmd = &cobra.Command{
 		
 		switch key {
 		case "endpoint":
+			value, err := cleanEndpoint(value)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting endpoint: %v\n", err)
+				os.Exit(1)
+			}
 			if err := setEndpoint(value); err != nil {
 				fmt.Fprintf(os.Stderr, "Error setting endpoint: %v\n", err)
 				os.Exit(1)
 			}
-			fmt.Printf("✅ Endpoint set to: %s\n", value)
+			fmt.Printf(glyphs("✅ Endpoint set to: %s\n"), value)
 			// TODO: Test connection
-			fmt.Println("⚠️  Connection test not implemented yet")
+			fmt.Println(glyphs("⚠️  Connection test not implemented yet"))
+		case tlsMinVersionKey, tlsCiphersKey:
+			if err := setTLSValue(key, value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set to: %s\n"), key, value)
+		case pinSHA256Key:
+			if err := setCertPins(value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set to: %s\n"), key, value)
+		case tokenKey:
+			if err := writeConfigValue(key, value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set\n"), key)
+		case tokenFileKey:
+			if abs, err := filepath.Abs(value); err == nil {
+				value = abs
+			}
+			if err := setTokenFile(value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set to: %s\n"), key, value)
+		case noIntroKey:
+			if err := setNoIntro(value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set to: %s\n"), key, value)
+		case sourceTypesCacheKey:
+			if err := setSourceTypesCache(value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set to: %s\n"), key, value)
+		case namePrefixKey:
+			if err := setNamePrefix(value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set to: %s\n"), key, value)
+		case defaultOutputDirKey:
+			if err := setDefaultOutputDir(value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set to: %s\n"), key, value)
+		case registryKey:
+			if err := setRegistry(value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set to: %s\n"), key, value)
+		case registryTokenKey:
+			if err := writeConfigValue(key, value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error setting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Printf(glyphs("✅ %s set\n"), key)
 		default:
 			fmt.Fprintf(os.Stderr, "Unknown configuration key: %s\n", key)
-			fmt.Println("Available keys: endpoint")
+			fmt.Println("Available keys: endpoint, tls-min-version, tls-ciphers, pin-sha256, token, token-file, noIntro, source-types-cache, name-prefix, scanner.default-output-dir, registry, registry-token")
 			os.Exit(1)
 		}
 	},
@@ -43,7 +114,7 @@ var configSetCmd = &cobra.Command{
 var configGetCmd = &cobra.Command{
 	Use:   "get <key>",
 	Short: "Get a configuration value",
-	Long:  "Get a configuration value. Available keys: endpoint",
+	Long:  "Get a configuration value. Available keys: endpoint, tls-min-version, tls-ciphers, pin-sha256, token, token-file, noIntro, source-types-cache, name-prefix, scanner.default-output-dir, registry, registry-token",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
@@ -60,9 +131,51 @@ var configGetCmd = &cobra.Command{
 			} else {
 				fmt.Printf("Current endpoint: %s\n", endpoint)
 			}
+		case tlsMinVersionKey, tlsCiphersKey, pinSHA256Key:
+			value, err := readConfigValue(key)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Println(valueOr(value, tlsDefaultDescription(key)))
+		case tokenKey, tokenFileKey:
+			value, err := readConfigValue(key)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Println(tokenDescription(key, value))
+		case noIntroKey, sourceTypesCacheKey:
+			value, err := readConfigValue(key)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Println(valueOr(value, "<not configured, default false>"))
+		case namePrefixKey, defaultOutputDirKey:
+			value, err := readConfigValue(key)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Println(valueOr(value, "<not configured>"))
+		case registryKey:
+			value, err := readConfigValue(key)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Println(valueOr(value, "<not configured, default "+defaultRegistry+">"))
+		case registryTokenKey:
+			value, err := readConfigValue(key)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error getting %s: %v\n", key, err)
+				os.Exit(1)
+			}
+			fmt.Println(tokenDescription(key, value))
 		default:
 			fmt.Fprintf(os.Stderr, "Unknown configuration key: %s\n", key)
-			fmt.Println("Available keys: endpoint")
+			fmt.Println("Available keys: endpoint, tls-min-version, tls-ciphers, pin-sha256, token, token-file, noIntro, source-types-cache, name-prefix, scanner.default-output-dir, registry, registry-token")
 			os.Exit(1)
 		}
 	},
@@ -83,22 +196,73 @@ var configListCmd = &cobra.Command{
 		} else {
 			fmt.Printf("  endpoint: %s\n", endpoint)
 		}
+		
+		for _, key := range []string{tlsMinVersionKey, tlsCiphersKey, pinSHA256Key} {
+			value, err := readConfigValue(key)
+			if err != nil {
+				fmt.Printf("  %s: <error: %v>\n", key, err)
+			} else {
+				fmt.Printf("  %s: %s\n", key, valueOr(value, tlsDefaultDescription(key)))
+			}
+		}
+		
+		for _, key := range []string{tokenKey, tokenFileKey} {
+			value, err := readConfigValue(key)
+			if err != nil {
+				fmt.Printf("  %s: <error: %v>\n", key, err)
+			} else {
+				fmt.Printf("  %s: %s\n", key, tokenDescription(key, value))
+			}
+		}
+		
+		for _, key := range []string{noIntroKey, sourceTypesCacheKey} {
+			value, err := readConfigValue(key)
+			if err != nil {
+				fmt.Printf("  %s: <error: %v>\n", key, err)
+			} else {
+				fmt.Printf("  %s: %s\n", key, valueOr(value, "<not configured, default false>"))
+			}
+		}
+		
+		namePrefix, err := readConfigValue(namePrefixKey)
+		if err != nil {
+			fmt.Printf("  %s: <error: %v>\n", namePrefixKey, err)
+		} else {
+			fmt.Printf("  %s: %s\n", namePrefixKey, valueOr(namePrefix, "<not configured>"))
+		}
+		outputDir, err := readConfigValue(defaultOutputDirKey)
+		if err != nil {
+			fmt.Printf("  %s: <error: %v>\n", defaultOutputDirKey, err)
+		} else {
+			fmt.Printf("  %s: %s\n", defaultOutputDirKey, valueOr(outputDir, "<not configured, default ./{name}>"))
+		}
+		
+		registry, err := readConfigValue(registryKey)
+		if err != nil {
+			fmt.Printf("  %s: <error: %v>\n", registryKey, err)
+		} else {
+			fmt.Printf("  %s: %s\n", registryKey, valueOr(registry, "<not configured, default "+defaultRegistry+">"))
+		}
+		registryToken, err := readConfigValue(registryTokenKey)
+		if err != nil {
+			fmt.Printf("  %s: <error: %v>\n", registryTokenKey, err)
+		} else {
+			fmt.Printf("  %s: %s\n", registryTokenKey, tokenDescription(registryTokenKey, registryToken))
+		}
 	},
 }
 
 func setEndpoint(endpoint string) error {
-	configDir, err := getConfigDir()
+	endpoint, _, err := normalizeEndpoint(endpoint)
 	if err != nil {
 		return err
 	}
-	
-	// Create config directory if it doesn't exist
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	configDir, err := getConfigDir()
+	if err != nil {
 		return err
 	}
 	
-	configFile := filepath.Join(configDir, "config")
-	return os.WriteFile(configFile, []byte(endpoint), 0644)
+	return writeConfigFile(configDir, "config", []byte(endpoint))
 }
 
 func getEndpoint() (string, error) {
@@ -119,14 +283,114 @@ func getEndpoint() (string, error) {
 	return string(data), nil
 }
 
+// configDirFlag overrides the configuration directory (--config)
+var configDirFlag string
+
+// getConfigDir returns the nwx configuration directory: --config if given,
+// otherwise ~/.nwx when it exists, then $XDG_CONFIG_HOME/nwx, then
+// ~/.config/nwx when it exists (see 'nwx aa config migrate'), then ~/.nwx
 func getConfigDir() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+	if configDirFlag != "" {
+		return configDirFlag, nil
+	}
+	
+	homeDir, homeErr := os.UserHomeDir()
+	if homeErr == nil {
+		legacyDir := filepath.Join(homeDir, ".nwx")
+		if _, err := os.Stat(legacyDir); err == nil {
+			return legacyDir, nil
+		}
+	}
+	
+	if xdgDir := os.Getenv("XDG_CONFIG_HOME"); xdgDir != "" {
+		return filepath.Join(xdgDir, "nwx"), nil
+	}
+	
+	if homeErr == nil {
+		xdgDefaultDir := filepath.Join(homeDir, ".config", "nwx")
+		if _, err := os.Stat(xdgDefaultDir); err == nil {
+			return xdgDefaultDir, nil
+		}
+	}
+	
+	if homeErr != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w\nUse --config <dir> or set XDG_CONFIG_HOME to choose a configuration directory", homeErr)
 	}
 	return filepath.Join(homeDir, ".nwx"), nil
 }
 
+// secretConfigKeys are the keys holding credentials, written readable only
+// by the user
+var secretConfigKeys = map[string]bool{
+	tokenKey:         true,
+	registryTokenKey: true,
+}
+
+// configFileMode returns the permissions of a file in a configuration
+// directory: 0600 for secret keys, 0644 otherwise
+func configFileMode(name string) os.FileMode {
+	if secretConfigKeys[name] {
+		return 0600
+	}
+	return 0644
+}
+
+// writeConfigFile writes a file in a configuration directory, creating the
+// directory first. The file is replaced atomically, so an interrupted write
+// never leaves it empty. Secret keys are written 0600, in a directory
+// created 0700. Permission failures (e.g. a read-only home directory in a
+// locked-down container) are reported with a suggested fix.
+func writeConfigFile(dir, name string, data []byte) error {
+	dirMode := os.FileMode(0755)
+	if secretConfigKeys[name] {
+		dirMode = 0700
+	}
+	err := os.MkdirAll(dir, dirMode)
+	if err == nil {
+		err = writeFileAtomic(filepath.Join(dir, name), data, configFileMode(name))
+	}
+	return configWriteError(dir, err)
+}
+
+// configWriteError adds the suggested fix to a permission or read-only
+// file system error from writing in the configuration directory dir
+func configWriteError(dir string, err error) error {
+	if err != nil && (errors.Is(err, fs.ErrPermission) || errors.Is(err, syscall.EROFS)) {
+		return fmt.Errorf("configuration directory %s is not writable: %w\nUse --config <dir> or set XDG_CONFIG_HOME to a writable location", dir, err)
+	}
+	return err
+}
+
+// writeFileAtomic replaces path with data by writing a temporary file in the
+// same directory and renaming it into place, so readers see either the old
+// or the new contents. The temporary file is a dotfile, which the
+// configuration check ignores should a crash leave it behind.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmpPath, perm)
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+	}
+	return err
+}
+
 func init() {
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configGetCmd)
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// configEntry describes a file the CLI reads from a configuration directory
+type configEntry struct {
+	dir bool
+
+	// validate checks the trimmed contents; nil accepts anything. Errors
+	// name the key and the expected format.
+	validate func(value string) error
+}
+
+// rootConfigEntries are the files known in the configuration directory
+var rootConfigEntries = map[string]configEntry{
+	"config":                 {validate: validateEndpointURL}, // endpoint
+	tlsMinVersionKey:         {validate: func(v string) error { _, err := parseTLSMinVersion(v); return err }},
+	tlsCiphersKey:            {validate: func(v string) error { _, err := parseTLSCiphers(v); return err }},
+	pinSHA256Key:             {validate: func(v string) error { _, err := parseCertPins(v); return err }},
+	tokenKey:                 {},
+	tokenFileKey:             {},
+	noIntroKey:               {validate: func(v string) error { _, err := parseNoIntro(v); return err }},
+	sourceTypesCacheKey:      {validate: validateBoolValue(sourceTypesCacheKey)},
+	sourceTypesCacheFileName: {},
+	namePrefixKey:            {validate: validateNamePrefix},
+	defaultOutputDirKey:      {},
+	registryKey:              {validate: validateRegistry},
+	registryTokenKey:         {},
+	"access-analyzer":        {dir: true},
+}
+
+// aaConfigEntries are the files known in the Access Analyzer directory
+var aaConfigEntries = map[string]configEntry{
+	"endpoint":         {validate: validateEndpointURL},
+	"profile":          {validate: validateProfileName},
+	"profiles":         {dir: true},
+	sourceTagsFileName: {},
+}
+
+// validateBoolValue returns a validator for a true/false key
+func validateBoolValue(key string) func(string) error {
+	return func(value string) error {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid %s '%s' (expected true or false)", key, value)
+		}
+		return nil
+	}
+}
+
+var (
+	configCheckOnce sync.Once
+	configCheckErr  error
+)
+
+// checkConfigOnce validates the configuration the first time it is loaded,
+// printing warnings to stderr. Later calls return the first result.
+func checkConfigOnce() error {
+	configCheckOnce.Do(func() {
+		var warnings []string
+		warnings, configCheckErr = checkConfig()
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, glyphs("⚠️  %s\n"), warning)
+		}
+	})
+	return configCheckErr
+}
+
+// checkConfig validates the configuration directories. Unknown files, such
+// as a misspelled key, are returned as warnings since they are ignored;
+// malformed values of known keys are errors.
+func checkConfig() ([]string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	aaConfigDir, err := getAAConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	profilesDir, err := getAAProfilesDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	var errs []error
+	check := func(dir string, entries map[string]configEntry) {
+		w, e := checkConfigDir(dir, entries)
+		warnings = append(warnings, w...)
+		errs = append(errs, e...)
+	}
+	check(configDir, rootConfigEntries)
+	check(aaConfigDir, aaConfigEntries)
+
+	// Every file in profiles is a profile endpoint
+	profiles, err := listAAProfiles()
+	if err != nil {
+		return nil, err
+	}
+	profileEntries := make(map[string]configEntry, len(profiles))
+	for _, name := range profiles {
+		profileEntries[name] = configEntry{validate: validateEndpointURL}
+	}
+	check(profilesDir, profileEntries)
+
+	return warnings, errors.Join(errs...)
+}
+
+// checkConfigDir validates the files in dir against the known entries
+func checkConfigDir(dir string, entries map[string]configEntry) ([]string, []error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var warnings []string
+	var errs []error
+	for _, file := range files {
+		name := file.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+
+		entry, known := entries[name]
+		if !known || entry.dir != file.IsDir() {
+			warning := fmt.Sprintf("unknown configuration key '%s' in %s is ignored", name, dir)
+			if suggestion := closestConfigKey(name, entries); suggestion != "" {
+				warning += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+			}
+			warnings = append(warnings, warning)
+			continue
+		}
+		if entry.validate == nil {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := entry.validate(strings.TrimSpace(string(data))); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+	return warnings, errs
+}
+
+// closestConfigKey returns the known key within two edits of name, or ""
+func closestConfigKey(name string, entries map[string]configEntry) string {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	best, bestDistance := "", 3
+	for _, key := range keys {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(key)); d < bestDistance {
+			best, bestDistance = key, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckConfigValidDirectory(t *testing.T) {
+	dir := useTempConfigDir(t)
+	writeTestFile(t, filepath.Join(dir, "config"), "http://localhost:3020")
+	writeTestFile(t, filepath.Join(dir, tlsMinVersionKey), "1.3")
+	writeTestFile(t, filepath.Join(dir, sourceTypesCacheKey), "true")
+	writeTestFile(t, filepath.Join(dir, namePrefixKey), "dev-alice-")
+	writeTestFile(t, filepath.Join(dir, ".config.tmp-123"), "left behind by a crash")
+	writeTestFile(t, filepath.Join(dir, "access-analyzer", "endpoint"), "https://aa.example.com")
+	writeTestFile(t, filepath.Join(dir, "access-analyzer", "profile"), "dev")
+	writeTestFile(t, filepath.Join(dir, "access-analyzer", "profiles", "dev"), "localhost:3020")
+
+	warnings, err := checkConfig()
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("checkConfig() = %v, %v, want no warnings or errors", warnings, err)
+	}
+}
+
+func TestCheckConfigUnknownKeys(t *testing.T) {
+	dir := useTempConfigDir(t)
+	writeTestFile(t, filepath.Join(dir, "tls-min-versoin"), "1.2")
+	writeTestFile(t, filepath.Join(dir, "notes.txt"), "hello")
+	writeTestFile(t, filepath.Join(dir, "access-analyzer", "endpont"), "http://localhost:3020")
+	// A known key with the wrong kind of entry
+	if err := os.MkdirAll(filepath.Join(dir, namePrefixKey), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	warnings, err := checkConfig()
+	if err != nil {
+		t.Fatalf("unknown keys are not errors: %v", err)
+	}
+	want := []string{
+		"unknown configuration key 'name-prefix'",
+		"unknown configuration key 'notes.txt' in " + dir + " is ignored",
+		"unknown configuration key 'tls-min-versoin' in " + dir + " is ignored (did you mean 'tls-min-version'?)",
+		"unknown configuration key 'endpont' in " + filepath.Join(dir, "access-analyzer") + " is ignored (did you mean 'endpoint'?)",
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("warnings = %q, want %d", warnings, len(want))
+	}
+	for _, w := range want {
+		found := false
+		for _, warning := range warnings {
+			found = found || strings.HasPrefix(warning, w)
+		}
+		if !found {
+			t.Errorf("no warning starting with %q in %q", w, warnings)
+		}
+	}
+	for _, warning := range warnings {
+		if strings.Contains(warning, "notes.txt") && strings.Contains(warning, "did you mean") {
+			t.Errorf("unexpected suggestion: %s", warning)
+		}
+	}
+}
+
+func TestCheckConfigBadValues(t *testing.T) {
+	dir := useTempConfigDir(t)
+	aaDir := filepath.Join(dir, "access-analyzer")
+	bad := map[string]string{
+		filepath.Join(dir, "config"):                "ftp://example.com",
+		filepath.Join(dir, tlsMinVersionKey):        "1.9",
+		filepath.Join(dir, sourceTypesCacheKey):     "maybe",
+		filepath.Join(aaDir, "endpoint"):            "http://",
+		filepath.Join(aaDir, "profiles", "staging"): "not a url at all://",
+	}
+	for path, value := range bad {
+		writeTestFile(t, path, value)
+	}
+
+	_, err := checkConfig()
+	if err == nil {
+		t.Fatal("checkConfig() accepted malformed values")
+	}
+	for path := range bad {
+		if !strings.Contains(err.Error(), path+": ") {
+			t.Errorf("error does not name %s:\n%v", path, err)
+		}
+	}
+	if !strings.Contains(err.Error(), "expected true or false") {
+		t.Errorf("error does not give the expected format:\n%v", err)
+	}
+}
+
+func TestClosestConfigKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Config", "config"},
+		{"regstry", "registry"},
+		{"tokn", "token"},
+		{"something-else", ""},
+	}
+	for _, tt := range tests {
+		if got := closestConfigKey(tt.name, rootConfigEntries); got != tt.want {
+			t.Errorf("closestConfigKey(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
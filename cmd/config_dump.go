@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var configDumpOutput string
+
+// configDumpEntry is one resolved setting: its value, with secrets masked,
+// where it came from (flag, env, file or default) and the file holding it
+type configDumpEntry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Path   string `json:"path,omitempty"`
+	Mode   string `json:"mode,omitempty"`
+}
+
+// configDump is the resolved configuration printed by 'nwx config dump'
+type configDump struct {
+	Version         string            `json:"version"`
+	ConfigDir       string            `json:"configDir"`
+	ConfigDirSource string            `json:"configDirSource"`
+	ConfigDirMode   string            `json:"configDirMode"`
+	Settings        []configDumpEntry `json:"settings"`
+	Warnings        []string          `json:"warnings,omitempty"`
+}
+
+var configDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print the resolved configuration for bug reports",
+	Long: `Print every configuration setting as this command would use it: its value,
+where it came from (a flag, an environment variable, a file in the
+configuration directory, or the default), and the file's path and
+permissions. Tokens are masked and credentials in endpoints are redacted,
+so the output can be pasted into a bug report.
+
+Global flags such as --config, --api-endpoint and --token-file are taken
+into account, so pass the same ones as the failing command.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(configDumpOutput, outputText, outputJSON); err != nil {
+			return err
+		}
+		dump, err := dumpConfig()
+		if err != nil {
+			return err
+		}
+		if configDumpOutput == outputJSON {
+			return printJSON(dump)
+		}
+		printConfigDump(dump)
+		return nil
+	},
+}
+
+// dumpConfig resolves every setting in the order the CLI applies them
+func dumpConfig() (*configDump, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	aaDir, err := getAAConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	dump := &configDump{
+		Version:         version,
+		ConfigDir:       configDir,
+		ConfigDirSource: configDirSource(configDir),
+		ConfigDirMode:   fileMode(configDir),
+	}
+	add := func(entry configDumpEntry) {
+		dump.Settings = append(dump.Settings, entry)
+	}
+
+	add(dumpEndpoint(configDir, aaDir))
+	add(dumpSetting("profile", profileOverrideFlag, "--use-profile", profileEnvVar, aaDir, "profile", false, "<not configured>"))
+	add(dumpSetting(tokenKey, "", "", tokenEnvVar, configDir, tokenKey, true, "<not configured>"))
+	add(dumpSetting(tokenFileKey, tokenFileFlag, "--token-file", "", configDir, tokenFileKey, false, "<not configured>"))
+	for _, key := range []string{tlsMinVersionKey, tlsCiphersKey, pinSHA256Key} {
+		add(dumpSetting(key, "", "", "", configDir, key, false, tlsDefaultDescription(key)))
+	}
+	noIntro := ""
+	if noIntroFlag {
+		noIntro = "true"
+	}
+	add(dumpSetting(noIntroKey, noIntro, "--no-intro", "", configDir, noIntroKey, false, "<not configured, default false>"))
+	add(dumpSetting(sourceTypesCacheKey, "", "", "", configDir, sourceTypesCacheKey, false, "<not configured, default false>"))
+	add(dumpSetting(namePrefixKey, "", "", "", configDir, namePrefixKey, false, "<not configured>"))
+	add(dumpSetting(defaultOutputDirKey, "", "", "", configDir, defaultOutputDirKey, false, "<not configured, default ./{name}>"))
+	add(dumpSetting(registryKey, "", "", "", configDir, registryKey, false, "<not configured, default "+defaultRegistry+">"))
+	add(dumpSetting(registryTokenKey, "", "", registryTokenEnvVar, configDir, registryTokenKey, true, "<not configured>"))
+
+	warnings, err := checkConfig()
+	dump.Warnings = warnings
+	if err != nil {
+		dump.Warnings = append(dump.Warnings, err.Error())
+	}
+	return dump, nil
+}
+
+// dumpSetting resolves a setting from, in order, its flag, its environment
+// variable and its file. Secret values are masked.
+func dumpSetting(key, flagValue, flagName, envVar, dir, file string, secret bool, unset string) configDumpEntry {
+	entry := configDumpEntry{Key: key}
+	path := filepath.Join(dir, file)
+	value := ""
+	switch {
+	case flagValue != "":
+		value, entry.Source = flagValue, "flag "+flagName
+	case envVar != "" && strings.TrimSpace(os.Getenv(envVar)) != "":
+		value, entry.Source = strings.TrimSpace(os.Getenv(envVar)), "env "+envVar
+	default:
+		data, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			entry.Value, entry.Source = unset, "default"
+			return entry
+		case err != nil:
+			entry.Value, entry.Source = fmt.Sprintf("<error: %v>", err), "file"
+		default:
+			value, entry.Source = strings.TrimSpace(string(data)), "file"
+		}
+		entry.Path, entry.Mode = path, fileMode(path)
+	}
+
+	if entry.Value == "" {
+		entry.Value = redactSecrets(value)
+		if secret && value != "" {
+			entry.Value = maskedValue
+		}
+	}
+	return entry
+}
+
+// dumpEndpoint resolves the endpoint as API commands do, with the file it
+// was read from
+func dumpEndpoint(configDir, aaDir string) configDumpEntry {
+	entry := configDumpEntry{Key: "endpoint"}
+	resolved, err := ResolveEndpoint(globalEndpointFlags())
+	switch {
+	case err != nil:
+		entry.Value, entry.Source = fmt.Sprintf("<error: %v>", err), "-"
+		return entry
+	case resolved.Endpoint == "":
+		entry.Value, entry.Source = "<not configured>", "default"
+		return entry
+	}
+	entry.Value, entry.Source = redactSecrets(resolved.Endpoint), resolved.Source
+
+	switch {
+	case strings.HasPrefix(resolved.Source, "profile "):
+		entry.Path = filepath.Join(aaDir, "profiles", strings.TrimPrefix(resolved.Source, "profile "))
+	case resolved.Source == "config (nwx aa config)":
+		entry.Path = filepath.Join(aaDir, "endpoint")
+	case resolved.Source == "config (nwx config endpoint)":
+		entry.Path = filepath.Join(configDir, "config")
+	}
+	if entry.Path != "" {
+		entry.Mode = fileMode(entry.Path)
+	}
+	return entry
+}
+
+// configDirSource tells where the configuration directory was chosen
+func configDirSource(configDir string) string {
+	if configDirFlag != "" {
+		return "flag --config"
+	}
+	if xdgDir := os.Getenv("XDG_CONFIG_HOME"); xdgDir != "" && configDir == filepath.Join(xdgDir, "nwx") {
+		return "env XDG_CONFIG_HOME"
+	}
+	return "default"
+}
+
+// fileMode returns the permissions of path, or a note when it is missing
+func fileMode(path string) string {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "<missing>"
+	}
+	if err != nil {
+		return fmt.Sprintf("<error: %v>", err)
+	}
+	return info.Mode().String()
+}
+
+// printConfigDump prints the dump as aligned text
+func printConfigDump(dump *configDump) {
+	fmt.Printf("nwx version:          %s\n", dump.Version)
+	fmt.Printf("Configuration dir:    %s (%s, %s)\n", dump.ConfigDir, dump.ConfigDirSource, dump.ConfigDirMode)
+	fmt.Println()
+
+	rows := make([][]string, 0, len(dump.Settings))
+	for _, entry := range dump.Settings {
+		file := "-"
+		if entry.Path != "" {
+			file = entry.Path + " (" + entry.Mode + ")"
+		}
+		rows = append(rows, []string{entry.Key, entry.Value, entry.Source, file})
+	}
+	printTable([]string{"KEY", "VALUE", "SOURCE", "FILE"}, rows)
+
+	if len(dump.Warnings) > 0 {
+		fmt.Println()
+		for _, warning := range dump.Warnings {
+			fmt.Printf(glyphs("⚠️  %s\n"), warning)
+		}
+	}
+}
+
+func init() {
+	configDumpCmd.Flags().StringVarP(&configDumpOutput, "output", "o", outputText, "Output format (text|json)")
+
+	configCmd.AddCommand(configDumpCmd)
+}
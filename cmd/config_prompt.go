@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// maskedValue replaces secret values in summaries
+const maskedValue = "********"
+
+// ConfigValue is a value entered for a connection config field
+type ConfigValue struct {
+	Item  SpecConfigItem
+	Value string
+}
+
+// isSecretConfigItem reports whether a config field holds a secret that must
+// not be echoed or printed
+func isSecretConfigItem(item SpecConfigItem) bool {
+	return item.Type == "password"
+}
+
+// newConfigItemPrompt returns the prompt for a config field: a masked
+// password prompt for secrets and a plain input otherwise. Secrets never
+// get a default, so a stored value is not shown.
+func newConfigItemPrompt(item SpecConfigItem) survey.Prompt {
+	message := valueOr(item.Label, item.Key) + ":"
+	if isSecretConfigItem(item) {
+		return &survey.Password{Message: message, Help: item.Description}
+	}
+
+	prompt := &survey.Input{Message: message, Help: item.Description}
+	if item.Default != nil {
+		prompt.Default = fmt.Sprint(item.Default)
+	}
+	return prompt
+}
+
+// maskConfigValue returns value for display, masked when the field is a secret
+func maskConfigValue(item SpecConfigItem, value string) string {
+	if isSecretConfigItem(item) && value != "" {
+		return maskedValue
+	}
+	return value
+}
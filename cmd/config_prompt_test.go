@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- string(data)
+	}()
+	defer func() {
+		os.Stdout = saved
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
+
+var (
+	hostItem     = SpecConfigItem{Key: "host", Label: "Host", Type: "text", Default: "db.example.com"}
+	passwordItem = SpecConfigItem{Key: "password", Label: "Password", Type: "password", Default: "stored"}
+)
+
+func TestNewConfigItemPrompt(t *testing.T) {
+	input, ok := newConfigItemPrompt(hostItem).(*survey.Input)
+	if !ok || input.Default != "db.example.com" {
+		t.Errorf("prompt for a text field = %#v, want an input with the default", newConfigItemPrompt(hostItem))
+	}
+	if _, ok := newConfigItemPrompt(passwordItem).(*survey.Password); !ok {
+		t.Errorf("prompt for a password field = %#v, want a password prompt", newConfigItemPrompt(passwordItem))
+	}
+}
+
+func TestMaskConfigValue(t *testing.T) {
+	tests := []struct {
+		item  SpecConfigItem
+		value string
+		want  string
+	}{
+		{hostItem, "db.example.com", "db.example.com"},
+		{passwordItem, "hunter2", maskedValue},
+		{passwordItem, "", ""},
+	}
+	for _, tt := range tests {
+		if got := maskConfigValue(tt.item, tt.value); got != tt.want {
+			t.Errorf("maskConfigValue(%s, %q) = %q, want %q", tt.item.Key, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestScannerSummaryMasksSecrets(t *testing.T) {
+	scanner := &ScannerCreationData{
+		Name:     "my-scanner",
+		Version:  "1.0.0",
+		Language: "python",
+		ConnectionValues: []ConfigValue{
+			{Item: hostItem, Value: "db.example.com"},
+			{Item: passwordItem, Value: "hunter2"},
+		},
+	}
+
+	text := captureStdout(t, func() { printScannerSummary(scanner) })
+	if strings.Contains(text, "hunter2") {
+		t.Errorf("text summary shows the password:\n%s", text)
+	}
+	if !strings.Contains(text, "db.example.com") || !strings.Contains(text, maskedValue) {
+		t.Errorf("text summary is missing the connection values:\n%s", text)
+	}
+
+	data, err := json.Marshal(newScannerSummary(scanner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary struct {
+		ConnectionValues map[string]string `json:"connectionValues"`
+	}
+	if err := json.Unmarshal(data, &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.ConnectionValues["password"] != maskedValue || summary.ConnectionValues["host"] != "db.example.com" {
+		t.Errorf("JSON summary connection values = %v", summary.ConnectionValues)
+	}
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// useTempConfigDir points the configuration directory at a fresh temporary
+// directory for the duration of a test
+func useTempConfigDir(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "nwx")
+	old := configDirFlag
+	configDirFlag = dir
+	t.Cleanup(func() { configDirFlag = old })
+	return dir
+}
+
+func TestWriteConfigValueSecretPermissions(t *testing.T) {
+	tests := []struct {
+		key      string
+		wantFile os.FileMode
+		wantDir  os.FileMode
+	}{
+		{tokenKey, 0600, 0700},
+		{registryTokenKey, 0600, 0700},
+		{namePrefixKey, 0644, 0755},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			dir := useTempConfigDir(t)
+			if err := writeConfigValue(tt.key, "value"); err != nil {
+				t.Fatal(err)
+			}
+			info, err := os.Stat(filepath.Join(dir, tt.key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := info.Mode().Perm(); got != tt.wantFile {
+				t.Errorf("file mode = %v, want %v", got, tt.wantFile)
+			}
+			info, err = os.Stat(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := info.Mode().Perm(); got&^tt.wantDir != 0 {
+				t.Errorf("directory mode = %v, want at most %v", got, tt.wantDir)
+			}
+		})
+	}
+}
+
+func TestWriteConfigValueTightensExistingSecret(t *testing.T) {
+	dir := useTempConfigDir(t)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, tokenKey)
+	if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeConfigValue(tokenKey, "new"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0600 {
+		t.Errorf("file mode = %v, want 0600", got)
+	}
+}
+
+func TestSetTokenFileStoresAbsolutePath(t *testing.T) {
+	useTempConfigDir(t)
+	work := t.TempDir()
+	if err := os.WriteFile(filepath.Join(work, "token"), []byte("secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(work)
+
+	if err := setTokenFile("token"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := readConfigValue(tokenFileKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(work, "token"); got != want {
+		t.Errorf("stored token-file = %q, want %q", got, want)
+	}
+}
+
+func TestConfigWriteError(t *testing.T) {
+	tests := []struct {
+		err      error
+		wantHint bool
+	}{
+		{nil, false},
+		{&fs.PathError{Op: "open", Path: "/home/nwx/.nwx/config", Err: syscall.EACCES}, true},
+		{&fs.PathError{Op: "mkdir", Path: "/home/nwx/.nwx", Err: syscall.EROFS}, true},
+		{&fs.PathError{Op: "open", Path: "/home/nwx/.nwx/config", Err: syscall.ENOSPC}, false},
+	}
+	for _, tt := range tests {
+		err := configWriteError("/home/nwx/.nwx", tt.err)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("configWriteError(%v) = %v, does not wrap the original error", tt.err, err)
+		}
+		if hint := err != nil && strings.Contains(err.Error(), "--config <dir>"); hint != tt.wantHint {
+			t.Errorf("configWriteError(%v) = %v, want hint %v", tt.err, err, tt.wantHint)
+		}
+	}
+}
+
+func TestWriteConfigFileReadOnlyDir(t *testing.T) {
+	parent := t.TempDir()
+	if err := os.Chmod(parent, 0500); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(parent, 0700) })
+	if probe, err := os.CreateTemp(parent, "probe"); err == nil {
+		probe.Close()
+		os.Remove(probe.Name())
+		t.Skip("the directory is writable despite its mode (running as root)")
+	}
+
+	dir := filepath.Join(parent, "nwx")
+	err := writeConfigFile(dir, namePrefixKey, []byte("dev-"))
+	if err == nil || !errors.Is(err, fs.ErrPermission) {
+		t.Fatalf("writeConfigFile in a read-only directory = %v, want a permission error", err)
+	}
+	if !strings.Contains(err.Error(), "configuration directory "+dir+" is not writable") || !strings.Contains(err.Error(), "XDG_CONFIG_HOME") {
+		t.Errorf("error does not suggest a fix: %v", err)
+	}
+}
+
+// testFileSizeLimit is the file size limit of withFileSizeLimit. It is
+// well above the size of the files tests write, including the log of the
+// test binary itself.
+const testFileSizeLimit = 4 << 20
+
+// withFileSizeLimit runs fn with the size of files this process may write
+// limited to testFileSizeLimit, so a write of more than that fails part way
+// through, even as root. Go ignores SIGXFSZ, so the write returns EFBIG
+// instead of killing the test.
+func withFileSizeLimit(t *testing.T, fn func()) {
+	t.Helper()
+	var saved syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_FSIZE, &saved); err != nil {
+		t.Fatal(err)
+	}
+	small := saved
+	small.Cur = testFileSizeLimit
+	if err := syscall.Setrlimit(syscall.RLIMIT_FSIZE, &small); err != nil {
+		t.Skipf("cannot limit the file size: %v", err)
+	}
+	defer func() {
+		if err := syscall.Setrlimit(syscall.RLIMIT_FSIZE, &saved); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	fn()
+}
+
+func TestWriteConfigFileFailureKeepsOriginal(t *testing.T) {
+	dir := useTempConfigDir(t)
+	if err := writeConfigFile(dir, namePrefixKey, []byte("dev-alice-")); err != nil {
+		t.Fatal(err)
+	}
+
+	var err error
+	withFileSizeLimit(t, func() {
+		err = writeConfigFile(dir, namePrefixKey, []byte(strings.Repeat("x", 2*testFileSizeLimit)))
+	})
+
+	if !errors.Is(err, syscall.EFBIG) {
+		t.Fatalf("writeConfigFile past the file size limit = %v, want EFBIG", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, namePrefixKey))
+	if err != nil || string(data) != "dev-alice-" {
+		t.Errorf("%s after a failed write = %q, %v, want the original value", namePrefixKey, data, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != namePrefixKey {
+			t.Errorf("failed write left %s behind", entry.Name())
+		}
+	}
+}
+
+func TestGetConfigDirPrecedence(t *testing.T) {
+	home := t.TempDir()
+	xdg := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	saved := configDirFlag
+	t.Cleanup(func() { configDirFlag = saved })
+
+	check := func(want string) {
+		t.Helper()
+		got, err := getConfigDir()
+		if err != nil || got != want {
+			t.Errorf("getConfigDir() = %q, %v, want %q", got, err, want)
+		}
+	}
+
+	configDirFlag = ""
+	check(filepath.Join(xdg, "nwx"))
+
+	if err := os.Mkdir(filepath.Join(home, ".nwx"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	check(filepath.Join(home, ".nwx"))
+
+	configDirFlag = filepath.Join(t.TempDir(), "custom")
+	check(configDirFlag)
+}
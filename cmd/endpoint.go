@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// normalizeEndpoint turns a pasted endpoint such as "host:3020",
+// "http://host:3020/" or "https://host/api/" into a base URL without a
+// trailing slash. A missing scheme defaults to http for localhost and
+// https otherwise; the assumed scheme is returned so callers can warn.
+func normalizeEndpoint(endpoint string) (normalized, assumedScheme string, err error) {
+	invalid := fmt.Errorf("invalid endpoint '%s' (expected an http or https URL)", endpoint)
+	value := strings.TrimSpace(endpoint)
+	if value == "" {
+		return "", "", invalid
+	}
+
+	if !strings.Contains(value, "://") {
+		assumedScheme = "https"
+		if isLocalHost(value) {
+			assumedScheme = "http"
+		}
+		value = assumedScheme + "://" + value
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", "", invalid
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+	return u.String(), assumedScheme, nil
+}
+
+// isLocalHost reports whether a schemeless endpoint points at this machine
+func isLocalHost(endpoint string) bool {
+	hostPort, _, _ := strings.Cut(endpoint, "/")
+	host := hostPort
+	if h, _, err := net.SplitHostPort(hostPort); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// cleanEndpoint normalizes an endpoint entered by the user, warning on
+// stderr when a scheme had to be assumed
+func cleanEndpoint(endpoint string) (string, error) {
+	normalized, assumedScheme, err := normalizeEndpoint(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if assumedScheme != "" {
+		fmt.Fprintf(os.Stderr, glyphs("⚠️  Endpoint '%s' has no scheme; using %s\n"), strings.TrimSpace(endpoint), normalized)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables that select the Access Analyzer endpoint
+const (
+	endpointEnvVar = "NWX_ENDPOINT"
+	profileEnvVar  = "NWX_PROFILE"
+)
+
+var (
+	// endpointOverrideFlag is the global --api-endpoint flag
+	endpointOverrideFlag string
+
+	// profileOverrideFlag is the global --use-profile flag
+	profileOverrideFlag string
+)
+
+// EndpointFlags are the command-line inputs to endpoint resolution
+type EndpointFlags struct {
+	Endpoint string // --api-endpoint
+	Profile  string // --use-profile
+}
+
+// ResolvedEndpoint is an endpoint and where it was found
+type ResolvedEndpoint struct {
+	Endpoint string
+	Source   string // e.g. "flag --api-endpoint", "env NWX_ENDPOINT", "profile prod"
+}
+
+// globalEndpointFlags returns the global --api-endpoint and --use-profile flags
+func globalEndpointFlags() EndpointFlags {
+	return EndpointFlags{Endpoint: endpointOverrideFlag, Profile: profileOverrideFlag}
+}
+
+// ResolveEndpoint finds the Access Analyzer endpoint. The first of these
+// that is set wins:
+//
+//  1. the --api-endpoint flag
+//  2. $NWX_ENDPOINT
+//  3. the profile named by --use-profile or $NWX_PROFILE
+//  4. the saved endpoint ('nwx aa config --endpoint', which 'nwx aa use'
+//     also sets), then the 'endpoint' key of 'nwx config'
+//
+// An empty Endpoint with no error means none is configured.
+func ResolveEndpoint(flags EndpointFlags) (ResolvedEndpoint, error) {
+	if flags.Endpoint != "" {
+		return ResolvedEndpoint{Endpoint: flags.Endpoint, Source: "flag --api-endpoint"}, nil
+	}
+	if endpoint := strings.TrimSpace(os.Getenv(endpointEnvVar)); endpoint != "" {
+		return ResolvedEndpoint{Endpoint: endpoint, Source: "env " + endpointEnvVar}, nil
+	}
+
+	profile, profileSource := flags.Profile, "flag --use-profile"
+	if profile == "" {
+		profile, profileSource = strings.TrimSpace(os.Getenv(profileEnvVar)), "env "+profileEnvVar
+	}
+	if profile != "" {
+		endpoint, err := getAAProfileEndpoint(profile)
+		if err != nil {
+			return ResolvedEndpoint{}, fmt.Errorf("%w (from %s)", err, profileSource)
+		}
+		return ResolvedEndpoint{Endpoint: endpoint, Source: "profile " + profile}, nil
+	}
+
+	endpoint, err := getAAEndpoint()
+	if err != nil {
+		return ResolvedEndpoint{}, err
+	}
+	if endpoint != "" {
+		return ResolvedEndpoint{Endpoint: endpoint, Source: "config (nwx aa config)"}, nil
+	}
+	endpoint, err = getEndpoint()
+	if err != nil {
+		return ResolvedEndpoint{}, err
+	}
+	if endpoint != "" {
+		return ResolvedEndpoint{Endpoint: endpoint, Source: "config (nwx config endpoint)"}, nil
+	}
+	return ResolvedEndpoint{}, nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveEndpointPrecedence(t *testing.T) {
+	const (
+		flagURL    = "https://flag.example.com"
+		envURL     = "https://env.example.com"
+		profileURL = "https://profile.example.com"
+		savedURL   = "https://saved.example.com"
+		legacyURL  = "https://legacy.example.com"
+	)
+
+	tests := []struct {
+		name       string
+		flags      EndpointFlags
+		env        string
+		envProfile string
+		saved      bool
+		legacy     bool
+		want       ResolvedEndpoint
+	}{
+		{
+			name:   "flag wins over everything",
+			flags:  EndpointFlags{Endpoint: flagURL, Profile: "prod"},
+			env:    envURL,
+			saved:  true,
+			legacy: true,
+			want:   ResolvedEndpoint{Endpoint: flagURL, Source: "flag --api-endpoint"},
+		},
+		{
+			name:  "env wins over profile and saved",
+			flags: EndpointFlags{Profile: "prod"},
+			env:   envURL,
+			saved: true,
+			want:  ResolvedEndpoint{Endpoint: envURL, Source: "env " + endpointEnvVar},
+		},
+		{
+			name:  "profile flag wins over saved",
+			flags: EndpointFlags{Profile: "prod"},
+			saved: true,
+			want:  ResolvedEndpoint{Endpoint: profileURL, Source: "profile prod"},
+		},
+		{
+			name:       "profile env wins over saved",
+			envProfile: "prod",
+			saved:      true,
+			want:       ResolvedEndpoint{Endpoint: profileURL, Source: "profile prod"},
+		},
+		{
+			name:   "saved aa endpoint wins over nwx config endpoint",
+			saved:  true,
+			legacy: true,
+			want:   ResolvedEndpoint{Endpoint: savedURL, Source: "config (nwx aa config)"},
+		},
+		{
+			name:   "nwx config endpoint is the last resort",
+			legacy: true,
+			want:   ResolvedEndpoint{Endpoint: legacyURL, Source: "config (nwx config endpoint)"},
+		},
+		{
+			name: "nothing configured",
+			want: ResolvedEndpoint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := useTempConfigDir(t)
+			aaDir := filepath.Join(dir, "access-analyzer")
+			writeTestFile(t, filepath.Join(aaDir, "profiles", "prod"), profileURL)
+			if tt.saved {
+				writeTestFile(t, filepath.Join(aaDir, "endpoint"), savedURL)
+			}
+			if tt.legacy {
+				writeTestFile(t, filepath.Join(dir, "config"), legacyURL)
+			}
+			t.Setenv(endpointEnvVar, tt.env)
+			t.Setenv(profileEnvVar, tt.envProfile)
+
+			got, err := ResolveEndpoint(tt.flags)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveEndpoint() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveEndpointUnknownProfile(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv(endpointEnvVar, "")
+	t.Setenv(profileEnvVar, "")
+
+	if _, err := ResolveEndpoint(EndpointFlags{Profile: "missing"}); err == nil {
+		t.Fatal("expected an error for an unknown profile")
+	}
+}
+
+// writeTestFile writes content to path, creating its directory
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import "testing"
+
+func TestNormalizeEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint      string
+		want          string
+		assumedScheme string
+	}{
+		{"host:3020", "https://host:3020", "https"},
+		{"aa.example.com/api/", "https://aa.example.com/api", "https"},
+		{"localhost:3020/", "http://localhost:3020", "http"},
+		{"LocalHost", "http://LocalHost", "http"},
+		{"127.0.0.1:3020", "http://127.0.0.1:3020", "http"},
+		{"127.8.9.10", "http://127.8.9.10", "http"},
+		{"[::1]:3020", "http://[::1]:3020", "http"},
+		{"localhost.example.com", "https://localhost.example.com", "https"},
+		{"http://host:3020/", "http://host:3020", ""},
+		{"https://host/api/", "https://host/api", ""},
+		{"  https://host//  ", "https://host", ""},
+		{"HTTPS://host", "https://host", ""},
+		{"http://localhost:3020", "http://localhost:3020", ""},
+	}
+	for _, tt := range tests {
+		got, assumedScheme, err := normalizeEndpoint(tt.endpoint)
+		if err != nil {
+			t.Errorf("normalizeEndpoint(%q) error: %v", tt.endpoint, err)
+			continue
+		}
+		if got != tt.want || assumedScheme != tt.assumedScheme {
+			t.Errorf("normalizeEndpoint(%q) = %q, %q, want %q, %q", tt.endpoint, got, assumedScheme, tt.want, tt.assumedScheme)
+		}
+	}
+}
+
+func TestNormalizeEndpointInvalid(t *testing.T) {
+	for _, endpoint := range []string{"", "   ", "ftp://host", "file:///etc/passwd", "http://", "://host", "https://[::1"} {
+		if got, _, err := normalizeEndpoint(endpoint); err == nil {
+			t.Errorf("normalizeEndpoint(%q) = %q, want an error", endpoint, got)
+		}
+	}
+}
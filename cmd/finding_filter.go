@@ -0,0 +1,31 @@
+package cmd
+
+import "fmt"
+
+// Values of the --only flag of 'scanner doctor' and 'scanner validate'
+const (
+	onlyAll    = "all"
+	onlyErrors = "errors"
+)
+
+// validateOnlyFlag checks an --only value
+func validateOnlyFlag(only string) error {
+	if only != onlyAll && only != onlyErrors {
+		return fmt.Errorf("invalid --only '%s' (expected all or errors)", only)
+	}
+	return nil
+}
+
+// filterFindings drops the warnings when only is "errors"
+func filterFindings(findings []SpecFinding, only string) []SpecFinding {
+	if only != onlyErrors {
+		return findings
+	}
+	filtered := []SpecFinding{}
+	for _, f := range findings {
+		if f.Severity == severityError {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileWriteError is a generated file that could not be written
+type FileWriteError struct {
+	Name string
+	Err  error
+}
+
+// GenerationError lists every generated file that could not be written, so
+// a problem affecting several files (such as permissions) shows in one run
+type GenerationError struct {
+	OutputDir  string
+	Failures   []FileWriteError
+	RolledBack bool
+}
+
+func (e *GenerationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to write %d file(s) in %s:", len(e.Failures), e.OutputDir)
+	for _, f := range e.Failures {
+		// The path is already in the message; keep just the cause
+		err := f.Err
+		var pathErr *fs.PathError
+		var linkErr *os.LinkError
+		if errors.As(err, &pathErr) {
+			err = pathErr.Err
+		} else if errors.As(err, &linkErr) {
+			err = linkErr.Err
+		}
+		fmt.Fprintf(&b, "\n  %s: %v", f.Name, err)
+	}
+	if e.RolledBack {
+		b.WriteString("\nThe files that were written have been rolled back")
+	}
+	return b.String()
+}
+
+// Unwrap returns the individual write errors for errors.Is and errors.As
+func (e *GenerationError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// previousFile is the content and permissions of a file before it was replaced
+type previousFile struct {
+	data []byte
+	perm os.FileMode
+}
+
+// writeFilesWithRollback writes files to dir atomically, attempting every
+// file even after a failure, and calls wrote after each file is written. A
+// replaced file keeps its permissions; new files are created 0644. If any
+// write fails, the files written are rolled back, restoring the previous
+// contents and permissions of files that were replaced, and a
+// *GenerationError listing all the failures is returned.
+func writeFilesWithRollback(dir string, files []GeneratedFile, wrote func(GeneratedFile, time.Duration)) error {
+	previous := make(map[string]previousFile)
+	for _, file := range files {
+		path := filepath.Join(dir, file.Name)
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if data, err := os.ReadFile(path); err == nil {
+			previous[file.Name] = previousFile{data: data, perm: info.Mode().Perm()}
+		}
+	}
+
+	genErr := &GenerationError{OutputDir: dir}
+	var written []string
+	for _, file := range files {
+		start := time.Now()
+		perm := os.FileMode(0644)
+		if p, ok := previous[file.Name]; ok {
+			perm = p.perm
+		}
+		if err := writeFileAtomic(filepath.Join(dir, file.Name), file.Bytes, perm); err != nil {
+			genErr.Failures = append(genErr.Failures, FileWriteError{Name: file.Name, Err: err})
+			continue
+		}
+		written = append(written, file.Name)
+		wrote(file, time.Since(start))
+	}
+	if len(genErr.Failures) == 0 {
+		return nil
+	}
+
+	genErr.RolledBack = true
+	for _, name := range written {
+		path := filepath.Join(dir, name)
+		var err error
+		if p, ok := previous[name]; ok {
+			err = writeFileAtomic(path, p.data, p.perm)
+		} else {
+			err = os.Remove(path)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, glyphs("⚠️  Could not roll back %s: %v\n"), name, err)
+			genErr.RolledBack = false
+		}
+	}
+	return genErr
+}
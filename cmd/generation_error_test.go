@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteFilesWithRollbackReportsEveryFailure(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "README.md"), "original readme")
+	// A directory where a file should go fails the write, even as root
+	if err := os.Mkdir(filepath.Join(dir, "main.py"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []GeneratedFile{
+		{Name: "README.md", Bytes: []byte("new readme")},
+		{Name: "main.py", Bytes: []byte("print()")},
+		{Name: "requirements.txt", Bytes: []byte("requests")},
+		{Name: filepath.Join("missing", "Dockerfile"), Bytes: []byte("FROM scratch")},
+	}
+	var wrote []string
+	err := writeFilesWithRollback(dir, files, func(file GeneratedFile, _ time.Duration) {
+		wrote = append(wrote, file.Name)
+	})
+
+	var genErr *GenerationError
+	if !errors.As(err, &genErr) {
+		t.Fatalf("writeFilesWithRollback() = %v, want a *GenerationError", err)
+	}
+	if len(genErr.Failures) != 2 || genErr.Failures[0].Name != "main.py" || genErr.Failures[1].Name != files[3].Name {
+		t.Errorf("failures = %+v, want main.py and %s", genErr.Failures, files[3].Name)
+	}
+	if len(wrote) != 2 {
+		t.Errorf("wrote %v, want README.md and requirements.txt attempted after the first failure", wrote)
+	}
+	if !genErr.RolledBack {
+		t.Error("RolledBack = false, want the written files rolled back")
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("errors.Is does not reach the individual failures: %v", err)
+	}
+
+	message := err.Error()
+	for _, want := range []string{
+		"failed to write 2 file(s) in " + dir + ":",
+		"\n  main.py: ",
+		"\n  " + files[3].Name + ": no such file or directory",
+		"rolled back",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("message does not contain %q:\n%s", want, message)
+		}
+	}
+	// The cause is shown without repeating the path or the temporary file
+	if strings.Count(message, dir) != 1 || strings.Contains(message, ".tmp-") {
+		t.Errorf("message repeats the output directory:\n%s", message)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil || string(data) != "original readme" {
+		t.Errorf("README.md = %q, %v, want the original restored", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "requirements.txt")); !os.IsNotExist(err) {
+		t.Errorf("new file requirements.txt was not removed: %v", err)
+	}
+}
+
+func TestWriteFilesWithRollbackKeepsPermissions(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "run.sh")
+	secret := filepath.Join(dir, "secret.env")
+	writeTestFile(t, script, "#!/bin/sh\necho old\n")
+	writeTestFile(t, secret, "TOKEN=old\n")
+	if err := os.Chmod(script, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(secret, 0600); err != nil {
+		t.Fatal(err)
+	}
+	wantModes := map[string]os.FileMode{script: 0755, secret: 0600}
+	checkModes := func(when string) {
+		t.Helper()
+		for path, want := range wantModes {
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != want {
+				t.Errorf("%s: %s has mode %v, want %v", when, filepath.Base(path), info.Mode().Perm(), want)
+			}
+		}
+	}
+
+	files := []GeneratedFile{
+		{Name: "run.sh", Bytes: []byte("#!/bin/sh\necho new\n")},
+		{Name: "secret.env", Bytes: []byte("TOKEN=new\n")},
+	}
+	if err := writeFilesWithRollback(dir, files, func(GeneratedFile, time.Duration) {}); err != nil {
+		t.Fatal(err)
+	}
+	checkModes("after replacing")
+
+	failing := append(files, GeneratedFile{Name: filepath.Join("missing", "x"), Bytes: []byte("x")})
+	failing[0].Bytes = []byte("#!/bin/sh\necho newer\n")
+	if err := writeFilesWithRollback(dir, failing, func(GeneratedFile, time.Duration) {}); err == nil {
+		t.Fatal("writeFilesWithRollback() = nil, want an error")
+	}
+	checkModes("after rolling back")
+	data, err := os.ReadFile(script)
+	if err != nil || string(data) != "#!/bin/sh\necho new\n" {
+		t.Errorf("run.sh after rolling back = %q, %v", data, err)
+	}
+}
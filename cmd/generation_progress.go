@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	// quietFlag hides the per-file progress of scanner generation (--quiet)
+	quietFlag bool
+
+	// generationFormatFlag selects how generation progress is reported
+	// (--generation-format text|json)
+	generationFormatFlag string
+)
+
+// GeneratedFileTiming is how long writing one generated file took
+type GeneratedFileTiming struct {
+	Name       string  `json:"name"`
+	Bytes      int     `json:"bytes"`
+	DurationMs float64 `json:"durationMs"`
+}
+
+// generationReport is printed at the end of generation with
+// --generation-format json
+type generationReport struct {
+	OutputDir  string                `json:"outputDir"`
+	TotalFiles int                   `json:"totalFiles"`
+	ElapsedMs  float64               `json:"elapsedMs"`
+	Files      []GeneratedFileTiming `json:"files"`
+}
+
+// generationProgress reports generated files as they are written and the
+// totals at the end
+type generationProgress struct {
+	total int
+	start time.Time
+	files []GeneratedFileTiming
+}
+
+func newGenerationProgress(total int) *generationProgress {
+	return &generationProgress{total: total, start: time.Now()}
+}
+
+// wrote records a written file, printing it unless --quiet or JSON output
+// is selected
+func (p *generationProgress) wrote(file GeneratedFile, elapsed time.Duration) {
+	p.files = append(p.files, GeneratedFileTiming{
+		Name:       file.Name,
+		Bytes:      len(file.Bytes),
+		DurationMs: durationMs(elapsed),
+	})
+	if quietFlag || generationFormatFlag == outputJSON {
+		return
+	}
+	fmt.Printf(glyphs("  ✅ [%d/%d] Created %s\n"), len(p.files), p.total, file.Name)
+}
+
+// finish prints the number of files written and the elapsed time, or the
+// JSON report with the time taken by each file
+func (p *generationProgress) finish(outputDir string) error {
+	elapsed := time.Since(p.start)
+	if generationFormatFlag == outputJSON {
+		return printJSON(generationReport{
+			OutputDir:  outputDir,
+			TotalFiles: len(p.files),
+			ElapsedMs:  durationMs(elapsed),
+			Files:      p.files,
+		})
+	}
+	fmt.Printf(glyphs("📁 Wrote %d file(s) in %s\n"), len(p.files), elapsed.Round(time.Millisecond))
+	return nil
+}
+
+// durationMs converts a duration to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
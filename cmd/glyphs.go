@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// asciiFlag forces ASCII-only output (--ascii); --ascii=false forces Unicode
+var asciiFlag bool
+
+// asciiMode is set by applyGlyphSettings when output must be ASCII-only
+var asciiMode bool
+
+// asciiGlyphs maps status markers and symbols to ASCII. Longer keys come
+// first so a marker's trailing space is consumed with it.
+var asciiGlyphs = strings.NewReplacer(
+	"✅", "[OK]",
+	"❌", "[FAIL]",
+	"✗", "[FAIL]",
+	"⚠️ ", "[WARN]",
+	"⚠️", "[WARN]",
+	"ℹ️ ", "[INFO]",
+	"ℹ️", "[INFO]",
+	"✏️ ", "*",
+	"→", "->",
+	"←", "<-",
+	"↑/↓", "up/down",
+	"•", "-",
+	"●", "*",
+)
+
+// decorativeGlyphPattern matches the remaining emoji, which are decoration
+// and are dropped together with the space after them
+var decorativeGlyphPattern = regexp.MustCompile(`[\x{1F000}-\x{1FFFF}\x{2600}-\x{27BF}]\x{FE0F}? *`)
+
+// asciiBorder replaces box-drawing borders in ASCII mode
+var asciiBorder = lipgloss.Border{
+	Top: "-", Bottom: "-", Left: "|", Right: "|",
+	TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+}
+
+// glyphs returns s for display, with emoji and symbols swapped for ASCII in
+// ASCII mode
+func glyphs(s string) string {
+	if !asciiMode {
+		return s
+	}
+	return decorativeGlyphPattern.ReplaceAllString(asciiGlyphs.Replace(s), "")
+}
+
+// menuBorder returns the border drawn around menu titles
+func menuBorder() lipgloss.Border {
+	if asciiMode {
+		return asciiBorder
+	}
+	return lipgloss.RoundedBorder()
+}
+
+// applyGlyphSettings turns on ASCII mode when --ascii is given or, without
+// the flag, when the terminal is unlikely to render Unicode
+func applyGlyphSettings(cmd *cobra.Command) {
+	if cmd.Flags().Changed("ascii") {
+		asciiMode = asciiFlag
+	} else {
+		asciiMode = !terminalSupportsUnicode()
+	}
+	titleStyle = titleStyle.Border(menuBorder())
+}
+
+// terminalSupportsUnicode guesses whether the terminal renders Unicode. On
+// Windows only modern terminals do; elsewhere the locale must be UTF-8 when
+// one is set.
+func terminalSupportsUnicode() bool {
+	if runtime.GOOS == "windows" {
+		return os.Getenv("WT_SESSION") != "" || os.Getenv("TERM_PROGRAM") != "" || os.Getenv("ConEmuANSI") == "ON"
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := strings.ToUpper(os.Getenv(name)); value != "" {
+			return strings.Contains(value, "UTF-8") || strings.Contains(value, "UTF8")
+		}
+	}
+	return true
+}
+
+// showASCIILogo prints the intro logo without block characters
+func showASCIILogo() {
+	fmt.Println()
+	fmt.Println("  _   _ _____ _______        ______  _____  __  __")
+	fmt.Println(" | \\ | | ____|_   _\\ \\      / /  _ \\|_ _\\ \\/ /")
+	fmt.Println(" |  \\| |  _|   | |  \\ \\ /\\ / /| |_) || | \\  / ")
+	fmt.Println(" | |\\  | |___  | |   \\ V  V / |  _ < | | /  \\ ")
+	fmt.Println(" |_| \\_|_____| |_|    \\_/\\_/  |_| \\_\\___/_/\\_\\")
+	fmt.Println()
+	fmt.Println("                      CLI")
+	fmt.Println()
+}
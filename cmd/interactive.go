@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -110,7 +113,7 @@ func getAccessAnalyzerMenuItems() []MenuItem {
 			},
 		},
 		{
-			Title:       "← Back to Main Menu",
+			Title:       glyphs("← Back to Main Menu"),
 			Description: "Return to main menu",
 			Action: func() error {
 				return runMainMenu()
@@ -129,7 +132,7 @@ func getScannerMenuItems() []MenuItem {
 			},
 		},
 		{
-			Title:       "← Back to Access Analyzer",
+			Title:       glyphs("← Back to Access Analyzer"),
 			Description: "Return to Access Analyzer menu",
 			Action: func() error {
 				return runAccessAnalyzerMenu()
@@ -144,8 +147,10 @@ var InteractiveCommand = &cobra.Command{
 	Short: "Start interactive CLI mode",
 	Long:  "Start the interactive CLI interface for a guided experience",
 	Run: func(cmd *cobra.Command, args []string) {
-		// Show intro logo
-		showIntroLogo()
+		// Show intro logo unless turned off with --no-intro or noIntro
+		if introEnabled() {
+			showIntroLogo()
+		}
 		
 		// Try interactive mode first, fall back to simple menu if not available
 		if err := runMainMenu(); err != nil {
@@ -220,18 +225,18 @@ func (m InteractiveModel) View() string {
 	headerStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#5C33FF")).
 		Bold(true).
-		Border(lipgloss.RoundedBorder()).
+		Border(menuBorder()).
 		BorderForeground(lipgloss.Color("#5C33FF")).
 		Padding(0, 2).
 		MarginBottom(1)
 	
-	s.WriteString(headerStyle.Render("NETWRIX CLI"))
+	s.WriteString(headerStyle.Render(productTitle()))
 	s.WriteString("\n\n")
 
 	// Menu items
 	for i, choice := range m.choices {
 		if m.cursor == i {
-			s.WriteString(selectedStyle.Render("→ " + choice))
+			s.WriteString(selectedStyle.Render(glyphs("→ ") + choice))
 		} else {
 			s.WriteString(normalStyle.Render("  " + choice))
 		}
@@ -239,7 +244,7 @@ func (m InteractiveModel) View() string {
 	}
 
 	// Help text
-	s.WriteString(helpStyle.Render("\nNavigation: ↑/↓ or j/k to move, enter to select, q to quit"))
+	s.WriteString(helpStyle.Render(glyphs("\nNavigation: ↑/↓ or j/k to move, enter to select, q to quit")))
 
 	return s.String()
 }
@@ -258,15 +263,15 @@ func runScannerMenu() error {
 }
 
 func runConfigMenu() error {
-	fmt.Println(menuStyle.Render("📋 Configuration Menu"))
+	fmt.Println(menuStyle.Render(glyphs("📋 Configuration Menu")))
 	fmt.Println("Configuration options coming soon...")
 	fmt.Println(helpStyle.Render("Press any key to continue..."))
-	fmt.Scanln()
+	waitForEnter()
 	return runMainMenu()
 }
 
 func runAAConfigMenu() error {
-	fmt.Println(menuStyle.Render("⚙️  Access Analyzer Configuration"))
+	fmt.Println(menuStyle.Render(glyphs("⚙️  Access Analyzer Configuration")))
 	
 	// Show current endpoint
 	endpoint, err := getAAEndpoint()
@@ -276,26 +281,39 @@ func runAAConfigMenu() error {
 		fmt.Printf("Current endpoint: %s\n", successStyle.Render(endpoint))
 	}
 	
-	fmt.Print("\nEnter new endpoint (or press Enter to keep current): ")
-	var newEndpoint string
-	fmt.Scanln(&newEndpoint)
+	fmt.Println()
+	newEndpoint, err := promptLine(lineInput{
+		Message:     "Enter new endpoint (or press Enter to keep current):",
+		Placeholder: "http://localhost:3020",
+		Validate: func(value string) error {
+			if value == "" {
+				return nil
+			}
+			return validateEndpointURL(value)
+		},
+	})
+	if err != nil && err != errInputCancelled {
+		fmt.Printf(glyphs("❌ Error: %v\n"), err)
+	}
 	
 	if newEndpoint != "" {
-		if err := setAAEndpoint(newEndpoint); err != nil {
+		if newEndpoint, err = cleanEndpoint(newEndpoint); err != nil {
+			fmt.Printf("Error setting endpoint: %v\n", err)
+		} else if err := setAAEndpoint(newEndpoint); err != nil {
 			fmt.Printf("Error setting endpoint: %v\n", err)
 		} else {
-			fmt.Println(successStyle.Render("✅ Endpoint updated successfully"))
+			fmt.Println(successStyle.Render(glyphs("✅ Endpoint updated successfully")))
 		}
 	}
 	
 	fmt.Println(helpStyle.Render("Press any key to continue..."))
-	fmt.Scanln()
+	waitForEnter()
 	return runAccessAnalyzerMenu()
 }
 
 func runHelpMenu() error {
-	fmt.Println(menuStyle.Render("📚 Help"))
-	fmt.Println(`
+	fmt.Println(menuStyle.Render(glyphs("📚 Help")))
+	fmt.Print(glyphs(`
 Welcome to NWX CLI - Interactive Mode!
 
 This CLI provides tools for managing Access Analyzer scanners and configuration.
@@ -311,80 +329,81 @@ Navigation:
 • Press 'q' to quit at any time
 
 For more information, visit: https://github.com/netwrix/nwx-cli
-`)
+`))
 	fmt.Println(helpStyle.Render("Press any key to continue..."))
-	fmt.Scanln()
+	waitForEnter()
 	return runMainMenu()
 }
 
 func runStatusCommand() error {
-	fmt.Println(menuStyle.Render("🔍 Access Analyzer Status"))
+	fmt.Println(menuStyle.Render(glyphs("🔍 Access Analyzer Status")))
 	
 	client, err := getAPIClient()
 	if err != nil {
-		fmt.Printf("❌ Error: %v\n", err)
+		fmt.Printf(glyphs("❌ Error: %v\n"), err)
 		fmt.Println(helpStyle.Render("Press any key to continue..."))
-		fmt.Scanln()
+		waitForEnter()
 		return runAccessAnalyzerMenu()
 	}
 	
-	fmt.Printf("🔗 Endpoint: %s\n", client.BaseURL)
+	fmt.Printf(glyphs("🔗 Endpoint: %s\n"), redactSecrets(client.BaseURL))
 	
-	if err := client.TestConnection(); err != nil {
-		fmt.Printf("❌ Connection failed: %v\n", err)
+	result := checkStatus(context.Background(), client)
+	if result.Err != nil {
+		fmt.Printf(glyphs("❌ Connection failed: %v\n"), result.Err)
 	} else {
-		fmt.Println(successStyle.Render("✅ Connection successful"))
+		fmt.Println(successStyle.Render(fmt.Sprintf(glyphs("✅ Connection successful (%s)"), formatLatency(result.Latency))))
 	}
 	
 	fmt.Println(helpStyle.Render("Press any key to continue..."))
-	fmt.Scanln()
+	waitForEnter()
 	return runAccessAnalyzerMenu()
 }
 
 func runScannerCreation() error {
-	fmt.Println(menuStyle.Render("🚀 Scanner Creation"))
+	fmt.Println(menuStyle.Render(glyphs("🚀 Scanner Creation")))
 	fmt.Println("Starting interactive scanner creation workflow...")
 	
 	// Check if endpoint is configured
 	client, err := getAPIClient()
 	if err != nil {
-		fmt.Printf("❌ Error: %v\n", err)
+		fmt.Printf(glyphs("❌ Error: %v\n"), err)
 		fmt.Println(helpStyle.Render("Press any key to continue..."))
-		fmt.Scanln()
+		waitForEnter()
 		return runScannerMenu()
 	}
 	
-	fmt.Printf("🔍 Connecting to Access Analyzer at: %s\n", client.BaseURL)
+	fmt.Printf(glyphs("🔍 Connecting to Access Analyzer at: %s\n"), redactSecrets(client.BaseURL))
 	
-	// Test connection first
-	if err := client.TestConnection(); err != nil {
-		fmt.Printf("❌ Connection failed: %v\n", err)
-		fmt.Println(helpStyle.Render("Press any key to continue..."))
-		fmt.Scanln()
-		return runScannerMenu()
-	}
-	
-	// Get existing scanners
-	response, err := client.GetSourceTypes()
-	if err != nil {
-		fmt.Printf("⚠️  Could not fetch existing scanners: %v\n", err)
+	// Test connection first, falling back to cached scanners offline
+	var existing []SourceType
+	if connErr := client.TestConnection(context.Background()); connErr != nil {
+		fmt.Printf(glyphs("❌ Connection failed: %v\n"), connErr)
+		cached, ok := cachedExistingScanners(client.BaseURL)
+		if !ok {
+			fmt.Println(helpStyle.Render("Press any key to continue..."))
+			waitForEnter()
+			return runScannerMenu()
+		}
+		existing = cached
 	} else {
-		fmt.Printf("✅ Found %d existing scanners\n", len(response.Data))
+		// Get existing scanners
+		existing, err = fetchExistingScanners(context.Background(), client)
 	}
-	
-	fmt.Println()
-	
-	// Start interactive scanner creation workflow
-	if err := runInteractiveScannerCreation(response); err != nil {
-		if err.Error() == "EOF" {
-			fmt.Println("❌ Scanner creation cancelled by user")
+	if err == nil {
+		// Start interactive scanner creation workflow
+		err = runInteractiveScannerCreation(existing)
+	}
+	if err != nil {
+		if errors.Is(err, errCancelledByUser) {
+			fmt.Println(glyphs("❌ Scanner creation cancelled by user"))
 		} else {
-			fmt.Printf("❌ Scanner creation failed: %v\n", err)
+			fmt.Printf(glyphs("❌ Scanner creation failed: %v\n"), err)
 		}
 	}
 	
 	fmt.Println(helpStyle.Render("Press any key to continue..."))
-	fmt.Scanln()
+	waitForEnter()
 	return runScannerMenu()
 }
 
@@ -408,31 +427,41 @@ func runMenu(items []MenuItem, title string) error {
 	return nil
 }
 
-// showIntroLogo displays the NETWRIX CLI intro logo
+// showIntroLogo displays the NETWRIX CLI intro logo, or the neutral header
+// of a white-label build
 func showIntroLogo() {
 	// Clear screen
 	fmt.Print("\033[2J\033[H")
 	
-	// NETWRIX logo in Vigilant Blue
-	logoStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#5C33FF")).
-		Bold(true)
+	if brandingMode() == brandingNeutral {
+		showNeutralHeader()
+		return
+	}
 	
-	fmt.Println(logoStyle.Render(""))
-	fmt.Println(logoStyle.Render("███╗   ██╗███████╗████████╗██╗    ██╗██████╗ ██╗██╗  ██╗"))
-	fmt.Println(logoStyle.Render("████╗  ██║██╔════╝╚══██╔══╝██║    ██║██╔══██╗██║╚██╗██╔╝"))
-	fmt.Println(logoStyle.Render("██╔██╗ ██║█████╗     ██║   ██║ █╗ ██║██████╔╝██║ ╚███╔╝ "))
-	fmt.Println(logoStyle.Render("██║╚██╗██║██╔══╝     ██║   ██║███╗██║██╔══██╗██║ ██╔██╗ "))
-	fmt.Println(logoStyle.Render("██║ ╚████║███████╗   ██║   ╚███╔███╔╝██║  ██║██║██╔╝ ██╗"))
-	fmt.Println(logoStyle.Render("╚═╝  ╚═══╝╚══════╝   ╚═╝    ╚══╝╚══╝ ╚═╝  ╚═╝╚═╝╚═╝  ╚═╝"))
-	fmt.Println(logoStyle.Render(""))
-	fmt.Println(logoStyle.Render("         ██████╗██╗     ██╗                            "))
-	fmt.Println(logoStyle.Render("        ██╔════╝██║     ██║                            "))
-	fmt.Println(logoStyle.Render("        ██║     ██║     ██║                            "))
-	fmt.Println(logoStyle.Render("        ██║     ██║     ██║                            "))
-	fmt.Println(logoStyle.Render("        ╚██████╗███████╗██║                            "))
-	fmt.Println(logoStyle.Render("         ╚═════╝╚══════╝╚═╝                            "))
-	fmt.Println()
+	if asciiMode {
+		showASCIILogo()
+	} else {
+		// NETWRIX logo in Vigilant Blue
+		logoStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#5C33FF")).
+			Bold(true)
+	
+		fmt.Println(logoStyle.Render(""))
+		fmt.Println(logoStyle.Render("███╗   ██╗███████╗████████╗██╗    ██╗██████╗ ██╗██╗  ██╗"))
+		fmt.Println(logoStyle.Render("████╗  ██║██╔════╝╚══██╔══╝██║    ██║██╔══██╗██║╚██╗██╔╝"))
+		fmt.Println(logoStyle.Render("██╔██╗ ██║█████╗     ██║   ██║ █╗ ██║██████╔╝██║ ╚███╔╝ "))
+		fmt.Println(logoStyle.Render("██║╚██╗██║██╔══╝     ██║   ██║███╗██║██╔══██╗██║ ██╔██╗ "))
+		fmt.Println(logoStyle.Render("██║ ╚████║███████╗   ██║   ╚███╔███╔╝██║  ██║██║██╔╝ ██╗"))
+		fmt.Println(logoStyle.Render("╚═╝  ╚═══╝╚══════╝   ╚═╝    ╚══╝╚══╝ ╚═╝  ╚═╝╚═╝╚═╝  ╚═╝"))
+		fmt.Println(logoStyle.Render(""))
+		fmt.Println(logoStyle.Render("         ██████╗██╗     ██╗                            "))
+		fmt.Println(logoStyle.Render("        ██╔════╝██║     ██║                            "))
+		fmt.Println(logoStyle.Render("        ██║     ██║     ██║                            "))
+		fmt.Println(logoStyle.Render("        ██║     ██║     ██║                            "))
+		fmt.Println(logoStyle.Render("        ╚██████╗███████╗██║                            "))
+		fmt.Println(logoStyle.Render("         ╚═════╝╚══════╝╚═╝                            "))
+		fmt.Println()
+	}
 	
 	// Subtitle
 	subtitleStyle := lipgloss.NewStyle().
@@ -447,18 +476,18 @@ func showIntroLogo() {
 		Foreground(lipgloss.Color("#10B981")).
 		Bold(true)
 	
-	fmt.Println(taglineStyle.Render("         🚀 Access Analyzer Scanner Management"))
+	fmt.Println(taglineStyle.Render(glyphs("         🚀 Access Analyzer Scanner Management")))
 	fmt.Println()
 	
 	// Brief pause for dramatic effect
 	fmt.Print("Press any key to continue...")
-	fmt.Scanln()
+	waitForEnter()
 	fmt.Print("\033[2J\033[H") // Clear screen again
 }
 
 // runSimpleMenu provides a fallback text-based menu when TTY is not available
 func runSimpleMenu() {
-	fmt.Println("🚀 NWX CLI - Interactive Mode")
+	fmt.Println(glyphs("🚀 NWX CLI - Interactive Mode"))
 	fmt.Println("=============================")
 	fmt.Println()
 	
@@ -472,8 +501,13 @@ func runSimpleMenu() {
 		fmt.Println()
 		fmt.Print("Select an option (1-4): ")
 		
-		var choice int
-		if _, err := fmt.Scanln(&choice); err != nil {
+		line, err := readLine()
+		if err != nil {
+			fmt.Println()
+			return
+		}
+		choice, err := strconv.Atoi(line)
+		if err != nil {
 			fmt.Println("Invalid input. Please enter a number.")
 			continue
 		}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// noIntroKey is the configuration key that turns off the intro banner
+const noIntroKey = "noIntro"
+
+// noIntroFlag skips the intro banner for this run (--no-intro)
+var noIntroFlag bool
+
+// parseNoIntro parses a noIntro value such as "true" or "false"
+func parseNoIntro(value string) (bool, error) {
+	noIntro, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s '%s' (expected true or false)", noIntroKey, value)
+	}
+	return noIntro, nil
+}
+
+// setNoIntro validates and stores the noIntro key
+func setNoIntro(value string) error {
+	noIntro, err := parseNoIntro(value)
+	if err != nil {
+		return err
+	}
+	return writeConfigValue(noIntroKey, strconv.FormatBool(noIntro))
+}
+
+// introEnabled reports whether the intro banner should be shown, which it is
+// unless --no-intro is given, noIntro is set in the configuration or the
+// build has no branding. An unreadable or invalid value keeps the banner.
+func introEnabled() bool {
+	if noIntroFlag || brandingMode() == brandingNone {
+		return false
+	}
+	value, err := readConfigValue(noIntroKey)
+	if err != nil || value == "" {
+		return true
+	}
+	noIntro, err := parseNoIntro(value)
+	return err != nil || !noIntro
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// noOnboardingFlag skips the first-run onboarding (--no-onboarding)
+var noOnboardingFlag bool
+
+// needsOnboarding reports whether no Access Analyzer endpoint is configured yet
+func needsOnboarding() bool {
+	resolved, err := ResolveEndpoint(globalEndpointFlags())
+	return err == nil && resolved.Endpoint == ""
+}
+
+// runOnboarding guides a first-time user through configuring the endpoint.
+// Without a terminal it only prints how to configure nwx.
+func runOnboarding(ctx context.Context) error {
+	if !isInteractiveTerminal() {
+		fmt.Fprintln(os.Stderr, "nwx is not configured yet. Set the Access Analyzer endpoint with:")
+		fmt.Fprintln(os.Stderr, "  nwx aa config --endpoint=\"<url>\"")
+		fmt.Fprintln(os.Stderr, "If the API requires a token, set it with:")
+		fmt.Fprintln(os.Stderr, "  nwx config set token-file <path>")
+		fmt.Fprintln(os.Stderr, "Use --no-onboarding to hide this message.")
+		return nil
+	}
+
+	fmt.Println(glyphs("👋 Welcome to nwx! No Access Analyzer endpoint is configured yet."))
+	fmt.Println()
+
+	setup, err := askConfirm(&survey.Confirm{
+		Message: "Set it up now?",
+		Default: true,
+	})
+	if err != nil {
+		return err
+	}
+	if !setup {
+		fmt.Println("You can configure it later with: nwx aa config --endpoint=\"<url>\"")
+		fmt.Println()
+		return nil
+	}
+
+	var endpoint string
+	endpointPrompt := &survey.Input{
+		Message: "Access Analyzer endpoint:",
+		Help:    "Base URL of the Access Analyzer API, e.g. http://localhost:3020",
+	}
+	if err := askOne(endpointPrompt, &endpoint, survey.WithValidator(survey.Required), survey.WithValidator(func(val interface{}) error {
+		return validateEndpointURL(val.(string))
+	})); err != nil {
+		return err
+	}
+	endpoint, err = cleanEndpoint(endpoint)
+	if err != nil {
+		return err
+	}
+
+	tokenKeyName, tokenValue, err := collectOnboardingToken()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(glyphs("🔍 Testing connection to %s\n"), endpoint)
+	client, err := newConfiguredAPIClient(endpoint)
+	if err != nil {
+		return err
+	}
+	if tokenKeyName != "" {
+		client.TokenSource = onboardingTokenSource(tokenKeyName, tokenValue)
+	}
+	if err := client.TestConnection(ctx); err != nil {
+		fmt.Printf(glyphs("⚠️  Connection failed: %v\n"), err)
+		save, err := askConfirm(&survey.Confirm{
+			Message: "Save this endpoint anyway?",
+			Default: false,
+		})
+		if err != nil {
+			return err
+		}
+		if !save {
+			fmt.Println("Endpoint not saved. Run nwx again to retry.")
+			fmt.Println()
+			return nil
+		}
+	} else {
+		fmt.Println(glyphs("✅ Connection successful"))
+	}
+
+	if err := setAAEndpoint(endpoint); err != nil {
+		return err
+	}
+	fmt.Printf(glyphs("✅ Access Analyzer endpoint set to: %s\n"), endpoint)
+	if tokenKeyName != "" {
+		if err := saveOnboardingToken(tokenKeyName, tokenValue); err != nil {
+			return err
+		}
+		fmt.Printf(glyphs("✅ API %s saved\n"), tokenKeyName)
+	}
+	fmt.Println()
+	return nil
+}
+
+// Answers to the optional API token question of the onboarding
+const (
+	onboardingNoToken   = "No token"
+	onboardingTokenFile = "Read the token from a file"
+	onboardingToken     = "Enter the token"
+)
+
+// collectOnboardingToken optionally asks for the API token, either as a
+// file to read it from or as the token itself. It returns the config key
+// to store (token-file or token) and its value, or an empty key when no
+// token is wanted or one is already configured.
+func collectOnboardingToken() (string, string, error) {
+	if source, err := loadTokenSource(); err != nil || source != nil {
+		return "", "", err
+	}
+
+	var choice string
+	if err := askOne(&survey.Select{
+		Message: "Does the API require a token?",
+		Options: []string{onboardingNoToken, onboardingTokenFile, onboardingToken},
+		Default: onboardingNoToken,
+		Help:    "A token file can be rotated without reconfiguring nwx; an entered token is stored in the configuration directory",
+	}, &choice); err != nil {
+		return "", "", err
+	}
+
+	switch choice {
+	case onboardingTokenFile:
+		var path string
+		if err := askOne(&survey.Input{
+			Message: "Token file:",
+		}, &path, survey.WithValidator(survey.Required), survey.WithValidator(func(val interface{}) error {
+			abs, err := filepath.Abs(val.(string))
+			if err != nil {
+				return err
+			}
+			_, err = readTokenFile(abs, "the token file")
+			return err
+		})); err != nil {
+			return "", "", err
+		}
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return "", "", err
+		}
+		return tokenFileKey, path, nil
+	case onboardingToken:
+		var token string
+		if err := askOne(&survey.Password{
+			Message: "API token:",
+		}, &token, survey.WithValidator(survey.Required)); err != nil {
+			return "", "", err
+		}
+		return tokenKey, strings.TrimSpace(token), nil
+	}
+	return "", "", nil
+}
+
+// onboardingTokenSource returns the token source for an answer of
+// collectOnboardingToken, so the connection test uses the new token
+func onboardingTokenSource(key, value string) TokenSource {
+	if key == tokenFileKey {
+		return fileTokenSource(value, tokenFileKey)
+	}
+	return staticTokenSource(value)
+}
+
+// saveOnboardingToken stores an answer of collectOnboardingToken
+func saveOnboardingToken(key, value string) error {
+	if key == tokenFileKey {
+		return setTokenFile(value)
+	}
+	return writeConfigValue(key, value)
+}
+
+// validateEndpointURL checks that an endpoint is an http(s) URL, possibly
+// without a scheme (see normalizeEndpoint)
+func validateEndpointURL(endpoint string) error {
+	_, _, err := normalizeEndpoint(endpoint)
+	return err
+}
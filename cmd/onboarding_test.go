@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveOnboardingToken(t *testing.T) {
+	dir := useTempConfigDir(t)
+
+	if err := saveOnboardingToken(tokenKey, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(dir, tokenKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("token mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	writeTestFile(t, tokenFile, "from-file\n")
+	if err := saveOnboardingToken(tokenFileKey, tokenFile); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := readConfigValue(tokenFileKey); err != nil || got != tokenFile {
+		t.Errorf("token-file = %q, %v, want %q", got, err, tokenFile)
+	}
+
+	if err := saveOnboardingToken(tokenFileKey, filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("saving a missing token file succeeded")
+	}
+}
+
+func TestOnboardingTokenSource(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	writeTestFile(t, tokenFile, " from-file \n")
+
+	tests := []struct {
+		key, value, want string
+	}{
+		{tokenKey, "typed", "typed"},
+		{tokenFileKey, tokenFile, "from-file"},
+	}
+	for _, tt := range tests {
+		got, err := onboardingTokenSource(tt.key, tt.value)()
+		if err != nil || got != tt.want {
+			t.Errorf("onboardingTokenSource(%s) = %q, %v, want %q", tt.key, got, err, tt.want)
+		}
+	}
+}
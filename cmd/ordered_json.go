@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// orderedObject is a JSON object that remembers the order of its keys, so
+// a file the CLI edits in place keeps the layout its author chose. Values
+// are *orderedObject, []interface{}, json.Number, string, bool or nil.
+type orderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// get returns the value of key and whether it is present
+func (o *orderedObject) get(key string) (interface{}, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+// set replaces the value of key, adding it after the existing keys when
+// it is new
+func (o *orderedObject) set(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// setFirst is set, but a new key is added before the existing ones
+func (o *orderedObject) setFirst(key string, value interface{}) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append([]string{key}, o.keys...)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON writes the object with its keys in their original order
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := marshalNoEscape(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := marshalNoEscape(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeOrderedJSON decodes a JSON document, keeping the key order of its
+// objects and numbers as written
+func decodeOrderedJSON(data []byte) (interface{}, error) {
+	// The token decoder reports some syntax errors a token early; check
+	// the document first so errors are located as json.Unmarshal does
+	var check interface{}
+	if err := json.Unmarshal(data, &check); err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	value, err := decodeOrderedValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the top-level value at offset %d", decoder.InputOffset())
+	}
+	return value, nil
+}
+
+// decodeOrderedValue reads the next value from decoder
+func decodeOrderedValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := &orderedObject{values: make(map[string]interface{})}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object.set(keyToken.(string), value)
+		}
+		_, err := decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := decoder.Token()
+		return array, err
+	}
+	return token, nil
+}
+
+// marshalOrderedJSON indents v with two spaces like the generated files,
+// without escaping <, > and & as encoding/json does by default
+func marshalOrderedJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// marshalNoEscape is json.Marshal without HTML escaping
+func marshalNoEscape(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Output formats shared by commands with an --output flag
+const (
+	outputText  = "text"
+	outputTable = "table"
+	outputJSON  = "json"
+	outputJSONL = "jsonl"
+	outputCSV   = "csv"
+
+	// outputTemplate runs a Go template given with --template per record
+	outputTemplate = "template"
+)
+
+// validateOutputFormat checks that format is one of the formats a command supports
+func validateOutputFormat(format string, allowed ...string) error {
+	for _, a := range allowed {
+		if format == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format '%s' (expected one of: %s)", format, strings.Join(allowed, ", "))
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// jsonLinesWriter streams values to stdout as one JSON object per line
+type jsonLinesWriter struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+// newJSONLinesWriter creates a JSON lines writer on stdout
+func newJSONLinesWriter() *jsonLinesWriter {
+	w := bufio.NewWriter(os.Stdout)
+	return &jsonLinesWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+// Write encodes v as a single line
+func (j *jsonLinesWriter) Write(v interface{}) error {
+	return j.enc.Encode(v)
+}
+
+// Flush writes any buffered lines to stdout
+func (j *jsonLinesWriter) Flush() error {
+	return j.w.Flush()
+}
+
+// printTable writes rows as aligned columns under the given headers
+func printTable(headers []string, rows [][]string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+// printCSV writes rows as CSV under the given headers
+func printCSV(headers []string, rows [][]string) error {
+	w := csv.NewWriter(os.Stdout)
+	if err := w.Write(headers); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// printRows writes rows as a table or as CSV. CSV output has only the
+// header and data rows so it can be piped straight into a file.
+func printRows(format string, headers []string, rows [][]string) error {
+	if format == outputCSV {
+		return printCSV(headers, rows)
+	}
+	printTable(headers, rows)
+	return nil
+}
+
+// recordFields describes the fields of a struct that can be selected as
+// output columns, by JSON name in struct order
+type recordFields struct {
+	names []string
+	index map[string]int
+}
+
+// newRecordFields collects the JSON-named fields of t, skipping exclude
+func newRecordFields(t reflect.Type, exclude ...string) recordFields {
+	f := recordFields{index: make(map[string]int)}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || contains(exclude, name) {
+			continue
+		}
+		f.names = append(f.names, name)
+		f.index[name] = i
+	}
+	return f
+}
+
+// validate checks that every selected field exists
+func (f recordFields) validate(fields []string) error {
+	for _, name := range fields {
+		if _, ok := f.index[name]; !ok {
+			return fmt.Errorf("unknown field '%s' (valid fields: %s)", name, strings.Join(f.names, ", "))
+		}
+	}
+	return nil
+}
+
+// row formats the selected fields of record, a struct or pointer to struct
+func (f recordFields) row(record interface{}, fields []string) []string {
+	v := reflect.Indirect(reflect.ValueOf(record))
+	row := make([]string, len(fields))
+	for i, name := range fields {
+		field := v.Field(f.index[name])
+		switch field.Kind() {
+		case reflect.Bool:
+			row[i] = strconv.FormatBool(field.Bool())
+		case reflect.Int, reflect.Int64:
+			row[i] = strconv.FormatInt(field.Int(), 10)
+		case reflect.Slice:
+			if values, ok := field.Interface().([]string); ok {
+				row[i] = strings.Join(values, ",")
+			}
+		case reflect.Map:
+			if values, ok := field.Interface().(map[string]string); ok {
+				pairs := make([]string, 0, len(values))
+				for _, key := range sortedStringKeys(values) {
+					pairs = append(pairs, key+"="+values[key])
+				}
+				row[i] = strings.Join(pairs, ",")
+			}
+		default:
+			row[i] = field.String()
+		}
+	}
+	return row
+}
+
+// parseFieldList splits a comma-separated --fields value
+func parseFieldList(value string) []string {
+	fields := strings.Split(value, ",")
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+	return fields
+}
+
+// noColorFlag disables colored output (--no-color)
+var noColorFlag bool
+
+// Styles for added, removed and changed values in diffs
+var (
+	diffAddStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	diffRemoveStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444"))
+	diffChangeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+)
+
+// applyColorSettings turns off styling when --no-color or NO_COLOR is set
+func applyColorSettings() {
+	if noColorFlag || os.Getenv("NO_COLOR") != "" {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
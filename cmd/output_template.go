@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// outputTemplateFuncs are the functions available to --template in
+// addition to the text/template builtins
+var outputTemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join": func(values []string, sep string) string {
+		return strings.Join(values, sep)
+	},
+}
+
+// parseOutputTemplate parses the --template of a command whose --output is
+// format, returning nil unless format is template. The template is run
+// once against sample, a zero record, so unknown fields are reported
+// before anything is fetched or printed.
+func parseOutputTemplate(format, text string, sample interface{}) (*template.Template, error) {
+	if format != outputTemplate {
+		if text != "" {
+			return nil, fmt.Errorf("--template requires --output template")
+		}
+		return nil, nil
+	}
+	if text == "" {
+		return nil, fmt.Errorf("--output template requires --template, e.g. --template '{{.Name}}'")
+	}
+
+	tmpl, err := template.New("output").Funcs(outputTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("invalid --template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// printTemplate executes tmpl once per record, ending each output with a
+// newline unless the template already does
+func printTemplate(tmpl *template.Template, records []interface{}) error {
+	var b strings.Builder
+	for _, record := range records {
+		b.Reset()
+		if err := tmpl.Execute(&b, record); err != nil {
+			return err
+		}
+		out := b.String()
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		if _, err := io.WriteString(os.Stdout, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// noPagerFlag turns off paging of long output (--no-pager)
+var noPagerFlag bool
+
+// defaultPager is used when $PAGER is not set
+const defaultPager = "less"
+
+// runPaged runs fn, which prints to stdout, and shows its output through a
+// pager like git does: only when stdout is a terminal and the output is
+// taller than it. Otherwise the output is written directly, so scripts and
+// pipes are unaffected.
+func runPaged(fn func() error) error {
+	if noPagerFlag || !isTerminal(os.Stdout) {
+		return fn()
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return fn()
+	}
+	collected := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		collected <- data
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	fnErr := fn()
+	os.Stdout = stdout
+	w.Close()
+	output := <-collected
+	r.Close()
+
+	showPaged(stdout, output)
+	return fnErr
+}
+
+// showPaged writes output to stdout, through the pager when it does not fit
+// the terminal
+func showPaged(stdout *os.File, output []byte) {
+	_, height, err := term.GetSize(int(stdout.Fd()))
+	if err != nil || bytes.Count(output, []byte("\n")) < height {
+		stdout.Write(output)
+		return
+	}
+
+	args := strings.Fields(os.Getenv("PAGER"))
+	if len(args) == 0 {
+		args = []string{defaultPager}
+	}
+	pager := exec.Command(args[0], args[1:]...)
+	pager.Stdin = bytes.NewReader(output)
+	pager.Stdout = stdout
+	pager.Stderr = os.Stderr
+	// Like git: keep colors and quit at once when everything fits
+	if os.Getenv("LESS") == "" {
+		pager.Env = append(os.Environ(), "LESS=FRX")
+	}
+	if err := pager.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			// The pager could not be started
+			stdout.Write(output)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// errCancelledByUser is returned by wizards the user left with Ctrl+C or Ctrl+D
+var errCancelledByUser = errors.New("cancelled by user")
+
+// exitCodeCancelled is the conventional exit status after an interrupt
+const exitCodeCancelled = 130
+
+// isUserCancellation reports whether err means the user interrupted a
+// prompt (Ctrl+C), closed input (Ctrl+D) or cancelled a text input. Only
+// the bare io.EOF survey returns from its reader counts; a wrapped EOF is
+// a real failure, such as a truncated file.
+func isUserCancellation(err error) bool {
+	return errors.Is(err, terminal.InterruptErr) ||
+		err == io.EOF ||
+		errors.Is(err, errInputCancelled) ||
+		errors.Is(err, errCancelledByUser)
+}
+
+// normalizeCancellation turns any user cancellation into errCancelledByUser
+// so callers only have one error to check for
+func normalizeCancellation(err error) error {
+	if err != nil && isUserCancellation(err) {
+		return errCancelledByUser
+	}
+	return err
+}
+
+// askOne shows a prompt and reads the answer. Every prompt goes through it
+// so tests can answer in place of a terminal.
+var askOne = survey.AskOne
+
+// assumeYesFlag answers every yes/no confirmation with yes (--yes/--assume-yes)
+var assumeYesFlag bool
+
+// askConfirm asks a yes/no question. With --yes the question is printed
+// and answered with yes without prompting. Only confirmations go through
+// here; inputs and validation are never skipped.
+func askConfirm(prompt *survey.Confirm) (bool, error) {
+	if assumeYesFlag {
+		fmt.Printf("? %s Yes (--yes)\n", prompt.Message)
+		return true, nil
+	}
+
+	var confirmed bool
+	if err := askOne(prompt, &confirmed); err != nil {
+		return false, err
+	}
+	return confirmed, nil
+}
+
+// isInteractiveTerminal reports whether stdin and stdout are both terminals
+func isInteractiveTerminal() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// isTerminal reports whether f is a character device such as a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/terminal"
+)
+
+// withAssumeYes sets --yes for the duration of a test
+func withAssumeYes(t *testing.T) {
+	t.Helper()
+	saved := assumeYesFlag
+	assumeYesFlag = true
+	t.Cleanup(func() { assumeYesFlag = saved })
+}
+
+func TestAskConfirmAssumeYes(t *testing.T) {
+	withAssumeYes(t)
+	// A default of false shows the answer does not come from the prompt
+	confirmed, err := askConfirm(&survey.Confirm{Message: "Overwrite 2 existing file(s)?", Default: false})
+	if err != nil || !confirmed {
+		t.Fatalf("askConfirm with --yes = %v, %v, want true", confirmed, err)
+	}
+}
+
+func TestScannerConfirmationsAssumeYes(t *testing.T) {
+	withAssumeYes(t)
+	scanner := &ScannerCreationData{
+		Name:               "my-scanner",
+		Version:            "1.0.0",
+		Language:           "python",
+		SupportedScanTypes: []string{"access"},
+	}
+
+	if err := showSummaryAndConfirm(scanner); err != nil {
+		t.Errorf("summary confirmation with --yes: %v", err)
+	}
+	action, err := previewSpecification(scanner)
+	if err != nil || action != previewProceed {
+		t.Errorf("specification preview with --yes = %q, %v, want %q", action, err, previewProceed)
+	}
+}
+
+func TestNormalizeCancellation(t *testing.T) {
+	other := errors.New("boom")
+	tests := []struct {
+		err  error
+		want error
+	}{
+		{nil, nil},
+		{terminal.InterruptErr, errCancelledByUser},
+		{fmt.Errorf("name: %w", terminal.InterruptErr), errCancelledByUser},
+		{io.EOF, errCancelledByUser},
+		// A wrapped EOF is a failure, such as a truncated file, not Ctrl+D
+		{fmt.Errorf("read spec: %w", io.EOF), nil},
+		{errInputCancelled, errCancelledByUser},
+		{errCancelledByUser, errCancelledByUser},
+		{other, other},
+	}
+	for _, tt := range tests {
+		if tt.want == nil {
+			tt.want = tt.err
+		}
+		if got := normalizeCancellation(tt.err); got != tt.want {
+			t.Errorf("normalizeCancellation(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// answerPrompts replaces askOne for the duration of a test. Prompts are
+// answered with their defaults and the scanner name with my-scanner; the
+// prompt whose message is failAt returns err. Reaching the summary means
+// failAt was never asked, so the test stops before anything is generated.
+func answerPrompts(t *testing.T, failAt string, err error) {
+	t.Helper()
+	saved := askOne
+	askOne = func(p survey.Prompt, response interface{}, _ ...survey.AskOpt) error {
+		var message string
+		switch p := p.(type) {
+		case *survey.Input:
+			message = p.Message
+		case *survey.Select:
+			message = p.Message
+		case *survey.MultiSelect:
+			message = p.Message
+		case *survey.Confirm:
+			message = p.Message
+		}
+		switch message {
+		case failAt:
+			return err
+		case "Create scanner with these settings?":
+			t.Fatalf("prompt %q was never asked", failAt)
+		}
+
+		switch p := p.(type) {
+		case *survey.Input:
+			*response.(*string) = p.Default
+			if strings.HasPrefix(message, "Scanner name") {
+				*response.(*string) = "my-scanner"
+			}
+		case *survey.Select:
+			answer, _ := p.Default.(string)
+			*response.(*string) = valueOr(answer, p.Options[0])
+		case *survey.MultiSelect:
+			*response.(*[]string), _ = p.Default.([]string)
+		case *survey.Confirm:
+			*response.(*bool) = p.Default
+		default:
+			t.Fatalf("unexpected prompt %T", p)
+		}
+		return nil
+	}
+	t.Cleanup(func() { askOne = saved })
+}
+
+func TestScannerCreationCancelledAtEachStep(t *testing.T) {
+	useTempConfigDir(t)
+	saved := reorderColumnsFlag
+	reorderColumnsFlag = true
+	t.Cleanup(func() { reorderColumnsFlag = saved })
+
+	steps := []struct {
+		step    string
+		message string
+	}{
+		{"basic information", "Scanner name (kebab-case, e.g., 'my-scanner'):"},
+		{"language", "Select programming language:"},
+		{"scan types", "Select supported scan types:"},
+		{"column order", "Column to move:"},
+		{"authentication methods", "Select authentication methods:"},
+		{"file generation", "Generate scanner files in current directory?"},
+	}
+	for _, step := range steps {
+		for _, cause := range []error{terminal.InterruptErr, io.EOF} {
+			t.Run(step.step+"/"+cause.Error(), func(t *testing.T) {
+				answerPrompts(t, step.message, cause)
+
+				var err error
+				out := captureStdout(t, func() {
+					err = runInteractiveScannerCreation(nil)
+					if err != nil {
+						err = scannerCreationError(err)
+					}
+				})
+				if err != errCancelledByUser {
+					t.Fatalf("scanner creation = %v, want %v", err, errCancelledByUser)
+				}
+				if code := exitCodeFor(err); code != exitCodeCancelled {
+					t.Errorf("exit code = %d, want %d", code, exitCodeCancelled)
+				}
+				if !strings.Contains(out, "Scanner creation cancelled by user") {
+					t.Errorf("output does not report the cancellation:\n%s", out)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// README formats accepted by --readme-format
+const (
+	readmeMarkdown = "md"
+	readmeRST      = "rst"
+	readmeAsciiDoc = "adoc"
+)
+
+// docMarkup renders the few constructs the generated README uses, so the
+// same content can be written as Markdown, reStructuredText or AsciiDoc
+type docMarkup struct {
+	heading   func(level int, text string) string
+	code      func(text string) string
+	strong    func(text string) string
+	bullet    string
+	number    func(n int) string
+	checkItem string
+}
+
+// list renders items as a bulleted or numbered list followed by a blank line
+func (m docMarkup) list(numbered bool, items ...string) string {
+	var b strings.Builder
+	for i, item := range items {
+		marker := m.bullet
+		if numbered {
+			marker = m.number(i + 1)
+		}
+		fmt.Fprintf(&b, "%s%s\n", marker, item)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// checklist renders items as unchecked task list entries
+func (m docMarkup) checklist(items ...string) string {
+	var b strings.Builder
+	for _, item := range items {
+		fmt.Fprintf(&b, "%s%s\n", m.checkItem, item)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+var readmeMarkups = map[string]docMarkup{
+	readmeMarkdown: {
+		heading: func(level int, text string) string {
+			return strings.Repeat("#", level) + " " + text + "\n\n"
+		},
+		code:      func(text string) string { return "`" + text + "`" },
+		strong:    func(text string) string { return "**" + text + "**" },
+		bullet:    "- ",
+		number:    func(n int) string { return fmt.Sprintf("%d. ", n) },
+		checkItem: "- [ ] ",
+	},
+	readmeRST: {
+		heading: func(level int, text string) string {
+			underline := "="
+			if level > 1 {
+				underline = "-"
+			}
+			return text + "\n" + strings.Repeat(underline, len([]rune(text))) + "\n\n"
+		},
+		code:   func(text string) string { return "``" + text + "``" },
+		strong: func(text string) string { return "**" + text + "**" },
+		bullet: "- ",
+		number: func(n int) string { return fmt.Sprintf("%d. ", n) },
+		// reStructuredText has no task lists; the box is kept as text
+		checkItem: "- [ ] ",
+	},
+	readmeAsciiDoc: {
+		heading: func(level int, text string) string {
+			return strings.Repeat("=", level) + " " + text + "\n\n"
+		},
+		code:      func(text string) string { return "`" + text + "`" },
+		strong:    func(text string) string { return "*" + text + "*" },
+		bullet:    "* ",
+		number:    func(n int) string { return ". " },
+		checkItem: "* [ ] ",
+	},
+}
+
+// validateReadmeFormat checks the value of --readme-format
+func validateReadmeFormat(format string) error {
+	if _, ok := readmeMarkups[format]; !ok {
+		return fmt.Errorf("invalid README format '%s' (expected md, rst or adoc)", format)
+	}
+	return nil
+}
+
+// readmeMarkupFor returns the markup for format, defaulting to Markdown
+func readmeMarkupFor(format string) docMarkup {
+	if m, ok := readmeMarkups[format]; ok {
+		return m
+	}
+	return readmeMarkups[readmeMarkdown]
+}
+
+// readmeFileName returns the README file name for the scanner's README format
+func readmeFileName(scanner *ScannerCreationData) string {
+	if _, ok := readmeMarkups[scanner.ReadmeFormat]; !ok {
+		return "README.md"
+	}
+	return "README." + scanner.ReadmeFormat
+}
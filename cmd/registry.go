@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Configuration keys for the container registry scanner images are checked
+// against, stored as files in the config directory
+const (
+	registryKey      = "registry"
+	registryTokenKey = "registry-token"
+)
+
+// registryTokenEnvVar supplies the registry token from the environment
+const registryTokenEnvVar = "NWX_REGISTRY_TOKEN"
+
+// defaultRegistry serves images without a registry host, as Docker does
+const defaultRegistry = "docker.io"
+
+// manifestMediaTypes are accepted when checking for an image manifest, so
+// both single-platform images and multi-platform indexes are found
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// validateRegistry checks a registry key value: a host with an optional
+// port, such as registry.example.com or localhost:5000
+func validateRegistry(value string) error {
+	u, err := url.Parse("//" + value)
+	if err != nil || value == "" || u.Host != value {
+		return fmt.Errorf("invalid %s '%s' (expected a host such as registry.example.com or localhost:5000)", registryKey, value)
+	}
+	return nil
+}
+
+// setRegistry validates and stores the registry key
+func setRegistry(value string) error {
+	if err := validateRegistry(value); err != nil {
+		return err
+	}
+	return writeConfigValue(registryKey, value)
+}
+
+// imageRef is a parsed image reference such as
+// registry.example.com/team/my-scanner:1.0.0
+type imageRef struct {
+	Registry   string
+	Repository string
+	Reference  string // tag or digest
+}
+
+// parseImageRef splits an image reference. Images without a registry host
+// are looked up in defaultRegistry; Docker Hub images without a namespace
+// live under library/.
+func parseImageRef(image, defaultRegistry string) (imageRef, error) {
+	ref := imageRef{Registry: defaultRegistry}
+	name := image
+	if first, rest, ok := strings.Cut(image, "/"); ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry, name = first, rest
+	}
+
+	if repo, digest, ok := strings.Cut(name, "@"); ok {
+		name, ref.Reference = repo, digest
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.Reference = name[:i], name[i+1:]
+	}
+	if ref.Reference == "" {
+		ref.Reference = "latest"
+	}
+	if name == "" {
+		return imageRef{}, fmt.Errorf("invalid image reference '%s'", image)
+	}
+
+	if ref.Registry == "docker.io" || ref.Registry == "index.docker.io" {
+		ref.Registry = "registry-1.docker.io"
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+	ref.Repository = name
+	return ref, nil
+}
+
+// registryClient checks images in container registries over the registry
+// v2 API, anonymously or with a bearer token
+type registryClient struct {
+	client          *http.Client
+	defaultRegistry string
+	token           string
+}
+
+// newRegistryClient creates a registry client from the registry and
+// registry-token keys; $NWX_REGISTRY_TOKEN takes precedence over the key
+func newRegistryClient() (*registryClient, error) {
+	registry, err := readConfigValue(registryKey)
+	if err != nil {
+		return nil, err
+	}
+	token := strings.TrimSpace(os.Getenv(registryTokenEnvVar))
+	if token == "" {
+		if token, err = readConfigValue(registryTokenKey); err != nil {
+			return nil, err
+		}
+	}
+	return &registryClient{
+		client:          &http.Client{Timeout: 30 * time.Second},
+		defaultRegistry: valueOr(registry, defaultRegistry),
+		token:           token,
+	}, nil
+}
+
+// imageExists reports whether the manifest of image exists, using a
+// manifest HEAD request. Registries that require a token for anonymous
+// pulls are handled through their bearer token challenge.
+func (r *registryClient) imageExists(ctx context.Context, image string) (bool, error) {
+	ref, err := parseImageRef(image, r.defaultRegistry)
+	if err != nil {
+		return false, err
+	}
+
+	scheme := "https"
+	if isLocalHost(ref.Registry) {
+		scheme = "http"
+	}
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, ref.Registry, ref.Repository, ref.Reference)
+
+	resp, err := r.headManifest(ctx, manifestURL, r.token)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && r.token == "" {
+		token, err := r.fetchAnonymousToken(ctx, resp.Header.Get("WWW-Authenticate"), ref)
+		if err != nil {
+			return false, err
+		}
+		if resp, err = r.headManifest(ctx, manifestURL, token); err != nil {
+			return false, err
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, fmt.Errorf("registry %s denied access to %s (HTTP %d); set %s or the %s key", ref.Registry, ref.Repository, resp.StatusCode, registryTokenEnvVar, registryTokenKey)
+	}
+	return false, fmt.Errorf("registry %s returned HTTP %d for %s", ref.Registry, resp.StatusCode, image)
+}
+
+// headManifest sends a manifest HEAD request, with token when not empty
+func (r *registryClient) headManifest(ctx context.Context, manifestURL, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach registry: %w", err)
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// challengeParamPattern matches the key="value" pairs of a
+// WWW-Authenticate challenge
+var challengeParamPattern = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// fetchAnonymousToken answers a bearer challenge by requesting a pull token
+// for the repository from the registry's token service
+func (r *registryClient) fetchAnonymousToken(ctx context.Context, challenge string, ref imageRef) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("registry %s requires authentication; set %s or the %s key", ref.Registry, registryTokenEnvVar, registryTokenKey)
+	}
+	values := make(map[string]string)
+	for _, match := range challengeParamPattern.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || values["realm"] == "" {
+		return "", fmt.Errorf("registry %s sent an invalid authentication challenge", ref.Registry)
+	}
+
+	query := realm.Query()
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	query.Set("scope", valueOr(values["scope"], "repository:"+ref.Repository+":pull"))
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get registry token: HTTP %d from %s", resp.StatusCode, realm.Host)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to get registry token: %w", err)
+	}
+	return valueOr(body.Token, body.AccessToken), nil
+}
@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/spf13/cobra"
 )
 
@@ -11,9 +16,17 @@ var rootCmd = &cobra.Command{
 	Use:   "nwx",
 	Short: "Netwrix CLI tool",
 	Long:  "A command-line interface tool for Netwrix operations and management.",
+	// Errors are reported once by Execute
+	SilenceErrors: true,
+	SilenceUsage:  true,
 	Run: func(cmd *cobra.Command, args []string) {
 		// If no arguments provided, start interactive mode
 		if len(args) == 0 {
+			if !noOnboardingFlag && needsOnboarding() {
+				if err := runOnboarding(cmd.Context()); err != nil {
+					fmt.Printf(glyphs("❌ Onboarding failed: %v\n"), err)
+				}
+			}
 			InteractiveCommand.Run(cmd, args)
 		} else {
 			cmd.Help()
@@ -21,14 +34,93 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+// errorFormatFlag selects how Execute reports a failed command ("text" or "json")
+var errorFormatFlag string
+
+// timeoutFlag bounds the whole command (--timeout); zero means no limit
+var timeoutFlag time.Duration
+
+// cancelTimeout releases the --timeout context once the command returns
+var cancelTimeout context.CancelFunc = func() {}
+
+// exitCodeTimeout is the exit status when --timeout expires, as with timeout(1)
+const exitCodeTimeout = 124
+
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	err := rootCmd.Execute()
+	cancelTimeout()
+	if err == nil {
+		return
+	}
+	code := exitCodeFor(err)
+	switch {
+	case code == exitCodeTimeout:
+		printError(fmt.Errorf("timed out after %s (--timeout): %w", timeoutFlag, err))
+	case code == exitCodeCancelled:
+		// The user interrupted a prompt; the command has already said so
+	case errors.Is(err, errPingFailed) || errors.Is(err, errSpecInvalid) || errors.Is(err, errSourcesUnreachable):
+		// The command has already reported the failure
+	default:
+		printError(err)
+	}
+	os.Exit(code)
+}
+
+// exitCodeFor returns the exit status for the error a command returned
+func exitCodeFor(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, context.DeadlineExceeded) && timeoutFlag > 0:
+		return exitCodeTimeout
+	case errors.Is(err, errCancelledByUser) || errors.Is(err, terminal.InterruptErr):
+		return exitCodeCancelled
+	default:
+		return 1
+	}
+}
+
+// cliError is the structured form of an error printed with --error-format json
+type cliError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Status  int    `json:"status,omitempty"`
+}
+
+// printError reports err on stderr in the selected error format
+func printError(err error) {
+	if errorFormatFlag != "json" {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", redactSecrets(err.Error()))
+		return
+	}
+
+	structured := cliError{Code: "error", Message: err.Error()}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		structured.Code = valueOr(apiErr.Code, "api_error")
+		structured.Message = valueOr(apiErr.Message, apiErr.Body)
+		structured.Status = apiErr.StatusCode
 	}
+
+	structured.Message = redactSecrets(structured.Message)
+	data, _ := json.Marshal(map[string]cliError{"error": structured})
+	fmt.Fprintln(os.Stderr, string(data))
 }
 
 func showIntroScreen() {
+	switch brandingMode() {
+	case brandingNone:
+		return
+	case brandingNeutral:
+		showNeutralHeader()
+		return
+	}
+	
+	if asciiMode {
+		showASCIILogo()
+		return
+	}
+	
 	// NETWRIX ASCII art logo in Vigilant Blue
 	fmt.Println("\033[38;2;92;51;255m") // Vigilant Blue RGB (92, 51, 255)
 	fmt.Println("███╗   ██╗ ███████╗ ████████╗ ██╗    ██╗ ██████╗  ██╗ ██╗  ██╗")
@@ -46,7 +138,7 @@ func showIntroScreen() {
 	fmt.Println("╚══════╝ ╚══════╝ ╚═╝")
 	fmt.Println("\033[0m") // Reset color
 	fmt.Println()
-	fmt.Println("\033[38;2;255;198;26m🚧 Under Construction 🚧\033[0m") // Signal Yellow
+	fmt.Println(glyphs("\033[38;2;255;198;26m🚧 Under Construction 🚧\033[0m")) // Signal Yellow
 	fmt.Println()
 	fmt.Println("\033[38;2;252;250;245mYou've stumbled upon something that doesn't exist yet.\033[0m") // Access White
 	fmt.Println("\033[38;2;252;250;245mIf you're curious about what we're building, we'd love to hear from you.\033[0m")
@@ -58,5 +150,37 @@ func showIntroScreen() {
 }
 
 func init() {
-	// Remove all flags and subcommands - just show the intro
+	rootCmd.PersistentFlags().StringVar(&errorFormatFlag, "error-format", "text", "Format for errors printed on failure (text|json)")
+	rootCmd.PersistentFlags().StringVar(&configDirFlag, "config", "", "Configuration directory (default ~/.nwx or $XDG_CONFIG_HOME/nwx)")
+	rootCmd.PersistentFlags().BoolVar(&asciiFlag, "ascii", false, "Use ASCII instead of emoji and box drawing (detected from the locale when not given)")
+	rootCmd.PersistentFlags().BoolVar(&noPagerFlag, "no-pager", false, "Do not page long output through $PAGER (default less)")
+	rootCmd.PersistentFlags().BoolVar(&noColorFlag, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&noOnboardingFlag, "no-onboarding", false, "Skip the first-run setup when no endpoint is configured")
+	rootCmd.PersistentFlags().BoolVar(&noIntroFlag, "no-intro", false, "Skip the intro banner (also set with 'nwx config set noIntro true')")
+	rootCmd.PersistentFlags().IntVar(&maxPagesFlag, "max-pages", defaultMaxPages, "Maximum number of pages to fetch from paginated API listings")
+	rootCmd.PersistentFlags().BoolVarP(&assumeYesFlag, "yes", "y", false, "Answer yes to all confirmation prompts")
+	rootCmd.PersistentFlags().BoolVar(&assumeYesFlag, "assume-yes", false, "Alias for --yes")
+	rootCmd.PersistentFlags().StringVar(&tokenFileFlag, "token-file", "", "Read the API token from this file before each request (overrides $"+tokenEnvVar+" and the token settings)")
+	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "Print API requests and responses to stderr, with secrets redacted")
+	rootCmd.PersistentFlags().StringVar(&endpointOverrideFlag, "api-endpoint", "", "Access Analyzer endpoint for this command (overrides $"+endpointEnvVar+", profiles and the saved endpoint)")
+	rootCmd.PersistentFlags().StringVar(&profileOverrideFlag, "use-profile", "", "Use this endpoint profile for this command (overrides $"+profileEnvVar+" and the saved endpoint)")
+	rootCmd.PersistentFlags().DurationVar(&timeoutFlag, "timeout", 0, "Abort the command after this long (e.g. 30s, 2m); 0 means no limit")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if errorFormatFlag != "text" && errorFormatFlag != "json" {
+			errorFormat := errorFormatFlag
+			errorFormatFlag = "text"
+			return fmt.Errorf("invalid error format '%s' (expected text or json)", errorFormat)
+		}
+		applyColorSettings()
+		applyGlyphSettings(cmd)
+		if timeoutFlag < 0 {
+			return fmt.Errorf("invalid --timeout %s (must not be negative)", timeoutFlag)
+		}
+		if timeoutFlag > 0 {
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeoutFlag)
+			cancelTimeout = cancel
+			cmd.SetContext(ctx)
+		}
+		return nil
+	}
 }
\ No newline at end of file
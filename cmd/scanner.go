@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/AlecAivazis/survey/v2/core"
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +24,25 @@ var scannerCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("Scanner Management")
 		fmt.Println("Available commands:")
-		fmt.Println("  nwx aa scanner --create    - Create a new scanner interactively")
+		fmt.Println("  nwx aa scanner --create        - Create a new scanner interactively")
+		fmt.Println("  nwx aa scanner create          - Create a new scanner interactively")
+		fmt.Println("  nwx aa scanner validate        - Validate a scanner specification")
+		fmt.Println("  nwx aa scanner schema          - Print the specification JSON Schema")
+		fmt.Println("  nwx aa scanner rename          - Rename a scanner")
+		fmt.Println("  nwx aa scanner clone           - Create a new scanner from an existing one")
+		fmt.Println("  nwx aa scanner add-auth        - Add an authentication method to a specification")
+		fmt.Println("  nwx aa scanner test-connection - Check a scanner config against its specification")
+		fmt.Println("  nwx aa scanner diff            - Compare a local specification with the registered one")
+		fmt.Println("  nwx aa scanner bump-version    - Bump a scanner's version")
+		fmt.Println("  nwx aa scanner edit            - Edit an existing scanner's metadata")
+		fmt.Println("  nwx aa scanner workspace       - Run scanner commands across a workspace")
+		fmt.Println("  nwx aa scanner names           - Show a scanner's queue and table names")
+		fmt.Println("  nwx aa scanner doctor          - Check a scanner directory for common problems")
+		fmt.Println("  nwx aa scanner build           - Build a scanner's Docker image")
+		fmt.Println("  nwx aa scanner scaffold-db     - Generate the code that saves results to ClickHouse")
+		fmt.Println("  nwx aa scanner run-local       - Run a Python or Node.js scanner without Docker")
+		fmt.Println("  nwx aa scanner list-languages  - Print the supported languages as JSON")
+		fmt.Println("  nwx aa scanner list-scan-types - Print the supported scan types as JSON")
 		fmt.Println()
 		fmt.Println("Use 'nwx aa scanner <command> --help' for more information.")
 	},
@@ -27,53 +51,133 @@ var scannerCmd = &cobra.Command{
 var scannerCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new scanner",
-	Long:  "Interactive scanner creation workflow",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("🚀 Interactive Scanner Creation")
+	Long: `Interactive scanner creation workflow.
+
+With --yes every confirmation (summary, specification preview, name
+collisions, overwriting existing files) is answered automatically. The
+scanner's name, language, scan types, authentication methods and output
+directory are still asked for, so the command needs a terminal.
+
+Existing files in the output directory are only replaced after
+confirmation, and files tracked by git additionally require --force.
+
+With --summary-only the answers are collected and validated and the
+summary is printed (as JSON with --summary-format json), but no
+specification or files are generated.
+
+In shared environments --name-prefix (or 'nwx config set name-prefix')
+prepends a prefix such as 'dev-alice-' to the scanner name. The duplicate
+check uses the prefixed name; the display name is left as entered.
+
+Generated files are listed as they are written, followed by the number of
+files and the time taken. --quiet leaves out the per-file lines, and
+--generation-format json prints the report as JSON with the time taken by
+each file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println(glyphs("🚀 Interactive Scanner Creation"))
 		fmt.Println("=" + strings.Repeat("=", 35))
 		fmt.Println()
 		
+		if err := validateClickHouseProtocol(clickHouseProtocolFlag); err != nil {
+			return err
+		}
+		if iconFlag != "" {
+			if err := validateScannerIcon(iconFlag); err != nil {
+				return err
+			}
+		}
+		if err := validateReadmeFormat(readmeFormatFlag); err != nil {
+			return err
+		}
+		if err := validateOutputFormat(summaryFormatFlag, outputText, outputJSON); err != nil {
+			return err
+		}
+		if err := validateOutputFormat(generationFormatFlag, outputText, outputJSON); err != nil {
+			return err
+		}
+		if err := validateOwnersFormat(ownersFormatFlag); err != nil {
+			return err
+		}
+		if _, err := parseEnvVars(envFlag); err != nil {
+			return err
+		}
+		if _, err := parseServiceEnv(); err != nil {
+			return err
+		}
+		if _, err := findTemplateSet(templateVersionFlag); err != nil {
+			return err
+		}
+		if err := validateNamePrefix(namePrefixFlag); err != nil {
+			return err
+		}
+		if err := validateSourceKind(sourceKindFlag); err != nil {
+			return err
+		}
+		if err := validateMaintainer(maintainerFlag); err != nil {
+			return err
+		}
+		
 		// Check if endpoint is configured
 		client, err := getAPIClient()
 		if err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
-			return
+			return err
 		}
 		
-		fmt.Printf("🔍 Connecting to Access Analyzer at: %s\n", client.BaseURL)
+		fmt.Printf(glyphs("🔍 Connecting to Access Analyzer at: %s\n"), client.BaseURL)
 		
-		// Test connection first
-		if err := client.TestConnection(); err != nil {
-			fmt.Printf("❌ Connection failed: %v\n", err)
-			return
-		}
-		
-		// Get existing scanners
-		response, err := client.GetSourceTypes()
-		if err != nil {
-			fmt.Printf("⚠️  Could not fetch existing scanners: %v\n", err)
+		// Test connection first, falling back to cached scanners offline
+		var existing []SourceType
+		if connErr := client.TestConnection(cmd.Context()); connErr != nil {
+			cached, ok := cachedExistingScanners(client.BaseURL)
+			if !ok {
+				return fmt.Errorf("connection failed: %w", connErr)
+			}
+			fmt.Printf(glyphs("❌ Connection failed: %v\n"), connErr)
+			existing = cached
 		} else {
-			fmt.Printf("✅ Found %d existing scanners\n", len(response.Data))
+			// Get existing scanners
+			existing, err = fetchExistingScanners(cmd.Context(), client)
 		}
-		fmt.Println()
-		
-		// Start interactive scanner creation workflow
-		if err := runInteractiveScannerCreation(response); err != nil {
-			if err.Error() == "EOF" {
-				fmt.Println("❌ Scanner creation cancelled by user")
-				return
-			}
-			fmt.Printf("❌ Scanner creation failed: %v\n", err)
-			return
+		if err == nil {
+			// Start interactive scanner creation workflow
+			err = runInteractiveScannerCreation(existing)
 		}
+		if err != nil {
+			return scannerCreationError(err)
+		}
+		return nil
 	},
 }
 
+// scannerCreationError reports how scanner creation failed and returns the
+// command's error. A cancellation is reported here, so Execute only sets
+// the exit status.
+func scannerCreationError(err error) error {
+	if errors.Is(err, errCancelledByUser) {
+		fmt.Println(glyphs("❌ Scanner creation cancelled by user"))
+		return errCancelledByUser
+	}
+	return fmt.Errorf("scanner creation failed: %w", err)
+}
+
 
 
 // Add --create flag to scanner command
 var createFlag bool
 
+// Scanner creation options
+var (
+	clickHouseProtocolFlag string
+	iconFlag               string
+	envConfigFlag          bool
+	readmeFormatFlag       string
+	summaryOnlyFlag        bool
+	summaryFormatFlag      string
+	ownerFlag              string
+	ownersFormatFlag       string
+	envFlag                []string
+)
+
 // ScannerCreationData holds the data collected during scanner creation
 type ScannerCreationData struct {
 	// Basic Information
@@ -93,11 +197,131 @@ type ScannerCreationData struct {
 	// File Generation
 	GenerateFiles bool
 	OutputDir     string
+	
+	// Collection database protocol ("native" or "http"); empty picks the
+	// language default (see clickHouseProtocol)
+	ClickHouseProtocol string
+	
+	// Also generate config/config.env.json referencing environment variables
+	EnvConfig bool
+	
+	// Markup of the generated README ("md", "rst" or "adoc")
+	ReadmeFormat string
+	
+	// Owner written to an ownership file; no file is generated when empty
+	Owner        string
+	OwnersFormat string
+	
+	// Contact stored in the source type as maintainer (--maintainer)
+	Maintainer string
+	
+	// Values entered for connection config fields. Secrets are masked
+	// wherever they are shown (see maskConfigValue).
+	ConnectionValues []ConfigValue
+	
+	// Extra runtime environment variables added to the generated Dockerfile
+	ExtraEnv []EnvVar
+	
+	// Service hosts and ports replacing the Dockerfile defaults (--rabbitmq-host, ...)
+	ServiceEnv []EnvVar
+	
+	// Output column names by scan type in the order chosen with
+	// --reorder-columns; columns not listed keep their default order
+	ColumnOrder map[string][]string
+	
+	// Version of the bundled templates to generate ("v1", "v2"; latest when empty)
+	TemplateVersion string
+	
+	// Prefix prepended to Name to avoid collisions in shared environments
+	NamePrefix string
+	
+	// Well-known kind of data source (e.g. "smb"), seeding connection and
+	// authentication defaults; empty when none was chosen
+	SourceKind string
+}
+
+// fetchExistingScanners fetches the registered scanners used to detect
+// duplicate names, retrying briefly on transient failures, and refreshes the
+// opt-in cache. If they still can't be fetched the cached scanners are used
+// when there are any; otherwise the user is warned that duplicates won't be
+// detected and, when prompting is possible, offered another try. Only a
+// cancelled prompt is returned as an error.
+func fetchExistingScanners(ctx context.Context, client *APIClient) ([]SourceType, error) {
+	for {
+		var existing []SourceType
+		err := retryWithBackoff(ctx, defaultRetryAttempts, defaultRetryDelay, func() error {
+			var err error
+			existing, err = client.GetAllSourceTypes(ctx)
+			return err
+		})
+		if err == nil {
+			fmt.Printf(glyphs("✅ Found %d existing scanners\n"), len(existing))
+			if err := saveSourceTypesCache(client.BaseURL, existing); err != nil {
+				fmt.Printf(glyphs("⚠️  Could not cache existing scanners: %v\n"), err)
+			}
+			fmt.Println()
+			return existing, nil
+		}
+		
+		fmt.Printf(glyphs("⚠️  Could not fetch existing scanners: %v\n"), err)
+		if cached, ok := cachedExistingScanners(client.BaseURL); ok {
+			return cached, nil
+		}
+		fmt.Println("   Duplicate scanner names won't be detected.")
+		
+		// --yes would answer the retry question forever
+		if assumeYesFlag || !isInteractiveTerminal() {
+			fmt.Println()
+			return nil, nil
+		}
+		retry, err := askConfirm(&survey.Confirm{
+			Message: "Retry fetching existing scanners?",
+			Default: true,
+		})
+		if err != nil {
+			return nil, normalizeCancellation(err)
+		}
+		if !retry {
+			fmt.Println()
+			return nil, nil
+		}
+	}
 }
 
-// runInteractiveScannerCreation runs the interactive scanner creation workflow
-func runInteractiveScannerCreation(existingScanners *SourceTypeListResponse) error {
-	scanner := &ScannerCreationData{}
+// runInteractiveScannerCreation runs the interactive scanner creation workflow.
+// Interrupting any step returns errCancelledByUser.
+func runInteractiveScannerCreation(existingScanners []SourceType) (err error) {
+	defer func() {
+		err = normalizeCancellation(err)
+	}()
+	
+	extraEnv, err := parseEnvVars(envFlag)
+	if err != nil {
+		return err
+	}
+	serviceEnv, err := parseServiceEnv()
+	if err != nil {
+		return err
+	}
+	namePrefix, err := scannerNamePrefix()
+	if err != nil {
+		return err
+	}
+	
+	scanner := &ScannerCreationData{
+		Icon:               iconFlag,
+		ClickHouseProtocol: clickHouseProtocolFlag,
+		EnvConfig:          envConfigFlag,
+		ReadmeFormat:       readmeFormatFlag,
+		Owner:              ownerFlag,
+		Maintainer:         maintainerFlag,
+		OwnersFormat:       ownersFormatFlag,
+		ExtraEnv:           extraEnv,
+		ServiceEnv:         serviceEnv,
+		TemplateVersion:    templateVersionFlag,
+		NamePrefix:         namePrefix,
+		SourceKind:         sourceKindFlag,
+	}
 	
 	// Step 1: Basic Information
 	if err := collectBasicInfo(scanner, existingScanners); err != nil {
@@ -113,71 +337,123 @@ func runInteractiveScannerCreation(existingScanners *SourceTypeListResponse) err
 	if err := collectScanTypes(scanner); err != nil {
 		return err
 	}
+	if reorderColumnsFlag {
+		if err := collectColumnOrder(scanner); err != nil {
+			return err
+		}
+	}
 	
 	// Step 4: Authentication Methods
 	if err := collectAuthMethods(scanner); err != nil {
 		return err
 	}
 	
+	if summaryOnlyFlag {
+		// Collect and validate the answers only; nothing is generated
+		scanner.GenerateFiles = false
+		if err := confirmNameCollisions(scanner, existingScanners); err != nil {
+			return err
+		}
+		if summaryFormatFlag == outputJSON {
+			return printJSON(newScannerSummary(scanner))
+		}
+		printScannerSummary(scanner)
+		return nil
+	}
+	
 	// Step 5: File Generation Options
 	if err := collectFileGeneration(scanner); err != nil {
 		return err
 	}
 	
-	// Step 6: Summary and Confirmation
-	if err := showSummaryAndConfirm(scanner); err != nil {
-		return err
+	for {
+		// Queue and table names combine name and version, so a new
+		// version can still clash with an existing scanner
+		if err := confirmNameCollisions(scanner, existingScanners); err != nil {
+			return err
+		}
+		
+		// Step 6: Summary and Confirmation
+		if err := showSummaryAndConfirm(scanner); err != nil {
+			return err
+		}
+		
+		if !scanner.GenerateFiles {
+			break
+		}
+		
+		// Step 7: Specification Preview
+		action, err := previewSpecification(scanner)
+		if err != nil {
+			return err
+		}
+		if action == previewProceed {
+			break
+		}
+		if err := editScannerStep(scanner, existingScanners); err != nil {
+			return err
+		}
 	}
 	
-	// Step 7: Generate Files
+	// Step 8: Generate Files
 	if scanner.GenerateFiles {
 		return generateScannerFiles(scanner)
 	}
 	
-	fmt.Println("✅ Scanner configuration completed!")
+	fmt.Println(glyphs("✅ Scanner configuration completed!"))
 	return nil
 }
 
 // collectBasicInfo collects basic scanner information
-func collectBasicInfo(scanner *ScannerCreationData, existing *SourceTypeListResponse) error {
-	fmt.Println("📋 Step 1: Basic Information")
+func collectBasicInfo(scanner *ScannerCreationData, existing []SourceType) error {
+	fmt.Println(glyphs("📋 Step 1: Basic Information"))
 	fmt.Println()
 	
 	// Get existing scanner names for validation
 	existingNames := make(map[string]bool)
-	if existing != nil {
-		for _, s := range existing.Data {
-			existingNames[s.TypeName] = true
-		}
+	for _, s := range existing {
+		existingNames[s.TypeName] = true
 	}
 	
-	// Scanner name (kebab-case)
+	// Scanner name (kebab-case), checked with the prefix applied
 	namePrompt := &survey.Input{
 		Message: "Scanner name (kebab-case, e.g., 'my-scanner'):",
 		Help:    "This will be used as the technical identifier",
+		Default: strings.TrimPrefix(scanner.Name, scanner.NamePrefix),
+	}
+	if scanner.NamePrefix != "" {
+		fmt.Printf("Name prefix: %s (from --name-prefix or the %s key)\n", scanner.NamePrefix, namePrefixKey)
+		namePrompt.Help += "; the name prefix is prepended"
 	}
-	if err := survey.AskOne(namePrompt, &scanner.Name, survey.WithValidator(func(val interface{}) error {
+	if err := askOne(namePrompt, &scanner.Name, survey.WithValidator(func(val interface{}) error {
 		if str := val.(string); str != "" {
+			str = applyNamePrefix(scanner.NamePrefix, str)
 			if existingNames[str] {
 				return fmt.Errorf("scanner name '%s' already exists", str)
 			}
-			// Basic kebab-case validation
-			if !strings.Contains(str, "-") && len(str) > 0 {
-				return fmt.Errorf("scanner name should be kebab-case (e.g., 'my-scanner')")
+			if err := validateScannerName(str); err != nil {
+				return err
 			}
 		}
 		return nil
 	})); err != nil {
 		return err
 	}
+	scanner.Name = applyNamePrefix(scanner.NamePrefix, scanner.Name)
 	
+	return collectScannerDetails(scanner)
+}
+
+// collectScannerDetails collects the descriptive fields shared by scanner
+// creation and editing: display name, description, version and icon
+func collectScannerDetails(scanner *ScannerCreationData) error {
 	// Display name
 	displayPrompt := &survey.Input{
 		Message: "Display name:",
 		Help:    "Human-readable name shown in the UI",
-		Default: strings.Title(strings.ReplaceAll(scanner.Name, "-", " ")),
+		Default: valueOr(scanner.DisplayName, strings.Title(strings.ReplaceAll(strings.TrimPrefix(scanner.Name, scanner.NamePrefix), "-", " "))),
 	}
-	if err := survey.AskOne(displayPrompt, &scanner.DisplayName); err != nil {
+	if err := askOne(displayPrompt, &scanner.DisplayName); err != nil {
 		return err
 	}
 	
@@ -185,50 +461,82 @@ func collectBasicInfo(scanner *ScannerCreationData, existing *SourceTypeListResp
 	descPrompt := &survey.Input{
 		Message: "Description:",
 		Help:    "Brief description of what this scanner does",
+		Default: scanner.Description,
 	}
-	if err := survey.AskOne(descPrompt, &scanner.Description); err != nil {
+	if err := askOne(descPrompt, &scanner.Description); err != nil {
 		return err
 	}
 	
 	// Version
 	versionPrompt := &survey.Input{
 		Message: "Version:",
-		Default: "1.0.0",
+		Default: valueOr(scanner.Version, "1.0.0"),
 		Help:    "Semantic version (e.g., 1.0.0)",
 	}
-	if err := survey.AskOne(versionPrompt, &scanner.Version); err != nil {
+	if err := askOne(versionPrompt, &scanner.Version); err != nil {
 		return err
 	}
 	
 	// Icon
-	iconOptions := []string{"folder", "database", "cloud", "server", "lock", "file", "network", "other"}
+	iconOptions := append(append([]string{}, suggestedIcons...), customIconOption)
+	iconDefault := valueOr(scanner.Icon, "folder")
+	if !contains(suggestedIcons, iconDefault) {
+		iconDefault = customIconOption
+	}
 	iconPrompt := &survey.Select{
 		Message: "Choose an icon:",
 		Options: iconOptions,
-		Default: "folder",
+		Default: iconDefault,
 	}
-	if err := survey.AskOne(iconPrompt, &scanner.Icon); err != nil {
+	var icon string
+	if err := askOne(iconPrompt, &icon); err != nil {
 		return err
 	}
 	
+	if icon == customIconOption {
+		customPrompt := &survey.Input{
+			Message: "Icon name or URL:",
+			Help:    "A built-in icon name (e.g. 'shield') or an http(s) URL to an image",
+		}
+		if !contains(suggestedIcons, scanner.Icon) {
+			customPrompt.Default = scanner.Icon
+		}
+		if err := askOne(customPrompt, &icon, survey.WithValidator(survey.Required), survey.WithValidator(func(val interface{}) error {
+			return validateScannerIcon(val.(string))
+		})); err != nil {
+			return err
+		}
+	}
+	scanner.Icon = icon
+	
 	fmt.Println()
 	return nil
 }
 
+// languageOptions are the scanner languages offered during creation
+var languageOptions = []string{"python", "javascript", "go", "java", "c#"}
+
+// scanTypeOptions are the scan types a scanner can support
+var scanTypeOptions = []string{"access", "sensitive_data"}
+
 // collectLanguage collects the programming language for the scanner
 func collectLanguage(scanner *ScannerCreationData) error {
-	fmt.Println("💻 Step 2: Programming Language")
+	fmt.Println(glyphs("💻 Step 2: Programming Language"))
 	fmt.Println()
 	
-	languageOptions := []string{"python", "javascript", "go", "java", "c#"}
 	languagePrompt := &survey.Select{
 		Message: "Select programming language:",
 		Options: languageOptions,
-		Default: "python",
+		Default: valueOr(scanner.Language, "python"),
 		Help:    "Choose the programming language for your scanner implementation",
 	}
 	
-	if err := survey.AskOne(languagePrompt, &scanner.Language); err != nil {
+	if err := askOne(languagePrompt, &scanner.Language, survey.WithValidator(func(val interface{}) error {
+		if answer, ok := val.(core.OptionAnswer); ok {
+			return checkClickHouseLanguage(scanner.ClickHouseProtocol, answer.Value)
+		}
+		return nil
+	})); err != nil {
 		return err
 	}
 	
@@ -238,18 +546,17 @@ func collectLanguage(scanner *ScannerCreationData) error {
 
 // collectScanTypes collects supported scan types
 func collectScanTypes(scanner *ScannerCreationData) error {
-	fmt.Println("🔍 Step 3: Scan Types")
+	fmt.Println(glyphs("🔍 Step 3: Scan Types"))
 	fmt.Println()
 	
-	scanTypeOptions := []string{"access", "sensitive_data"}
 	scanTypePrompt := &survey.MultiSelect{
 		Message: "Select supported scan types:",
 		Options: scanTypeOptions,
-		Default: []string{"access"},
+		Default: valuesOr(scanner.SupportedScanTypes, []string{"access"}),
 		Help:    "Use space to select/deselect, enter to confirm",
 	}
 	
-	if err := survey.AskOne(scanTypePrompt, &scanner.SupportedScanTypes); err != nil {
+	if err := askOne(scanTypePrompt, &scanner.SupportedScanTypes); err != nil {
 		return err
 	}
 	
@@ -257,29 +564,40 @@ func collectScanTypes(scanner *ScannerCreationData) error {
 	return nil
 }
 
+// authMethodOptions are the authentication methods offered during creation
+var authMethodOptions = []string{
+	"Username/Password",
+	"API Key",
+	"OAuth2",
+	"Certificate",
+	"Service Account",
+	"Windows Authentication",
+	"Custom",
+}
+
 // collectAuthMethods collects authentication methods
 func collectAuthMethods(scanner *ScannerCreationData) error {
-	fmt.Println("🔐 Step 4: Authentication Methods")
+	fmt.Println(glyphs("🔐 Step 4: Authentication Methods"))
 	fmt.Println()
 	
-	authOptions := []string{
-		"Username/Password",
-		"API Key",
-		"OAuth2",
-		"Certificate",
-		"Service Account",
-		"Windows Authentication",
-		"Custom",
+	if err := collectSourceKind(scanner); err != nil {
+		return err
+	}
+	
+	// Suggest the methods usual for the kind of source
+	defaultAuth := []string{"Username/Password"}
+	if kind, ok := scannerSourceKind(scanner); ok {
+		defaultAuth = kind.AuthMethods
 	}
 	
 	authPrompt := &survey.MultiSelect{
 		Message: "Select authentication methods:",
-		Options: authOptions,
-		Default: []string{"Username/Password"},
+		Options: authMethodOptions,
+		Default: valuesOr(scanner.AuthMethods, defaultAuth),
 		Help:    "Use space to select/deselect, enter to confirm",
 	}
 	
-	if err := survey.AskOne(authPrompt, &scanner.AuthMethods); err != nil {
+	if err := askOne(authPrompt, &scanner.AuthMethods); err != nil {
 		return err
 	}
 	
@@ -287,9 +605,24 @@ func collectAuthMethods(scanner *ScannerCreationData) error {
 	return nil
 }
 
+// newOutputDirPrompt builds the output directory prompt. Its default, which
+// becomes scanner.OutputDir when accepted, is the directory already chosen
+// or else the one from defaultScannerOutputDir.
+func newOutputDirPrompt(scanner *ScannerCreationData) (*survey.Input, error) {
+	defaultDir, err := defaultScannerOutputDir(scanner.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &survey.Input{
+		Message: "Output directory:",
+		Default: valueOr(scanner.OutputDir, defaultDir),
+		Help:    "Directory where scanner files will be generated",
+	}, nil
+}
+
 // collectFileGeneration collects file generation options
 func collectFileGeneration(scanner *ScannerCreationData) error {
-	fmt.Println("📁 Step 5: File Generation")
+	fmt.Println(glyphs("📁 Step 5: File Generation"))
 	fmt.Println()
 	
 	generatePrompt := &survey.Confirm{
@@ -298,17 +631,22 @@ func collectFileGeneration(scanner *ScannerCreationData) error {
 		Help:    "This will create the scanner structure, Dockerfile, and example code",
 	}
 	
-	if err := survey.AskOne(generatePrompt, &scanner.GenerateFiles); err != nil {
+	generate, err := askConfirm(generatePrompt)
+	if err != nil {
 		return err
 	}
+	scanner.GenerateFiles = generate
 	
 	if scanner.GenerateFiles {
-		dirPrompt := &survey.Input{
-			Message: "Output directory:",
-			Default: "./" + scanner.Name,
-			Help:    "Directory where scanner files will be generated",
+		dirPrompt, err := newOutputDirPrompt(scanner)
+		if err != nil {
+			return err
 		}
-		if err := survey.AskOne(dirPrompt, &scanner.OutputDir); err != nil {
+		if err := askOne(dirPrompt, &scanner.OutputDir); err != nil {
+			return err
+		}
+		
+		if err := collectExtraEnv(scanner); err != nil {
 			return err
 		}
 	}
@@ -319,138 +657,303 @@ func collectFileGeneration(scanner *ScannerCreationData) error {
 
 // showSummaryAndConfirm shows a summary and asks for confirmation
 func showSummaryAndConfirm(scanner *ScannerCreationData) error {
-	fmt.Println("📊 Step 6: Summary")
+	fmt.Println(glyphs("📊 Step 6: Summary"))
 	fmt.Println()
 	
+	printScannerSummary(scanner)
+	
+	confirmPrompt := &survey.Confirm{
+		Message: "Create scanner with these settings?",
+		Default: true,
+	}
+	
+	confirmed, err := askConfirm(confirmPrompt)
+	if err != nil {
+		return err
+	}
+	
+	if !confirmed {
+		return fmt.Errorf("scanner creation cancelled")
+	}
+	
+	return nil
+}
+
+// printScannerSummary prints the collected scanner settings
+func printScannerSummary(scanner *ScannerCreationData) {
 	fmt.Printf("Name:          %s\n", scanner.Name)
 	fmt.Printf("Display Name:  %s\n", scanner.DisplayName)
 	fmt.Printf("Description:   %s\n", scanner.Description)
 	fmt.Printf("Version:       %s\n", scanner.Version)
 	fmt.Printf("Icon:          %s\n", scanner.Icon)
 	fmt.Printf("Language:      %s\n", scanner.Language)
+	if scanner.Maintainer != "" {
+		fmt.Printf("Maintainer:    %s\n", scanner.Maintainer)
+	}
+	if kind, ok := scannerSourceKind(scanner); ok {
+		fmt.Printf("Source Kind:   %s (port %d)\n", kind.Label, kind.Port)
+	}
 	fmt.Printf("Scan Types:    %s\n", strings.Join(scanner.SupportedScanTypes, ", "))
 	fmt.Printf("Auth Methods:  %s\n", strings.Join(scanner.AuthMethods, ", "))
+	fmt.Printf("ClickHouse:    %s (port %s)\n", clickHouseProtocol(scanner), collectionDBPort(scanner))
+	fmt.Printf("Templates:     %s\n", scannerTemplateSet(scanner).Version)
+	if len(scanner.ExtraEnv) > 0 {
+		fmt.Printf("Environment:   %s\n", strings.Join(envVarNames(scanner.ExtraEnv), ", "))
+	}
+	if len(scanner.ServiceEnv) > 0 {
+		services := make([]string, 0, len(scanner.ServiceEnv))
+		for _, v := range scanner.ServiceEnv {
+			services = append(services, v.Name+"="+v.Value)
+		}
+		fmt.Printf("Services:      %s\n", strings.Join(services, ", "))
+	}
+	
+	if len(scanner.ConnectionValues) > 0 {
+		fmt.Println("Connection:")
+		for _, cv := range scanner.ConnectionValues {
+			fmt.Printf("  %-12s %s\n", cv.Item.Key+":", maskConfigValue(cv.Item, cv.Value))
+		}
+	}
+	
+	printOutputColumns(scanner)
 	
 	if scanner.GenerateFiles {
 		fmt.Printf("Output Dir:    %s\n", scanner.OutputDir)
 	}
 	
 	fmt.Println()
-	
-	confirmPrompt := &survey.Confirm{
-		Message: "Create scanner with these settings?",
-		Default: true,
+}
+
+// scannerSummary is the JSON form of the collected scanner settings
+type scannerSummary struct {
+	Name               string            `json:"name"`
+	DisplayName        string            `json:"displayName"`
+	Description        string            `json:"description"`
+	Version            string            `json:"version"`
+	Icon               string            `json:"icon"`
+	Language           string            `json:"language"`
+	SourceKind         string            `json:"sourceKind,omitempty"`
+	Maintainer         string            `json:"maintainer,omitempty"`
+	SupportedScanTypes []string          `json:"supportedScanTypes"`
+	AuthMethods        []string          `json:"authMethods"`
+	ClickHouseProtocol string            `json:"clickHouseProtocol"`
+	EnvConfig          bool              `json:"envConfig"`
+	ReadmeFormat       string            `json:"readmeFormat"`
+	ConnectionValues   map[string]string `json:"connectionValues,omitempty"`
+	ExtraEnv           []string          `json:"extraEnv,omitempty"`
+	ServiceEnv         map[string]string `json:"serviceEnv,omitempty"`
+	TemplateVersion    string            `json:"templateVersion"`
+	OutputColumns      map[string][]summaryColumn `json:"outputColumns,omitempty"`
+}
+
+// newScannerSummary returns the settings of scanner with defaults resolved
+func newScannerSummary(scanner *ScannerCreationData) scannerSummary {
+	return scannerSummary{
+		Name:               scanner.Name,
+		DisplayName:        scanner.DisplayName,
+		Description:        scanner.Description,
+		Version:            scanner.Version,
+		Icon:               scanner.Icon,
+		Language:           scanner.Language,
+		SourceKind:         scanner.SourceKind,
+		Maintainer:         scanner.Maintainer,
+		SupportedScanTypes: valuesOr(scanner.SupportedScanTypes, []string{}),
+		AuthMethods:        valuesOr(scanner.AuthMethods, []string{}),
+		ClickHouseProtocol: clickHouseProtocol(scanner),
+		EnvConfig:          scanner.EnvConfig,
+		ReadmeFormat:       valueOr(scanner.ReadmeFormat, readmeMarkdown),
+		ConnectionValues:   maskedConnectionValues(scanner.ConnectionValues),
+		ExtraEnv:           envVarNames(scanner.ExtraEnv),
+		ServiceEnv:         serviceEnvValues(scanner.ServiceEnv),
+		TemplateVersion:    scannerTemplateSet(scanner).Version,
+		OutputColumns:      summaryOutputColumns(scanner),
 	}
+}
+
+// maskedConnectionValues returns the connection values by key with secrets masked
+func maskedConnectionValues(values []ConfigValue) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	masked := make(map[string]string, len(values))
+	for _, cv := range values {
+		masked[cv.Item.Key] = maskConfigValue(cv.Item, cv.Value)
+	}
+	return masked
+}
+
+// GeneratedFile is a single file produced for a new scanner, with its path
+// relative to the output directory
+type GeneratedFile struct {
+	Name  string `json:"name"`
+	Bytes []byte `json:"-"`
+}
+
+// GenerationResult is everything generated for a new scanner. Nothing is
+// written to disk until it is passed to writeGeneratedFiles.
+type GenerationResult struct {
+	OutputDir string          `json:"outputDir"`
+	Files     []GeneratedFile `json:"files"`
+	Names     ScannerNames    `json:"names"`
+}
+
+// generateScannerFiles generates the scanner files, writes them to the
+// output directory and prints the next steps
+func generateScannerFiles(scanner *ScannerCreationData) error {
+	fmt.Printf(glyphs("🚀 Generating scanner files in: %s\n"), scanner.OutputDir)
 	
-	var confirmed bool
-	if err := survey.AskOne(confirmPrompt, &confirmed); err != nil {
+	result, err := generateScanner(scanner)
+	if err != nil {
 		return err
 	}
-	
-	if !confirmed {
-		return fmt.Errorf("scanner creation cancelled")
+	if err := writeGeneratedFiles(result); err != nil {
+		return err
 	}
 	
+	fmt.Println()
+	fmt.Println(glyphs("✅ Scanner files generated successfully!"))
+	fmt.Println()
+	
+	// Infrastructure has to be set up with the names the scanner derives
+	printScannerNames(result.Names)
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  1. cd %s\n", result.OutputDir)
+	fmt.Println("  2. Review and customize the generated files")
+	fmt.Println("  3. Update scanner.py with your specific implementation")
+	fmt.Println("  4. Test your scanner: docker build -t my-scanner .")
+	fmt.Println("  5. Deploy to Access Analyzer")
+	
 	return nil
 }
 
-// generateScannerFiles generates the scanner files
-func generateScannerFiles(scanner *ScannerCreationData) error {
-	fmt.Printf("🚀 Generating scanner files in: %s\n", scanner.OutputDir)
-	
-	// Create output directory
-	if err := os.MkdirAll(scanner.OutputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+// generateScanner renders every file for a new scanner in memory and
+// derives the queue and table names it will use
+func generateScanner(scanner *ScannerCreationData) (*GenerationResult, error) {
+	result := &GenerationResult{OutputDir: scanner.OutputDir}
+	add := func(name, content string) {
+		result.Files = append(result.Files, GeneratedFile{Name: name, Bytes: []byte(content)})
 	}
 	
-	// Create config directory
-	configDir := filepath.Join(scanner.OutputDir, "config")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
+	specContent := generateScannerSpecification(scanner)
+	add("scannerSpecification.json", specContent)
+	add("Dockerfile", addDockerfileEnv(overrideDockerfileEnv(generateDockerfile(scanner), scanner.ServiceEnv), scanner.ExtraEnv))
+	add(readmeFileName(scanner), generateReadme(scanner))
+	add("config/config.example.json", generateConfigExample(scanner))
+	add(fmt.Sprintf("%s-source-type.json", scanner.Name), generateSourceType(scanner))
+	
+	if scanner.EnvConfig {
+		add("config/config.env.json", generateConfigEnvExample(scanner))
 	}
 	
-	// Generate all files
-	files := []struct {
-		name    string
-		content string
-	}{
-		{"scannerSpecification.json", generateScannerSpecification(scanner)},
-		{"Dockerfile", generateDockerfile(scanner)},
-		{"README.md", generateReadme(scanner)},
-		{"config/config.example.json", generateConfigExample(scanner)},
-		{fmt.Sprintf("%s-source-type.json", scanner.Name), generateSourceType(scanner)},
+	if scanner.Owner != "" {
+		add(ownersFileName(scanner), generateOwnersFile(scanner))
 	}
 	
 	// Add language-specific files
 	switch scanner.Language {
 	case "python":
-		files = append(files, 
-			struct{name, content string}{"requirements.txt", generateRequirements(scanner)},
-			struct{name, content string}{"scanner.py", generateScannerPython(scanner)},
-		)
+		add("requirements.txt", generateRequirements(scanner))
+		add("scanner.py", generateScannerPython(scanner))
 	case "javascript":
-		files = append(files, 
-			struct{name, content string}{"package.json", generatePackageJson(scanner)},
-			struct{name, content string}{"scanner.js", generateScannerJavaScript(scanner)},
-		)
+		add("package.json", generatePackageJson(scanner))
+		add("scanner.js", generateScannerJavaScript(scanner))
 	case "go":
-		files = append(files, 
-			struct{name, content string}{"go.mod", generateGoMod(scanner)},
-			struct{name, content string}{"scanner.go", generateScannerGo(scanner)},
-		)
+		add("go.mod", generateGoMod(scanner))
+		add("scanner.go", generateScannerGo(scanner))
 	case "java":
-		files = append(files, 
-			struct{name, content string}{"pom.xml", generatePomXml(scanner)},
-			struct{name, content string}{"Scanner.java", generateScannerJava(scanner)},
-		)
+		add("pom.xml", generatePomXml(scanner))
+		add("Scanner.java", generateScannerJava(scanner))
 	case "c#":
-		files = append(files, 
-			struct{name, content string}{"Scanner.csproj", generateCsProj(scanner)},
-			struct{name, content string}{"Scanner.cs", generateScannerCSharp(scanner)},
-		)
+		add("Scanner.csproj", generateCsProj(scanner))
+		add("Scanner.cs", generateScannerCSharp(scanner))
+	}
+	
+	spec, err := parseSpec([]byte(specContent))
+	if err != nil {
+		return nil, err
+	}
+	result.Names = deriveScannerNames(spec.Name, spec.Version, specScanTypes(spec))
+	
+	return result, nil
+}
+
+// writeGeneratedFiles writes a generation result to its output directory,
+// asking before existing files are replaced
+func writeGeneratedFiles(result *GenerationResult) error {
+	// Create output directory
+	_, statErr := os.Stat(result.OutputDir)
+	outputDirExisted := statErr == nil
+	if err := os.MkdirAll(result.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 	
-	for _, file := range files {
-		filePath := filepath.Join(scanner.OutputDir, file.name)
-		if err := os.WriteFile(filePath, []byte(file.content), 0644); err != nil {
-			return fmt.Errorf("failed to write %s: %w", file.name, err)
+	// Create config directory
+	configDir := filepath.Join(result.OutputDir, "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	
+	// Don't silently replace files from an earlier run
+	var existingFiles []string
+	for _, file := range result.Files {
+		if _, err := os.Stat(filepath.Join(result.OutputDir, file.Name)); err == nil {
+			existingFiles = append(existingFiles, file.Name)
+		}
+	}
+	if len(existingFiles) > 0 {
+		// Committed customizations are only overwritten with --force
+		if tracked := gitTrackedFiles(result.OutputDir, existingFiles); len(tracked) > 0 {
+			if !forceFlag {
+				return fmt.Errorf("refusing to overwrite files tracked by git: %s (use --force to overwrite them)", strings.Join(tracked, ", "))
+			}
+			fmt.Printf(glyphs("⚠️  Overwriting files tracked by git (--force): %s\n"), strings.Join(tracked, ", "))
+		}
+		
+		fmt.Printf(glyphs("⚠️  These files already exist: %s\n"), strings.Join(existingFiles, ", "))
+		overwrite, err := askConfirm(&survey.Confirm{
+			Message: fmt.Sprintf("Overwrite %d existing file(s)?", len(existingFiles)),
+			Default: false,
+		})
+		if err != nil {
+			return err
+		}
+		if !overwrite {
+			return fmt.Errorf("scanner creation cancelled: existing files left unchanged")
 		}
-		fmt.Printf("  ✅ Created %s\n", file.name)
 	}
 	
-	fmt.Println()
-	fmt.Println("✅ Scanner files generated successfully!")
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  1. cd %s\n", scanner.OutputDir)
-	fmt.Println("  2. Review and customize the generated files")
-	fmt.Println("  3. Update scanner.py with your specific implementation")
-	fmt.Println("  4. Test your scanner: docker build -t my-scanner .")
-	fmt.Println("  5. Deploy to Access Analyzer")
+	// Every file is attempted so all failures are reported together, and
+	// a failed run leaves the output directory as it was
+	progress := newGenerationProgress(len(result.Files))
+	if err := writeFilesWithRollback(result.OutputDir, result.Files, progress.wrote); err != nil {
+		if !outputDirExisted {
+			os.Remove(configDir)
+			os.Remove(result.OutputDir)
+		}
+		return err
+	}
 	
-	return nil
+	return progress.finish(result.OutputDir)
 }
 
 // generateScannerSpecification generates the scannerSpecification.json file
 func generateScannerSpecification(scanner *ScannerCreationData) string {
 	spec := map[string]interface{}{
-		"name":    strings.ToUpper(strings.ReplaceAll(scanner.Name, "-", "_")),
-		"version": scanner.Version,
+		"specVersion": scannerTemplateSet(scanner).SpecVersion,
+		"name":        toSpecName(scanner.Name),
+		"version":     scanner.Version,
 		"connectionConfig": map[string]interface{}{
-			"items": []map[string]interface{}{
-				{
-					"key":         "host",
-					"label":       "Host",
-					"type":        "text",
-					"required":    true,
-					"placeholder": "example.com",
-					"description": "Host to connect to",
-				},
-			},
+			"items": connectionConfigItems(scanner),
 		},
 		"outputSchema": generateMinimalOutputSchema(scanner),
 	}
 	
+	// Older templates predate specVersion
+	if scannerTemplateSet(scanner).SpecVersion == 0 {
+		delete(spec, "specVersion")
+	}
+	
 	// Add minimal access scan config for access scanners
 	if contains(scanner.SupportedScanTypes, "access") {
 		spec["accessScanConfig"] = map[string]interface{}{
@@ -498,7 +1001,7 @@ func generateMinimalOutputSchema(scanner *ScannerCreationData) map[string]interf
 	
 	if contains(scanner.SupportedScanTypes, "access") {
 		schema["access"] = map[string]interface{}{
-			"columns": []map[string]interface{}{
+			"columns": orderColumns(scanner.ColumnOrder["access"], []map[string]interface{}{
 				{
 					"name":        "scan_id",
 					"type":        "string",
@@ -522,13 +1025,13 @@ func generateMinimalOutputSchema(scanner *ScannerCreationData) map[string]interf
 					"defaultValue": "CURRENT_TIMESTAMP",
 					"description": "When this scan record was created",
 				},
-			},
+			}),
 		}
 	}
 	
 	if contains(scanner.SupportedScanTypes, "sensitive_data") {
 		schema["sensitiveData"] = map[string]interface{}{
-			"columns": []map[string]interface{}{
+			"columns": orderColumns(scanner.ColumnOrder["sensitive_data"], []map[string]interface{}{
 				{
 					"name":        "scan_id",
 					"type":        "string",
@@ -552,7 +1055,7 @@ func generateMinimalOutputSchema(scanner *ScannerCreationData) map[string]interf
 					"defaultValue": "CURRENT_TIMESTAMP",
 					"description": "When this scan record was created",
 				},
-			},
+			}),
 		}
 	}
 	
@@ -597,7 +1100,8 @@ ENV APP_DB_PASSWORD=app_password
 
 # Collection database is ClickHouse (NOT PostgreSQL)
 ENV COLLECTION_DB_HOST=clickhouse
-ENV COLLECTION_DB_PORT=9000
+ENV COLLECTION_DB_PORT=` + collectionDBPort(scanner) + `
+ENV COLLECTION_DB_PROTOCOL=` + clickHouseProtocol(scanner) + `
 ENV COLLECTION_DB_NAME=default
 ENV COLLECTION_DB_USER=default
 ENV COLLECTION_DB_PASSWORD=
@@ -636,7 +1140,8 @@ ENV APP_DB_USER=appuser
 ENV APP_DB_PASSWORD=app_password
 
 ENV COLLECTION_DB_HOST=clickhouse
-ENV COLLECTION_DB_PORT=9000
+ENV COLLECTION_DB_PORT=` + collectionDBPort(scanner) + `
+ENV COLLECTION_DB_PROTOCOL=` + clickHouseProtocol(scanner) + `
 ENV COLLECTION_DB_NAME=default
 ENV COLLECTION_DB_USER=default
 ENV COLLECTION_DB_PASSWORD=
@@ -685,7 +1190,8 @@ ENV APP_DB_USER=appuser
 ENV APP_DB_PASSWORD=app_password
 
 ENV COLLECTION_DB_HOST=clickhouse
-ENV COLLECTION_DB_PORT=9000
+ENV COLLECTION_DB_PORT=` + collectionDBPort(scanner) + `
+ENV COLLECTION_DB_PROTOCOL=` + clickHouseProtocol(scanner) + `
 ENV COLLECTION_DB_NAME=default
 ENV COLLECTION_DB_USER=default
 ENV COLLECTION_DB_PASSWORD=
@@ -725,7 +1231,7 @@ ENV APP_DB_USER=appuser
 ENV APP_DB_PASSWORD=app_password
 
 ENV COLLECTION_DB_HOST=clickhouse
-ENV COLLECTION_DB_PORT=9000
+ENV COLLECTION_DB_PORT=` + collectionDBPort(scanner) + `
 ENV COLLECTION_DB_NAME=default
 ENV COLLECTION_DB_USER=default
 ENV COLLECTION_DB_PASSWORD=
@@ -766,7 +1272,7 @@ ENV APP_DB_USER=appuser
 ENV APP_DB_PASSWORD=app_password
 
 ENV COLLECTION_DB_HOST=clickhouse
-ENV COLLECTION_DB_PORT=9000
+ENV COLLECTION_DB_PORT=` + collectionDBPort(scanner) + `
 ENV COLLECTION_DB_NAME=default
 ENV COLLECTION_DB_USER=default
 ENV COLLECTION_DB_PASSWORD=
@@ -807,7 +1313,7 @@ ENV APP_DB_USER=appuser
 ENV APP_DB_PASSWORD=app_password
 
 ENV COLLECTION_DB_HOST=clickhouse
-ENV COLLECTION_DB_PORT=9000
+ENV COLLECTION_DB_PORT=` + collectionDBPort(scanner) + `
 ENV COLLECTION_DB_NAME=default
 ENV COLLECTION_DB_USER=default
 ENV COLLECTION_DB_PASSWORD=
@@ -822,7 +1328,7 @@ func generateRequirements(scanner *ScannerCreationData) string {
 	return `# Core dependencies for Access Analyzer scanners
 pika>=1.3.0
 psycopg2-binary>=2.9.0
-clickhouse-driver>=0.2.6
+` + pythonClickHouseRequirement(scanner) + `
 requests>=2.31.0
 
 # Add your scanner-specific dependencies here
@@ -848,7 +1354,7 @@ import json
 import logging
 import pika
 import psycopg2
-from clickhouse_driver import Client
+%s
 from datetime import datetime
 
 # Configure logging
@@ -936,7 +1442,7 @@ class QueueScanner:
         
         self.collection_db_config = {
             'host': os.environ.get('COLLECTION_DB_HOST', 'clickhouse'),
-            'port': os.environ.get('COLLECTION_DB_PORT', '9000'),
+            'port': os.environ.get('COLLECTION_DB_PORT', '%s'),
             'database': os.environ.get('COLLECTION_DB_NAME', 'default'),
             'user': os.environ.get('COLLECTION_DB_USER', 'default'),
             'password': os.environ.get('COLLECTION_DB_PASSWORD', '')
@@ -970,83 +1476,151 @@ class QueueScanner:
         # TODO: Implement scanner startup logic
         pass
 
+def load_local_config(path):
+    """Load a scanner config file, expanding ${VAR} references"""
+    with open(path, 'r') as f:
+        return json.loads(os.path.expandvars(f.read()))
+
 if __name__ == "__main__":
-    scanner = QueueScanner()
-    scanner.run()
+    config_path = os.environ.get('SCANNER_CONFIG')
+    if config_path:
+        # Local mode (nwx aa scanner run-local --config-file): run one scan
+        # with the given config instead of waiting for queue jobs
+        scanner = %sScanner(load_local_config(config_path))
+        scanner.scan()
+    else:
+        scanner = QueueScanner()
+        scanner.run()
 `,
 		scanner.DisplayName,
 		scanner.Description,
+		pythonClickHouseImport(scanner),
 		toPascalCase(scanner.Name),
+		collectionDBPort(scanner),
 		scanner.DisplayName,
+		toPascalCase(scanner.Name),
 	)
 }
 
-// generateReadme generates the README.md file
+// generateReadme generates the README in the markup chosen with --readme-format
 func generateReadme(scanner *ScannerCreationData) string {
-	return fmt.Sprintf(`# %s Scanner
-
-%s
-
-## Getting Started
-
-This is a minimal scanner scaffolding for Access Analyzer. You'll need to implement the actual scanning logic.
-
-## Files Generated
-
-- `+"`scannerSpecification.json`"+` - Scanner configuration schema
-- `+"`scanner.py`"+` - Main scanner implementation (minimal scaffolding)
-- `+"`Dockerfile`"+` - Docker container configuration
-- `+"`requirements.txt`"+` - Python dependencies
-- `+"`config/config.example.json`"+` - Configuration example
-
-## Next Steps
-
-1. **Review the scanner specification** in `+"`scannerSpecification.json`"+`
-2. **Implement your scanning logic** in `+"`scanner.py`"+`
-3. **Add your specific dependencies** to `+"`requirements.txt`"+`
-4. **Update the configuration** in `+"`config/config.example.json`"+`
-5. **Test your implementation** with `+"`python scanner.py`"+`
-6. **Build and deploy** with `+"`docker build -t %s-scanner .`"+`
-
-## Documentation
-
-See the scanner framework documentation for detailed implementation guidance:
-- Connection handling
-- Database integration
-- Queue processing
-- Error handling
-- Testing
-
-## TODO
-
-- [ ] Implement connection logic in `+"`scanner.py`"+`
-- [ ] Add scanning logic for your data source
-- [ ] Implement result processing
-- [ ] Add error handling
-- [ ] Test with real data
-- [ ] Add logging and monitoring
-`,
-		scanner.DisplayName,
-		scanner.Description,
-		scanner.Name,
-	)
+	m := readmeMarkupFor(scanner.ReadmeFormat)
+	code, strong := m.code, m.strong
+	
+	var b strings.Builder
+	b.WriteString(m.heading(1, scanner.DisplayName+" Scanner"))
+	fmt.Fprintf(&b, "%s\n\n", scanner.Description)
+	
+	b.WriteString(m.heading(2, "Getting Started"))
+	b.WriteString("This is a minimal scanner scaffolding for Access Analyzer. You'll need to implement the actual scanning logic.\n\n")
+	templates := scannerTemplateSet(scanner)
+	fmt.Fprintf(&b, "Generated from template version %s (%s). Regenerate with %s to keep the same conventions.\n\n",
+		strong(templates.Version), templates.Description, code("--template-version "+templates.Version))
+	
+	b.WriteString(m.heading(2, "Files Generated"))
+	b.WriteString(m.list(false,
+		code("scannerSpecification.json")+" - Scanner configuration schema",
+		code("scanner.py")+" - Main scanner implementation (minimal scaffolding)",
+		code("Dockerfile")+" - Docker container configuration",
+		code("requirements.txt")+" - Python dependencies",
+		code("config/config.example.json")+" - Configuration example",
+	))
+	
+	b.WriteString(m.heading(2, "Collection Database"))
+	fmt.Fprintf(&b, "Scan results are written to ClickHouse using the %s protocol (default %s %s).\n\n",
+		strong(clickHouseProtocol(scanner)), code("COLLECTION_DB_PORT"), collectionDBPort(scanner))
+	b.WriteString(m.list(false,
+		strong("native")+" (port 9000) - ClickHouse's binary TCP protocol. Fastest option, but the port is often not exposed outside the cluster.",
+		strong("http")+" (port 8123) - ClickHouse's HTTP interface. Use this when only HTTP is reachable, e.g. behind a load balancer or proxy.",
+	))
+	fmt.Fprintf(&b, "To switch protocols, change %s in the %s and use a client that speaks the matching protocol\n", code("COLLECTION_DB_PORT"), code("Dockerfile"))
+	fmt.Fprintf(&b, "(Python: %s for native, %s for HTTP; Go: set %s, which is passed as %s in %s).\n", code("clickhouse-driver"), code("clickhouse-connect"), code("COLLECTION_DB_PROTOCOL"), code("Protocol"), code("clickhouse.Options"))
+	b.WriteString("The JavaScript, Java and C# clients only speak HTTP, so those scanners always use http.\n\n")
+	
+	b.WriteString(m.heading(2, "Next Steps"))
+	b.WriteString(m.list(true,
+		strong("Review the scanner specification")+" in "+code("scannerSpecification.json"),
+		strong("Implement your scanning logic")+" in "+code("scanner.py"),
+		strong("Add your specific dependencies")+" to "+code("requirements.txt"),
+		strong("Update the configuration")+" in "+code("config/config.example.json"),
+		strong("Test your implementation")+" with "+code("python scanner.py"),
+		strong("Build and deploy")+" with "+code(fmt.Sprintf("docker build -t %s-scanner .", scanner.Name)),
+	))
+	
+	b.WriteString(m.heading(2, "Documentation"))
+	b.WriteString("See the scanner framework documentation for detailed implementation guidance:\n\n")
+	b.WriteString(m.list(false,
+		"Connection handling",
+		"Database integration",
+		"Queue processing",
+		"Error handling",
+		"Testing",
+	))
+	
+	b.WriteString(m.heading(2, "TODO"))
+	b.WriteString(m.checklist(
+		"Implement connection logic in "+code("scanner.py"),
+		"Add scanning logic for your data source",
+		"Implement result processing",
+		"Add error handling",
+		"Test with real data",
+		"Add logging and monitoring",
+	))
+	
+	return strings.TrimSuffix(b.String(), "\n")
 }
 
 // generateConfigExample generates a minimal configuration example
 func generateConfigExample(scanner *ScannerCreationData) string {
-	config := map[string]interface{}{
-		"connectionConfig": map[string]interface{}{
+	data, _ := json.MarshalIndent(configExampleValues(scanner), "", "  ")
+	return string(data)
+}
+
+// configExampleValues returns the example configuration values by section
+func configExampleValues(scanner *ScannerCreationData) map[string]map[string]interface{} {
+	values := map[string]map[string]interface{}{
+		"connectionConfig": {
 			"host": "example.com",
 		},
-		"accessScanConfig": map[string]interface{}{
+		"accessScanConfig": {
 			"scanDepth": 10,
 		},
 	}
+	if kind, ok := scannerSourceKind(scanner); ok {
+		values["connectionConfig"]["host"] = kind.Host
+		values["connectionConfig"]["port"] = kind.Port
+	}
+	return values
+}
+
+// generateConfigEnvExample generates config.env.json, which has the same
+// fields as the configuration example but references environment variables
+// (e.g. "${HOST}") so it can be used as a template at runtime
+func generateConfigEnvExample(scanner *ScannerCreationData) string {
+	config := make(map[string]map[string]interface{})
+	for section, values := range configExampleValues(scanner) {
+		config[section] = make(map[string]interface{})
+		for key := range values {
+			config[section][key] = "${" + toEnvVarName(key) + "}"
+		}
+	}
 	
 	data, _ := json.MarshalIndent(config, "", "  ")
 	return string(data)
 }
 
+// toEnvVarName converts a config key such as "scanDepth" to SCAN_DEPTH
+func toEnvVarName(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
 // generateSourceType generates the source type definition
 func generateSourceType(scanner *ScannerCreationData) string {
 	sourceType := map[string]interface{}{
@@ -1059,12 +1633,32 @@ func generateSourceType(scanner *ScannerCreationData) string {
 			"$ref": "scannerSpecification.json",
 		},
 	}
+	if scanner.Maintainer != "" {
+		sourceType["maintainer"] = scanner.Maintainer
+	}
 	
 	data, _ := json.MarshalIndent(sourceType, "", "  ")
 	return string(data)
 }
 
 // Helper functions
+
+// valueOr returns value, or fallback when value is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// valuesOr returns values, or fallback when values is empty
+func valuesOr(values, fallback []string) []string {
+	if len(values) == 0 {
+		return fallback
+	}
+	return values
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -1074,6 +1668,141 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// httpOnlyClickHouseLanguages generate scanners whose ClickHouse client
+// only speaks HTTP: @clickhouse/client, clickhouse-jdbc and ClickHouse.Client
+var httpOnlyClickHouseLanguages = []string{"javascript", "java", "c#"}
+
+// clickHouseProtocol returns the ClickHouse protocol for the scanner: the one
+// chosen with --clickhouse-protocol, else http for languages whose client
+// only speaks HTTP and native otherwise
+func clickHouseProtocol(scanner *ScannerCreationData) string {
+	if scanner.ClickHouseProtocol != "" {
+		return scanner.ClickHouseProtocol
+	}
+	if contains(httpOnlyClickHouseLanguages, scanner.Language) {
+		return "http"
+	}
+	return "native"
+}
+
+// validateClickHouseProtocol checks the value of --clickhouse-protocol; empty
+// picks the language's default
+func validateClickHouseProtocol(protocol string) error {
+	if protocol != "" && protocol != "native" && protocol != "http" {
+		return fmt.Errorf("invalid ClickHouse protocol '%s' (expected native or http)", protocol)
+	}
+	return nil
+}
+
+// checkClickHouseLanguage rejects the native protocol for a language whose
+// ClickHouse client only speaks HTTP
+func checkClickHouseLanguage(protocol, language string) error {
+	if protocol == "native" && contains(httpOnlyClickHouseLanguages, language) {
+		return fmt.Errorf("the %s ClickHouse client only supports the http protocol; choose another language or use --clickhouse-protocol http", language)
+	}
+	return nil
+}
+
+// suggestedIcons are offered first when choosing a scanner icon
+var suggestedIcons = []string{"folder", "database", "cloud", "server", "lock", "file", "network"}
+
+// customIconOption lets the user type any icon from builtinIcons or a URL
+const customIconOption = "other (type your own)"
+
+// builtinIcons is the full icon set supported by the Access Analyzer UI
+var builtinIcons = []string{
+	"folder", "database", "cloud", "server", "lock", "file", "network",
+	"shield", "key", "user", "users", "mail", "globe", "archive", "box",
+	"terminal", "hard-drive", "share", "table", "document", "other",
+}
+
+// validateScannerIcon accepts a built-in icon name or an absolute http(s) URL
+func validateScannerIcon(icon string) error {
+	if strings.Contains(icon, "://") {
+		u, err := url.Parse(icon)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid icon URL '%s' (expected an http or https URL)", icon)
+		}
+		return nil
+	}
+	if !contains(builtinIcons, icon) {
+		return fmt.Errorf("unknown icon '%s' (expected a URL or one of: %s)", icon, strings.Join(builtinIcons, ", "))
+	}
+	return nil
+}
+
+// collectionDBPort returns the default COLLECTION_DB_PORT for the chosen ClickHouse protocol
+func collectionDBPort(scanner *ScannerCreationData) string {
+	if clickHouseProtocol(scanner) == "http" {
+		return "8123"
+	}
+	return "9000"
+}
+
+// pythonClickHouseImport returns the ClickHouse client import for the Python template
+func pythonClickHouseImport(scanner *ScannerCreationData) string {
+	if clickHouseProtocol(scanner) == "http" {
+		return "import clickhouse_connect"
+	}
+	return "from clickhouse_driver import Client"
+}
+
+// pythonClickHouseRequirement returns the ClickHouse client requirement for requirements.txt
+func pythonClickHouseRequirement(scanner *ScannerCreationData) string {
+	if clickHouseProtocol(scanner) == "http" {
+		return "clickhouse-connect>=0.6.0"
+	}
+	return "clickhouse-driver>=0.2.6"
+}
+
+// scannerNamePattern matches kebab-case scanner names such as 'my-scanner'
+var scannerNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)+$`)
+
+// validateScannerName checks that a scanner name is kebab-case and that the
+// spec, queue and table names derived from it are valid
+func validateScannerName(name string) error {
+	if !scannerNamePattern.MatchString(name) {
+		if hint := scannerNameHint(name); hint != "" {
+			return fmt.Errorf("scanner name should be kebab-case (e.g., 'my-scanner'): %s", hint)
+		}
+		return fmt.Errorf("scanner name should be kebab-case (e.g., 'my-scanner')")
+	}
+	if len(name) > maxScannerNameLength {
+		return fmt.Errorf("scanner name is %d characters long (at most %d, so queue names stay within RabbitMQ's limit)", len(name), maxScannerNameLength)
+	}
+	if problems := specNameProblems(toSpecName(name), "0.0.0", scanTypeOptions); len(problems) > 0 {
+		return fmt.Errorf("scanner name '%s' gives spec name %s: %s", name, toSpecName(name), strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// scannerNameHint explains why a name is not kebab-case, since it becomes
+// the spec name and the queue and table names
+func scannerNameHint(name string) string {
+	switch {
+	case name == "":
+		return ""
+	case name[0] >= '0' && name[0] <= '9':
+		return "it must start with a letter, as table names can't start with a digit"
+	case strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-"):
+		return "it can't start or end with '-'"
+	case strings.Contains(name, "--"):
+		return "'--' would become '__' in the spec name"
+	case strings.ContainsAny(name, "_ "):
+		return "separate words with '-' instead of '_' or spaces"
+	case strings.ToLower(name) != name:
+		return "use lowercase letters only"
+	case !strings.Contains(name, "-"):
+		return "use at least two words separated by '-'"
+	}
+	return "use only lowercase letters, digits and '-'"
+}
+
+// toSpecName converts a kebab-case scanner name to the upper snake case name used in the spec
+func toSpecName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
 func toPascalCase(s string) string {
 	words := strings.Split(s, "-")
 	result := ""
@@ -1289,7 +2018,7 @@ class QueueScanner {
         
         this.collectionDbConfig = {
             host: process.env.COLLECTION_DB_HOST || 'clickhouse',
-            port: process.env.COLLECTION_DB_PORT || '9000',
+            port: process.env.COLLECTION_DB_PORT || '%s',
             database: process.env.COLLECTION_DB_NAME || 'default',
             username: process.env.COLLECTION_DB_USER || 'default',
             password: process.env.COLLECTION_DB_PASSWORD || ''
@@ -1320,12 +2049,27 @@ class QueueScanner {
     }
 }
 
+// Load a scanner config file, expanding ${VAR} references
+function loadLocalConfig(path) {
+    const text = fs.readFileSync(path, 'utf8')
+        .replace(/\$\{(\w+)\}/g, (ref, name) => process.env[name] ?? ref);
+    return JSON.parse(text);
+}
+
 // Start the scanner
 if (require.main === module) {
-    const scanner = new QueueScanner();
-    scanner.run().catch(console.error);
+    const configPath = process.env.SCANNER_CONFIG;
+    if (configPath) {
+        // Local mode (nwx aa scanner run-local --config-file): run one scan
+        // with the given config instead of waiting for queue jobs
+        const scanner = new %sScanner(loadLocalConfig(configPath));
+        scanner.scan().catch(console.error);
+    } else {
+        const scanner = new QueueScanner();
+        scanner.run().catch(console.error);
+    }
 }
-`, scanner.DisplayName, scanner.Description, toPascalCase(scanner.Name), scanner.DisplayName)
+`, scanner.DisplayName, scanner.Description, toPascalCase(scanner.Name), collectionDBPort(scanner), scanner.DisplayName, toPascalCase(scanner.Name))
 }
 
 // generateScannerGo generates scanner.go for Go
@@ -1460,7 +2204,8 @@ func NewQueueScanner() *QueueScanner {
 		
 		collectionDbConfig: map[string]interface{}{
 			"host":     getEnvWithDefault("COLLECTION_DB_HOST", "clickhouse"),
-			"port":     getEnvWithDefault("COLLECTION_DB_PORT", "9000"),
+			"port":     getEnvWithDefault("COLLECTION_DB_PORT", "%s"),
+			"protocol": getEnvWithDefault("COLLECTION_DB_PROTOCOL", "%s"), // clickhouse.Native or clickhouse.HTTP in clickhouse.Options
 			"database": getEnvWithDefault("COLLECTION_DB_NAME", "default"),
 			"username": getEnvWithDefault("COLLECTION_DB_USER", "default"),
 			"password": getEnvWithDefault("COLLECTION_DB_PASSWORD", ""),
@@ -1519,7 +2264,7 @@ func main() {
 		log.Fatal("Scanner failed:", err)
 	}
 }
-`, scanner.DisplayName, scanner.Description, toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name))
+`, scanner.DisplayName, scanner.Description, toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name), toPascalCase(scanner.Name), collectionDBPort(scanner), clickHouseProtocol(scanner))
 }
 
 // generateScannerJava generates Scanner.java for Java
@@ -1648,7 +2393,7 @@ class QueueScanner {
         
         this.collectionDbConfig = new HashMap<>();
         collectionDbConfig.put("host", getEnvWithDefault("COLLECTION_DB_HOST", "clickhouse"));
-        collectionDbConfig.put("port", getEnvWithDefault("COLLECTION_DB_PORT", "9000"));
+        collectionDbConfig.put("port", getEnvWithDefault("COLLECTION_DB_PORT", "%s"));
         collectionDbConfig.put("database", getEnvWithDefault("COLLECTION_DB_NAME", "default"));
         collectionDbConfig.put("username", getEnvWithDefault("COLLECTION_DB_USER", "default"));
         collectionDbConfig.put("password", getEnvWithDefault("COLLECTION_DB_PASSWORD", ""));
@@ -1692,7 +2437,7 @@ class QueueScanner {
         }
     }
 }
-`, scanner.DisplayName, scanner.Description, toPascalCase(scanner.Name), toPascalCase(scanner.Name))
+`, scanner.DisplayName, scanner.Description, toPascalCase(scanner.Name), toPascalCase(scanner.Name), collectionDBPort(scanner))
 }
 
 // generateScannerCSharp generates Scanner.cs for C#
@@ -1833,7 +2578,7 @@ namespace AccessAnalyzer.Scanner
             _collectionDbConfig = new Dictionary<string, object>
             {
                 ["host"] = GetEnvWithDefault("COLLECTION_DB_HOST", "clickhouse"),
-                ["port"] = GetEnvWithDefault("COLLECTION_DB_PORT", "9000"),
+                ["port"] = GetEnvWithDefault("COLLECTION_DB_PORT", "%s"),
                 ["database"] = GetEnvWithDefault("COLLECTION_DB_NAME", "default"),
                 ["username"] = GetEnvWithDefault("COLLECTION_DB_USER", "default"),
                 ["password"] = GetEnvWithDefault("COLLECTION_DB_PASSWORD", "")
@@ -1888,7 +2633,7 @@ namespace AccessAnalyzer.Scanner
         }
     }
 }
-`, scanner.DisplayName, scanner.Description, toPascalCase(scanner.Name), toPascalCase(scanner.Name))
+`, scanner.DisplayName, scanner.Description, toPascalCase(scanner.Name), toPascalCase(scanner.Name), collectionDBPort(scanner))
 }
 
 // truncateString truncates a string to a maximum length
@@ -1902,12 +2647,39 @@ func truncateString(s string, maxLen int) string {
 func init() {
 	scannerCmd.Flags().BoolVar(&createFlag, "create", false, "Create a new scanner interactively")
 	
+	// Creation options are accepted by both 'scanner --create' and 'scanner create'
+	for _, c := range []*cobra.Command{scannerCmd, scannerCreateCmd} {
+		c.Flags().StringVar(&clickHouseProtocolFlag, "clickhouse-protocol", "", "ClickHouse protocol used by the generated scanner (native|http; default native, or http for languages whose client only speaks HTTP)")
+		c.Flags().StringVar(&iconFlag, "icon", "", "Scanner icon: a built-in icon name or an http(s) URL")
+		c.Flags().BoolVar(&envConfigFlag, "env-config", false, "Also generate config/config.env.json with ${ENV_VAR} references")
+		c.Flags().StringVar(&readmeFormatFlag, "readme-format", readmeMarkdown, "Markup of the generated README (md|rst|adoc)")
+		c.Flags().BoolVar(&summaryOnlyFlag, "summary-only", false, "Collect and validate the answers and print the summary without generating anything")
+		c.Flags().StringVar(&summaryFormatFlag, "summary-format", outputText, "Format of the --summary-only summary (text|json)")
+		c.Flags().StringVar(&ownerFlag, "owner", "", "Generate an ownership file naming this owner (e.g. @alice or alice@example.com)")
+		c.Flags().StringVar(&maintainerFlag, "maintainer", "", "Maintainer stored in the source type, an email address or a handle (e.g. @org/team)")
+		c.Flags().StringVar(&ownersFormatFlag, "owners-format", ownersCodeowners, "Format of the ownership file generated with --owner (codeowners|owners)")
+		c.Flags().BoolVar(&forceFlag, "force", false, "Overwrite existing files even when they are tracked by git")
+		c.Flags().StringVar(&outputDirFlag, "output-dir", "", "Directory to generate the scanner in; {name} is replaced by the scanner name (defaults to the "+defaultOutputDirKey+" key, then ./<name>)")
+		c.Flags().StringVar(&namePrefixFlag, "name-prefix", "", "Prefix prepended to the scanner name, e.g. 'dev-alice-' (defaults to the "+namePrefixKey+" key)")
+		c.Flags().StringVar(&templateVersionFlag, "template-version", latestTemplateVersion, "Version of the bundled templates to generate, to match an older scanner framework (v1|v2)")
+		addServiceEnvFlags(c)
+		c.Flags().StringArrayVar(&envFlag, "env", nil, "Extra runtime environment variable for the generated Dockerfile as KEY=VALUE (repeatable)")
+		c.Flags().StringVar(&sourceKindFlag, "source-kind", "", "Kind of data source seeding the default port and authentication methods (smb|ldap|ldaps|sqlserver|postgresql|mysql|oracle|sharepoint|rest)")
+		c.Flags().BoolVar(&quietFlag, "quiet", false, "Do not list each file as it is generated or the output schema in the summary")
+		c.Flags().BoolVar(&reorderColumnsFlag, "reorder-columns", false, "Choose the order of the output schema columns after selecting the scan types")
+		c.Flags().BoolVar(&columnsPreviewFlag, "columns", true, "Show the output schema columns in the summary")
+		c.Flags().StringVar(&generationFormatFlag, "generation-format", outputText, "Format of the generation report: text, or json with the time taken by each file")
+		c.Flags().BoolVar(&refreshCacheFlag, "refresh-cache", false, "Save the fetched scanners to the local cache even if "+sourceTypesCacheKey+" is off")
+	}
+	
 	// Handle --create flag
-	scannerCmd.PreRun = func(cmd *cobra.Command, args []string) {
+	scannerCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
 		if createFlag {
-			scannerCreateCmd.Run(cmd, args)
+			return scannerCreateCmd.RunE(cmd, args)
 		}
+		return nil
 	}
 	
+	scannerCmd.AddCommand(scannerCreateCmd)
 	accessAnalyzerCmd.AddCommand(scannerCmd)
 }
\ No newline at end of file
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var scannerAddAuthCmd = &cobra.Command{
+	Use:   "add-auth <method> [dir]",
+	Short: "Add an authentication method to a scanner specification",
+	Long: `Add the connection config fields for an authentication method to an
+existing scannerSpecification.json. Fields that already exist are left
+untouched. The method is a name such as "api-key" or "Username/Password".
+The directory defaults to the current directory.
+
+Methods: ` + strings.Join(authMethodSlugs(), ", "),
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 1 {
+			dir = args[1]
+		}
+
+		method, ok := lookupAuthMethod(args[0])
+		if !ok {
+			return fmt.Errorf("unknown auth method '%s' (expected one of: %s)", args[0], strings.Join(authMethodSlugs(), ", "))
+		}
+
+		added, err := addAuthMethod(dir, method)
+		if err != nil {
+			return err
+		}
+		if len(added) == 0 {
+			fmt.Printf(glyphs("✅ %s fields already present in %s, nothing to do\n"), method, specFileName)
+			return nil
+		}
+
+		fmt.Printf(glyphs("✅ Added %s to %s\n"), method, filepath.Join(dir, specFileName))
+		for _, key := range added {
+			fmt.Printf("  + connectionConfig.%s\n", key)
+		}
+		return nil
+	},
+}
+
+// authConfigItems maps each authentication method to the connection config
+// fields it needs. "Custom" has no predefined fields.
+var authConfigItems = map[string][]SpecConfigItem{
+	"Username/Password": {
+		{Key: "username", Label: "Username", Type: "text", Required: true, Description: "User name used to connect"},
+		{Key: "password", Label: "Password", Type: "password", Required: true, Description: "Password for the user"},
+	},
+	"API Key": {
+		{Key: "apiKey", Label: "API Key", Type: "password", Required: true, Description: "API key used to authenticate"},
+	},
+	"OAuth2": {
+		{Key: "clientId", Label: "Client ID", Type: "text", Required: true, Description: "OAuth2 client identifier"},
+		{Key: "clientSecret", Label: "Client Secret", Type: "password", Required: true, Description: "OAuth2 client secret"},
+		{Key: "tokenUrl", Label: "Token URL", Type: "text", Required: true, Placeholder: "https://login.example.com/oauth2/token", Description: "OAuth2 token endpoint"},
+		{Key: "scope", Label: "Scope", Type: "text", Required: false, Description: "Space-separated OAuth2 scopes"},
+	},
+	"Certificate": {
+		{Key: "certificate", Label: "Certificate", Type: "textarea", Required: true, Description: "PEM-encoded client certificate"},
+		{Key: "privateKey", Label: "Private Key", Type: "password", Required: true, Description: "PEM-encoded private key"},
+		{Key: "keyPassphrase", Label: "Key Passphrase", Type: "password", Required: false, Description: "Passphrase for the private key"},
+	},
+	"Service Account": {
+		{Key: "serviceAccountKey", Label: "Service Account Key", Type: "textarea", Required: true, Description: "Service account key (JSON)"},
+	},
+	"Windows Authentication": {
+		{Key: "domain", Label: "Domain", Type: "text", Required: true, Description: "Windows domain"},
+		{Key: "username", Label: "Username", Type: "text", Required: true, Description: "User name used to connect"},
+		{Key: "password", Label: "Password", Type: "password", Required: true, Description: "Password for the user"},
+	},
+}
+
+// authMethodSlug turns "Username/Password" into "username-password"
+func authMethodSlug(method string) string {
+	slug := strings.ToLower(method)
+	slug = strings.NewReplacer("/", "-", " ", "-").Replace(slug)
+	return slug
+}
+
+// authMethodSlugs returns the methods accepted by add-auth
+func authMethodSlugs() []string {
+	var slugs []string
+	for _, method := range authMethodOptions {
+		if _, ok := authConfigItems[method]; ok {
+			slugs = append(slugs, authMethodSlug(method))
+		}
+	}
+	return slugs
+}
+
+// lookupAuthMethod resolves a method name or slug to its display name
+func lookupAuthMethod(name string) (string, bool) {
+	for method := range authConfigItems {
+		if strings.EqualFold(method, name) || authMethodSlug(method) == strings.ToLower(name) {
+			return method, true
+		}
+	}
+	return "", false
+}
+
+// addAuthMethod appends the missing connection config fields for method to
+// the spec in dir and returns the keys that were added. The spec is edited
+// in place, so key order and fields unknown to this version of the CLI are
+// kept.
+func addAuthMethod(dir, method string) ([]string, error) {
+	path := filepath.Join(dir, specFileName)
+	data, err := readSpecFile(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeOrderedJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scanner specification: %w", err)
+	}
+	spec, ok := decoded.(*orderedObject)
+	if !ok {
+		return nil, fmt.Errorf("invalid scanner specification: expected a JSON object")
+	}
+
+	connection := orderedField(spec, "connectionConfig")
+	if connection == nil {
+		connection = &orderedObject{values: make(map[string]interface{})}
+		spec.set("connectionConfig", connection)
+	}
+	items, _ := orderedValue(connection, "items").([]interface{})
+
+	existing := make(map[string]bool)
+	for _, item := range items {
+		if m, ok := item.(*orderedObject); ok {
+			if key, ok := orderedValue(m, "key").(string); ok {
+				existing[key] = true
+			}
+		}
+	}
+
+	var added []string
+	for _, item := range authConfigItems[method] {
+		if existing[item.Key] {
+			continue
+		}
+		items = append(items, item)
+		added = append(added, item.Key)
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	connection.set("items", items)
+
+	out, err := marshalOrderedJSON(spec)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.HasSuffix(data, []byte("\n")) {
+		out = append(out, '\n')
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(path, out, info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return added, nil
+}
+
+func init() {
+	scannerCmd.AddCommand(scannerAddAuthCmd)
+}
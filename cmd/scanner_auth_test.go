@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// connectionKeys returns the connection config keys of a spec in order
+func connectionKeys(t *testing.T, spec *ScannerSpec) []string {
+	t.Helper()
+	if spec.ConnectionConfig == nil {
+		t.Fatal("spec has no connectionConfig")
+	}
+	var keys []string
+	for _, item := range spec.ConnectionConfig.Items {
+		keys = append(keys, item.Key)
+	}
+	return keys
+}
+
+func TestGeneratedSpecIncludesAuthFields(t *testing.T) {
+	tests := []struct {
+		methods []string
+		want    []string
+	}{
+		{nil, []string{"host"}},
+		{[]string{"Custom"}, []string{"host"}},
+		{[]string{"API Key"}, []string{"host", "apiKey"}},
+		{[]string{"Username/Password", "Windows Authentication"}, []string{"host", "username", "password", "domain"}},
+	}
+	for _, tt := range tests {
+		scanner := &ScannerCreationData{
+			Name:               "my-scanner",
+			Version:            "1.0.0",
+			Language:           "python",
+			SupportedScanTypes: []string{"access"},
+			AuthMethods:        tt.methods,
+		}
+		spec, err := parseSpec([]byte(generateScannerSpecification(scanner)))
+		if err != nil {
+			t.Fatalf("generated spec for %v does not parse: %v", tt.methods, err)
+		}
+		if got := connectionKeys(t, spec); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("connectionConfig keys for %v = %v, want %v", tt.methods, got, tt.want)
+		}
+		for _, finding := range validateSpec(spec) {
+			if finding.Severity == severityError {
+				t.Errorf("generated spec for %v: %s: %s", tt.methods, finding.Field, finding.Message)
+			}
+		}
+	}
+}
+
+func TestAddAuthMethod(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, specFileName)
+	spec := `{
+  "name": "MY_SCANNER",
+  "version": "1.0.0",
+  "connectionConfig": {
+    "items": [
+      {"key": "host", "label": "Host", "type": "text", "required": true},
+      {"key": "username", "label": "User", "type": "text", "required": true}
+    ]
+  }
+}`
+	if err := os.WriteFile(path, []byte(spec), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := addAuthMethod(dir, "Username/Password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(added, []string{"password"}) {
+		t.Errorf("added = %v, want [password]", added)
+	}
+	loaded, err := LoadSpec(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := connectionKeys(t, loaded); !reflect.DeepEqual(got, []string{"host", "username", "password"}) {
+		t.Errorf("connectionConfig keys = %v", got)
+	}
+	if loaded.ConnectionConfig.Items[1].Label != "User" {
+		t.Errorf("existing username field was replaced: %+v", loaded.ConnectionConfig.Items[1])
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, version, connection := strings.Index(string(data), `"name"`), strings.Index(string(data), `"version"`), strings.Index(string(data), `"connectionConfig"`)
+	if !(name < version && version < connection) {
+		t.Errorf("add-auth reordered the spec's keys:\n%s", data)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("spec mode after add-auth = %v, want 0600", info.Mode().Perm())
+	}
+
+	added, err = addAuthMethod(dir, "Username/Password")
+	if err != nil || len(added) != 0 {
+		t.Errorf("adding the method again = %v, %v, want nothing added", added, err)
+	}
+}
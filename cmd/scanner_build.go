@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	buildTagFlag  string
+	buildPushFlag bool
+)
+
+var scannerBuildCmd = &cobra.Command{
+	Use:   "build [dir]",
+	Short: "Build a scanner's Docker image",
+	Long: `Build the Docker image of a scanner directory (defaults to the current
+directory) with 'docker build', streaming its output. The image is tagged
+access-analyzer/<name>-scanner:<version> from the name and version in
+scannerSpecification.json unless --tag is given. With --push the image is
+pushed after a successful build.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		spec, err := LoadSpec(dir)
+		if err != nil {
+			return err
+		}
+
+		docker, err := exec.LookPath("docker")
+		if err != nil {
+			return fmt.Errorf("docker is not installed or not on PATH; install Docker to build scanner images")
+		}
+
+		tag := buildTagFlag
+		if tag == "" {
+			tag = scannerImageTag(spec)
+		}
+
+		fmt.Printf(glyphs("🔧 Building %s from %s\n"), tag, dir)
+		if err := runDocker(cmd.Context(), docker, "build", "-t", tag, dir); err != nil {
+			return fmt.Errorf("docker build failed: %w", err)
+		}
+		fmt.Printf(glyphs("✅ Built %s\n"), tag)
+
+		if buildPushFlag {
+			fmt.Printf(glyphs("🔧 Pushing %s\n"), tag)
+			if err := runDocker(cmd.Context(), docker, "push", tag); err != nil {
+				return fmt.Errorf("docker push failed: %w", err)
+			}
+			fmt.Printf(glyphs("✅ Pushed %s\n"), tag)
+		}
+		return nil
+	},
+}
+
+// scannerImageTag returns the default image tag for a scanner spec
+func scannerImageTag(spec *ScannerSpec) string {
+	return fmt.Sprintf("access-analyzer/%s-scanner:%s", scannerNameFromSpec(spec.Name), spec.Version)
+}
+
+// scannerNameFromSpec turns a spec name such as MY_SCANNER back into the
+// kebab-case scanner name it was generated from
+func scannerNameFromSpec(specName string) string {
+	return strings.ToLower(strings.ReplaceAll(specName, "_", "-"))
+}
+
+// runDocker runs docker with args, streaming its output
+func runDocker(ctx context.Context, docker string, args ...string) error {
+	c := exec.CommandContext(ctx, docker, args...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+func init() {
+	scannerBuildCmd.Flags().StringVar(&buildTagFlag, "tag", "", "Image tag (default access-analyzer/<name>-scanner:<version>)")
+	scannerBuildCmd.Flags().BoolVar(&buildPushFlag, "push", false, "Push the image after building it")
+
+	scannerCmd.AddCommand(scannerBuildCmd)
+}
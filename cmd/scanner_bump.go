@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var bumpOutputFlag string
+
+var scannerBumpVersionCmd = &cobra.Command{
+	Use:   "bump-version <major|minor|patch|version> [dir]",
+	Short: "Bump a scanner's version",
+	Long: `Update the scanner version in scannerSpecification.json and the generated
+project files (package.json, pom.xml, Scanner.csproj). The directory
+defaults to the current directory. The before and after of each rewritten
+line is shown.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(bumpOutputFlag, outputText, outputJSON); err != nil {
+			return err
+		}
+
+		dir := "."
+		if len(args) > 1 {
+			dir = args[1]
+		}
+
+		spec, err := LoadSpec(dir)
+		if err != nil {
+			return err
+		}
+		newVersion, err := nextVersion(spec.Version, args[0])
+		if err != nil {
+			return err
+		}
+
+		edits, err := bumpVersion(dir, spec.Version, newVersion)
+		if err != nil {
+			return err
+		}
+
+		if bumpOutputFlag == outputJSON {
+			return printJSON(edits)
+		}
+
+		fmt.Printf(glyphs("✅ Bumped version %s → %s\n"), spec.Version, newVersion)
+		fmt.Println()
+		for _, e := range edits {
+			fmt.Printf("%s:%d\n", e.File, e.Line)
+			fmt.Println(diffRemoveStyle.Render("- " + e.Old))
+			fmt.Println(diffAddStyle.Render("+ " + e.New))
+		}
+		fmt.Println()
+		fmt.Println(glyphs("⚠️  Queue and table names include the version. Register the new version"))
+		fmt.Println("   before deploying it.")
+		return nil
+	},
+}
+
+// versionEdit is a single rewritten line
+type versionEdit struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Old  string `json:"old"`
+	New  string `json:"new"`
+}
+
+// versionLocations are the files that carry the scanner version and a
+// pattern for the line holding it. Only the first match in each file is
+// rewritten so dependency versions are left alone.
+var versionLocations = []struct {
+	file    string
+	pattern string
+}{
+	{specFileName, `"version"\s*:\s*"%s"`},
+	{"package.json", `"version"\s*:\s*"%s"`},
+	{"pom.xml", `<version>%s</version>`},
+	{"Scanner.csproj", `<AssemblyVersion>%s</AssemblyVersion>`},
+}
+
+// nextVersion applies a bump ("major", "minor", "patch") or an explicit
+// version to current
+func nextVersion(current, bump string) (string, error) {
+	if semverPattern.MatchString(bump) {
+		if bump == current {
+			return "", fmt.Errorf("version is already %s", current)
+		}
+		return bump, nil
+	}
+
+	if !semverPattern.MatchString(current) {
+		return "", fmt.Errorf("current version '%s' is not a semantic version", current)
+	}
+	parts := strings.Split(current, ".")
+	major, _ := strconv.Atoi(parts[0])
+	minor, _ := strconv.Atoi(parts[1])
+	patch, _ := strconv.Atoi(parts[2])
+
+	switch bump {
+	case "major":
+		return fmt.Sprintf("%d.0.0", major+1), nil
+	case "minor":
+		return fmt.Sprintf("%d.%d.0", major, minor+1), nil
+	case "patch":
+		return fmt.Sprintf("%d.%d.%d", major, minor, patch+1), nil
+	}
+	return "", fmt.Errorf("invalid version bump '%s' (expected major, minor, patch or a version like 1.2.3)", bump)
+}
+
+// bumpVersion rewrites oldVersion to newVersion in the known version
+// locations under dir. Every edit is computed before anything is written,
+// and if a file cannot be written the others are restored, so the files
+// never disagree on the version.
+func bumpVersion(dir, oldVersion, newVersion string) ([]versionEdit, error) {
+	edits := []versionEdit{}
+	var files []GeneratedFile
+	for _, loc := range versionLocations {
+		path := filepath.Join(dir, loc.file)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		pattern := regexp.MustCompile(fmt.Sprintf(loc.pattern, regexp.QuoteMeta(oldVersion)))
+		match := pattern.FindIndex(data)
+		if match == nil {
+			continue
+		}
+
+		replacement := strings.Replace(string(data[match[0]:match[1]]), oldVersion, newVersion, 1)
+		content := string(data[:match[0]]) + replacement + string(data[match[1]:])
+
+		lineStart := strings.LastIndex(string(data[:match[0]]), "\n") + 1
+		lineEnd := strings.Index(string(data[match[1]:]), "\n")
+		if lineEnd < 0 {
+			lineEnd = len(data) - match[1]
+		}
+		oldLine := string(data[lineStart : match[1]+lineEnd])
+		edits = append(edits, versionEdit{
+			File: loc.file,
+			Line: strings.Count(string(data[:match[0]]), "\n") + 1,
+			Old:  strings.TrimSpace(oldLine),
+			New:  strings.TrimSpace(strings.Replace(oldLine, oldVersion, newVersion, 1)),
+		})
+
+		files = append(files, GeneratedFile{Name: loc.file, Bytes: []byte(content)})
+	}
+
+	if len(edits) == 0 {
+		return nil, fmt.Errorf("no occurrences of version %s found", oldVersion)
+	}
+	if err := writeFilesWithRollback(dir, files, func(GeneratedFile, time.Duration) {}); err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
+
+func init() {
+	scannerBumpVersionCmd.Flags().StringVarP(&bumpOutputFlag, "output", "o", outputText, "Output format (text|json)")
+
+	scannerCmd.AddCommand(scannerBumpVersionCmd)
+}
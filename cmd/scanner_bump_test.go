@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestBumpVersion(t *testing.T) {
+	dir := writeTestScanner(t, "my-scanner", "javascript")
+
+	edits, err := bumpVersion(dir, "1.0.0", "1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edits) != 2 || edits[0].File != specFileName || edits[1].File != "package.json" {
+		t.Fatalf("edits = %+v, want the spec and package.json", edits)
+	}
+	for _, e := range edits {
+		if !strings.Contains(e.Old, "1.0.0") || !strings.Contains(e.New, "1.1.0") {
+			t.Errorf("%s:%d: %q -> %q", e.File, e.Line, e.Old, e.New)
+		}
+	}
+	spec, err := LoadSpec(dir)
+	if err != nil || spec.Version != "1.1.0" {
+		t.Errorf("spec version after the bump = %v, %v", spec, err)
+	}
+
+	if _, err := bumpVersion(dir, "9.9.9", "10.0.0"); err == nil {
+		t.Error("bumpVersion() of a version that is not there = nil, want an error")
+	}
+}
+
+func TestBumpVersionRollsBackWhenAWriteFails(t *testing.T) {
+	dir := writeTestScanner(t, "my-scanner", "javascript")
+	// package.json is written second and is too large for the limit
+	writeTestFile(t, filepath.Join(dir, "package.json"), `{"version": "1.0.0", "description": "`+strings.Repeat("x", testFileSizeLimit)+`"}`)
+	before := readTestTree(t, dir)
+
+	var err error
+	withFileSizeLimit(t, func() {
+		_, err = bumpVersion(dir, "1.0.0", "1.1.0")
+	})
+	if !errors.Is(err, syscall.EFBIG) {
+		t.Fatalf("bumpVersion() = %v, want the failed write", err)
+	}
+
+	after := readTestTree(t, dir)
+	for file, content := range before {
+		if after[file] != content {
+			t.Errorf("%s was left changed after the failed bump", file)
+		}
+	}
+	if len(after) != len(before) {
+		t.Errorf("failed bump left files behind: %d files, want %d", len(after), len(before))
+	}
+}
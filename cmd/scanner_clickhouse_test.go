@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClickHouseProtocolDefaults(t *testing.T) {
+	tests := []struct {
+		language string
+		flag     string
+		want     string
+		port     string
+	}{
+		{"python", "", "native", "9000"},
+		{"go", "", "native", "9000"},
+		{"javascript", "", "http", "8123"},
+		{"java", "", "http", "8123"},
+		{"c#", "", "http", "8123"},
+		{"python", "http", "http", "8123"},
+		{"go", "http", "http", "8123"},
+	}
+	for _, tt := range tests {
+		scanner := &ScannerCreationData{Language: tt.language, ClickHouseProtocol: tt.flag}
+		if got := clickHouseProtocol(scanner); got != tt.want {
+			t.Errorf("clickHouseProtocol(%s, %q) = %s, want %s", tt.language, tt.flag, got, tt.want)
+		}
+		if got := collectionDBPort(scanner); got != tt.port {
+			t.Errorf("collectionDBPort(%s, %q) = %s, want %s", tt.language, tt.flag, got, tt.port)
+		}
+	}
+}
+
+func TestCheckClickHouseLanguage(t *testing.T) {
+	for _, language := range languageOptions {
+		err := checkClickHouseLanguage("native", language)
+		httpOnly := contains(httpOnlyClickHouseLanguages, language)
+		if httpOnly != (err != nil) {
+			t.Errorf("checkClickHouseLanguage(native, %s) = %v", language, err)
+		}
+		if err := checkClickHouseLanguage("http", language); err != nil {
+			t.Errorf("checkClickHouseLanguage(http, %s) = %v", language, err)
+		}
+		if err := checkClickHouseLanguage("", language); err != nil {
+			t.Errorf("checkClickHouseLanguage(\"\", %s) = %v", language, err)
+		}
+	}
+}
+
+func TestGoScannerPassesProtocol(t *testing.T) {
+	for _, protocol := range []string{"native", "http"} {
+		scanner := &ScannerCreationData{Name: "my-scanner", Language: "go", ClickHouseProtocol: protocol}
+		code := generateScannerGo(scanner)
+		if !strings.Contains(code, `getEnvWithDefault("COLLECTION_DB_PROTOCOL", "`+protocol+`")`) {
+			t.Errorf("Go scanner does not default COLLECTION_DB_PROTOCOL to %s", protocol)
+		}
+		if !strings.Contains(generateDockerfile(scanner), "ENV COLLECTION_DB_PROTOCOL="+protocol) {
+			t.Errorf("Go Dockerfile does not set COLLECTION_DB_PROTOCOL=%s", protocol)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var cloneVersionFlag string
+
+var scannerCloneCmd = &cobra.Command{
+	Use:   "clone <dir> <new-name>",
+	Short: "Create a new scanner from an existing one",
+	Long: `Copy the scanner in <dir> to a sibling directory named <new-name> and
+rename the copy the way 'nwx aa scanner rename' does: the spec name, the
+source-type file and image, package/module names and class names. Queue
+and table names follow from the new name.
+
+The copy starts at version 1.0.0 unless --version is given. Build output
+and dependency directories (node_modules, target, bin, ...) and .git are
+not copied.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srcDir, newName := args[0], args[1]
+
+		if err := validateScannerName(newName); err != nil {
+			return err
+		}
+		if !semverPattern.MatchString(cloneVersionFlag) {
+			return fmt.Errorf("invalid --version '%s' (expected a version like 1.0.0)", cloneVersionFlag)
+		}
+
+		spec, err := LoadSpec(srcDir)
+		if err != nil {
+			return err
+		}
+		oldName := scannerNameFromSpec(spec.Name)
+		if oldName == newName {
+			return fmt.Errorf("new name is the same as the name of the scanner in %s", srcDir)
+		}
+
+		absSrc, err := filepath.Abs(srcDir)
+		if err != nil {
+			return err
+		}
+		newDir := filepath.Join(filepath.Dir(absSrc), newName)
+		if _, err := os.Stat(newDir); err == nil {
+			return fmt.Errorf("cannot clone to %s: it already exists", newDir)
+		}
+
+		if err := checkScannerNameAvailable(cmd.Context(), newName); err != nil {
+			return err
+		}
+
+		result, edits, err := cloneScanner(absSrc, newDir, oldName, newName, spec.Version, cloneVersionFlag)
+		if err != nil {
+			return err
+		}
+		printCloneSummary(result, edits, oldName, newName, spec.Version, cloneVersionFlag)
+		return nil
+	},
+}
+
+// cloneScanner copies srcDir to newDir and renames and re-versions the
+// copy. The copy is removed again if any step fails.
+func cloneScanner(srcDir, newDir, oldName, newName, oldVersion, newVersion string) (result *renameResult, edits []versionEdit, err error) {
+	defer func() {
+		if err != nil {
+			os.RemoveAll(newDir)
+		}
+	}()
+
+	if err := copyScannerDir(srcDir, newDir); err != nil {
+		return nil, nil, err
+	}
+	if result, err = renameScanner(newDir, oldName, newName); err != nil {
+		return nil, nil, err
+	}
+	if oldVersion != newVersion {
+		if edits, err = bumpVersion(newDir, oldVersion, newVersion); err != nil {
+			return nil, nil, err
+		}
+	}
+	return result, edits, nil
+}
+
+// copyScannerDir copies the regular files under src to dst, keeping their
+// permissions and skipping the directories a rename skips
+func copyScannerDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != src && skipRenameDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, info.Mode().Perm())
+	})
+}
+
+// printCloneSummary prints the changes made to a cloned scanner
+func printCloneSummary(result *renameResult, edits []versionEdit, oldName, newName, oldVersion, newVersion string) {
+	fmt.Printf(glyphs("✅ Cloned scanner '%s' to '%s'\n"), oldName, newName)
+	fmt.Println()
+	printRenameChanges(result)
+	if len(edits) > 0 {
+		fmt.Printf(glyphs("  🏷️  Version: %s → %s (%d file(s))\n"), oldVersion, newVersion, len(edits))
+	}
+
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  cd %s\n", result.Dir)
+	fmt.Println("  nwx aa scanner validate")
+}
+
+func init() {
+	scannerCloneCmd.Flags().StringVar(&cloneVersionFlag, "version", "1.0.0", "Version of the new scanner")
+
+	scannerCmd.AddCommand(scannerCloneCmd)
+}
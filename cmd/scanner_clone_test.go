@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCloneScannerLeavesUnrelatedIdentifiers(t *testing.T) {
+	srcDir := writeTestScanner(t, "read-file", "go")
+	writeTestFile(t, filepath.Join(srcDir, "helpers.go"), unrelatedIdentifiers)
+	source := readTestTree(t, srcDir)
+	newDir := filepath.Join(t.TempDir(), "other-scanner")
+
+	if _, _, err := cloneScanner(srcDir, newDir, "read-file", "other-scanner", "1.0.0", "2.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	files := readTestTree(t, newDir)
+
+	if got := files["helpers.go"]; got != unrelatedIdentifiers {
+		t.Errorf("helpers.go after the clone:\n%s", got)
+	}
+	if !strings.Contains(files["scanner.go"], `ioutil.ReadFile("scannerSpecification.json")`) {
+		t.Error("scanner.go: ioutil.ReadFile was renamed")
+	}
+	for file, want := range map[string][]string{
+		"scanner.go":                     {"type OtherScannerScanner struct", "func NewOtherScannerScanner("},
+		specFileName:                     {`"name": "OTHER_SCANNER"`, `"version": "2.0.0"`},
+		"other-scanner-source-type.json": {"access-analyzer/other-scanner-scanner:latest"},
+	} {
+		for _, w := range want {
+			if !strings.Contains(files[file], w) {
+				t.Errorf("%s does not contain %q", file, w)
+			}
+		}
+	}
+
+	// The source scanner is not touched
+	after := readTestTree(t, srcDir)
+	for file, content := range source {
+		if after[file] != content {
+			t.Errorf("source %s was changed by the clone", file)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// reorderColumnsFlag adds the column reordering step to creation
+// (--reorder-columns)
+var reorderColumnsFlag bool
+
+// columnOrderDone is the prompt option that ends reordering
+const columnOrderDone = "Done"
+
+// collectColumnOrder lets the user move the output columns of each scan
+// type up and down, storing the result in scanner.ColumnOrder. The order
+// is kept in the specification and the ClickHouse DDL; primary keys may be
+// placed anywhere and still form the sorting key in column order.
+func collectColumnOrder(scanner *ScannerCreationData) error {
+	if scanner.ColumnOrder == nil {
+		scanner.ColumnOrder = map[string][]string{}
+	}
+	columns := scannerOutputColumns(scanner)
+
+	for _, scanType := range scanTypeOptions {
+		cols, ok := columns[scanType]
+		if !ok {
+			continue
+		}
+		names := make([]string, len(cols))
+		for i, col := range cols {
+			names[i] = col.Name
+		}
+
+		fmt.Printf("Output columns for %s: move a column up or down, or choose %s\n", scanType, columnOrderDone)
+		for {
+			var picked string
+			if err := askOne(&survey.Select{
+				Message: "Column to move:",
+				Options: append(append([]string{}, names...), columnOrderDone),
+				Default: columnOrderDone,
+			}, &picked); err != nil {
+				return err
+			}
+			if picked == columnOrderDone {
+				break
+			}
+
+			var direction string
+			if err := askOne(&survey.Select{
+				Message: fmt.Sprintf("Move %s:", picked),
+				Options: []string{"Up", "Down"},
+			}, &direction); err != nil {
+				return err
+			}
+			names = moveColumn(names, picked, direction == "Up")
+			fmt.Printf("  Order: %v\n", names)
+		}
+		scanner.ColumnOrder[scanType] = names
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// moveColumn moves name one place up or down in names
+func moveColumn(names []string, name string, up bool) []string {
+	for i, n := range names {
+		if n != name {
+			continue
+		}
+		j := i + 1
+		if up {
+			j = i - 1
+		}
+		if j >= 0 && j < len(names) {
+			names[i], names[j] = names[j], names[i]
+		}
+		break
+	}
+	return names
+}
+
+// orderColumns sorts column definitions by the names in order. Columns not
+// in order follow in their original order.
+func orderColumns(order []string, columns []map[string]interface{}) []map[string]interface{} {
+	if len(order) == 0 {
+		return columns
+	}
+	ordered := make([]map[string]interface{}, 0, len(columns))
+	used := make(map[int]bool, len(columns))
+	for _, name := range order {
+		for i, col := range columns {
+			if !used[i] && col["name"] == name {
+				ordered = append(ordered, col)
+				used[i] = true
+			}
+		}
+	}
+	for i, col := range columns {
+		if !used[i] {
+			ordered = append(ordered, col)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumnOrderReachesSpecAndDDL(t *testing.T) {
+	scanner := &ScannerCreationData{
+		Name:               "my-scanner",
+		DisplayName:        "My Scanner",
+		Version:            "1.0.0",
+		Language:           "python",
+		SupportedScanTypes: []string{"access", "sensitive_data"},
+		ColumnOrder: map[string][]string{
+			// Unlisted columns follow in their original order; unknown
+			// names are ignored
+			"access":         {"scan_timestamp", "resource_id", "no_such_column"},
+			"sensitive_data": {"match_id", "scan_timestamp", "scan_id"},
+		},
+	}
+	spec, err := parseSpec([]byte(generateScannerSpecification(scanner)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantColumns := map[string][]string{
+		"access":        {"scan_timestamp", "resource_id", "scan_id"},
+		"sensitiveData": {"match_id", "scan_timestamp", "scan_id"},
+	}
+	for key, want := range wantColumns {
+		var got []string
+		for _, col := range spec.OutputSchema[key].Columns {
+			got = append(got, col.Name)
+		}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("spec %s columns = %v, want %v", key, got, want)
+		}
+	}
+
+	var ddl strings.Builder
+	writeClickHouseDDL(&ddl, spec, specClickHouseTables(spec, nil))
+	statements := strings.Split(ddl.String(), "CREATE TABLE")
+	if len(statements) != 3 {
+		t.Fatalf("DDL has %d statements, want 2:\n%s", len(statements)-1, ddl.String())
+	}
+	for i, key := range []string{"access", "sensitiveData"} {
+		statement := statements[i+1]
+		last := -1
+		for _, name := range wantColumns[key] {
+			at := strings.Index(statement, "\n    `"+name+"` ")
+			if at < 0 || at < last {
+				t.Errorf("%s DDL does not define the columns in the order %v:\n%s", key, wantColumns[key], statement)
+				break
+			}
+			last = at
+		}
+	}
+	// Primary keys form the sorting key in column order
+	if !strings.Contains(statements[1], "ORDER BY (`resource_id`, `scan_id`);") {
+		t.Errorf("access sorting key does not follow the column order:\n%s", statements[1])
+	}
+	if !strings.Contains(statements[2], "ORDER BY (`match_id`, `scan_id`);") {
+		t.Errorf("sensitive data sorting key does not follow the column order:\n%s", statements[2])
+	}
+}
+
+func TestMoveColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		up   bool
+		want string
+	}{
+		{"b", true, "b a c"},
+		{"b", false, "a c b"},
+		{"a", true, "a b c"},
+		{"c", false, "a b c"},
+		{"x", true, "a b c"},
+	}
+	for _, tt := range tests {
+		got := moveColumn([]string{"a", "b", "c"}, tt.name, tt.up)
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("moveColumn(%s, up=%v) = %v, want %s", tt.name, tt.up, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// columnsPreviewFlag shows the output schema in the creation summary
+// (--columns, on by default)
+var columnsPreviewFlag = true
+
+// summaryColumn is an output column in the JSON creation summary
+type summaryColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primaryKey,omitempty"`
+}
+
+// scannerOutputColumns returns the columns the generated specification
+// will have, by scan type
+func scannerOutputColumns(scanner *ScannerCreationData) map[string][]SpecColumn {
+	spec, err := parseSpec([]byte(generateScannerSpecification(scanner)))
+	if err != nil {
+		return nil
+	}
+	columns := map[string][]SpecColumn{}
+	for key, schema := range spec.OutputSchema {
+		if scanType, ok := outputSchemaScanTypes[key]; ok {
+			columns[scanType] = schema.Columns
+		}
+	}
+	return columns
+}
+
+// summaryOutputColumns returns the output columns for the JSON summary
+func summaryOutputColumns(scanner *ScannerCreationData) map[string][]summaryColumn {
+	columns := scannerOutputColumns(scanner)
+	if len(columns) == 0 {
+		return nil
+	}
+	summary := make(map[string][]summaryColumn, len(columns))
+	for scanType, cols := range columns {
+		for _, col := range cols {
+			summary[scanType] = append(summary[scanType], summaryColumn{Name: col.Name, Type: col.Type, PrimaryKey: col.PrimaryKey})
+		}
+	}
+	return summary
+}
+
+// printOutputColumns prints one line per scan type listing its columns,
+// e.g. "scan_id string pk, scan_timestamp timestamp". It is left out with
+// --columns=false or --quiet.
+func printOutputColumns(scanner *ScannerCreationData) {
+	if !columnsPreviewFlag || quietFlag {
+		return
+	}
+	columns := scannerOutputColumns(scanner)
+	if len(columns) == 0 {
+		return
+	}
+	scanTypes := make([]string, 0, len(columns))
+	for scanType := range columns {
+		scanTypes = append(scanTypes, scanType)
+	}
+	sort.Strings(scanTypes)
+
+	fmt.Println("Output Schema:")
+	for _, scanType := range scanTypes {
+		parts := make([]string, 0, len(columns[scanType]))
+		for _, col := range columns[scanType] {
+			part := col.Name + " " + col.Type
+			if col.PrimaryKey {
+				part += " pk"
+			}
+			parts = append(parts, part)
+		}
+		fmt.Printf("  %-15s %s\n", scanType+":", strings.Join(parts, ", "))
+	}
+}
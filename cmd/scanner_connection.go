@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var testConfigFileFlag string
+
+var scannerTestConnectionCmd = &cobra.Command{
+	Use:   "test-connection [dir]",
+	Short: "Check a scanner config against its specification",
+	Long: `Check a scanner configuration file against the scannerSpecification.json
+in dir (defaults to the current directory). Required fields must be present
+and every value must match the type declared in the specification.
+
+The configuration defaults to config/config.example.json in the scanner
+directory; use --config-file to check another file.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+
+		configFile := testConfigFileFlag
+		if configFile == "" {
+			configFile = filepath.Join(dir, "config", "config.example.json")
+		}
+
+		spec, err := LoadSpec(dir)
+		if err != nil {
+			return err
+		}
+
+		config, err := readScannerConfig(configFile)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf(glyphs("🔍 Checking %s against %s\n"), configFile, filepath.Join(dir, specFileName))
+
+		findings := validateScannerConfig(spec, config)
+		printFindings(findings)
+
+		errors, warnings := countFindings(findings)
+		if errors > 0 {
+			return fmt.Errorf("configuration has %d error(s) and %d warning(s)", errors, warnings)
+		}
+
+		fmt.Printf(glyphs("✅ Configuration matches the specification (%d warning(s))\n"), warnings)
+		return nil
+	},
+}
+
+// readScannerConfig reads a scanner configuration file such as config.example.json
+func readScannerConfig(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// validateScannerConfig checks each configuration section against the
+// matching section of the specification
+func validateScannerConfig(spec *ScannerSpec, config map[string]interface{}) []SpecFinding {
+	sections := []struct {
+		name string
+		spec *SpecConfigSection
+	}{
+		{"connectionConfig", spec.ConnectionConfig},
+		{"accessScanConfig", spec.AccessScanConfig},
+		{"sensitiveDataScanConfig", spec.SensitiveDataScanConfig},
+	}
+
+	var findings []SpecFinding
+	for _, section := range sections {
+		raw, present := config[section.name]
+		values, ok := raw.(map[string]interface{})
+		if present && !ok {
+			findings = append(findings, SpecFinding{severityError, section.name, "must be an object"})
+			continue
+		}
+
+		if section.spec == nil {
+			if present {
+				findings = append(findings, SpecFinding{severityWarning, section.name, "section is not defined in the specification"})
+			}
+			continue
+		}
+
+		findings = append(findings, validateConfigValues(section.name, section.spec, values)...)
+	}
+	return findings
+}
+
+// validateConfigValues checks the values of one configuration section
+func validateConfigValues(section string, spec *SpecConfigSection, values map[string]interface{}) []SpecFinding {
+	var findings []SpecFinding
+	known := make(map[string]bool)
+
+	for _, item := range spec.Items {
+		known[item.Key] = true
+		field := section + "." + item.Key
+
+		value, ok := values[item.Key]
+		if !ok || value == nil || value == "" {
+			if item.Required {
+				findings = append(findings, SpecFinding{severityError, field, "required field is missing"})
+			}
+			continue
+		}
+
+		if msg := checkConfigValue(item, value); msg != "" {
+			findings = append(findings, SpecFinding{severityError, field, msg})
+		}
+	}
+
+	unknown := make([]string, 0)
+	for key := range values {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		findings = append(findings, SpecFinding{severityWarning, section + "." + key, "field is not defined in the specification"})
+	}
+
+	return findings
+}
+
+// checkConfigValue returns a message describing why value does not match
+// the item's declared type, or "" if it does
+func checkConfigValue(item SpecConfigItem, value interface{}) string {
+	switch item.Type {
+	case "number":
+		n, ok := value.(json.Number)
+		if !ok {
+			return fmt.Sprintf("expected a number, got %s", jsonTypeName(value))
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return fmt.Sprintf("invalid number '%s'", n)
+		}
+		if item.Min != nil && f < *item.Min {
+			return fmt.Sprintf("value %s is below the minimum of %g", n, *item.Min)
+		}
+		if item.Max != nil && f > *item.Max {
+			return fmt.Sprintf("value %s is above the maximum of %g", n, *item.Max)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Sprintf("expected a boolean, got %s", jsonTypeName(value))
+		}
+	case "select":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected a string, got %s", jsonTypeName(value))
+		}
+		if !contains(item.Options, s) {
+			return fmt.Sprintf("'%s' is not one of the allowed options: %v", s, item.Options)
+		}
+	default:
+		// text, password and textarea
+		if _, ok := value.(string); !ok {
+			return fmt.Sprintf("expected a string, got %s", jsonTypeName(value))
+		}
+	}
+	return ""
+}
+
+// jsonTypeName names the JSON type of a decoded value for error messages
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "null"
+}
+
+func init() {
+	scannerTestConnectionCmd.Flags().StringVar(&testConfigFileFlag, "config-file", "", "Configuration file to check (default <dir>/config/config.example.json)")
+
+	scannerCmd.AddCommand(scannerTestConnectionCmd)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateScannerIcon(t *testing.T) {
+	tests := []struct {
+		icon    string
+		wantErr string
+	}{
+		{"database", ""},
+		{"other", ""},
+		{"https://example.com/icon.svg", ""},
+		{"http://example.com/icon.png", ""},
+		{"spaceship", "unknown icon 'spaceship'"},
+		{"Database", "unknown icon 'Database'"},
+		{"ftp://example.com/icon.png", "invalid icon URL"},
+		{"https://", "invalid icon URL"},
+		{"https://exa mple.com/icon.png", "invalid icon URL"},
+	}
+	for _, tt := range tests {
+		err := validateScannerIcon(tt.icon)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("validateScannerIcon(%q) = %v, want nil", tt.icon, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("validateScannerIcon(%q) = %v, want an error containing %q", tt.icon, err, tt.wantErr)
+		}
+	}
+}
+
+func TestScannerCreateReturnsFlagErrors(t *testing.T) {
+	saved := iconFlag
+	t.Cleanup(func() { iconFlag = saved })
+
+	iconFlag = "spaceship"
+	err := scannerCreateCmd.RunE(scannerCreateCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "unknown icon") {
+		t.Fatalf("scanner create with --icon spaceship = %v, want the icon error", err)
+	}
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorOutputFlag        string
+	doctorFailOnWarningFlag bool
+	doctorOnlyFlag          string
+)
+
+var scannerDoctorCmd = &cobra.Command{
+	Use:   "doctor [dir]",
+	Short: "Check a scanner directory for common problems",
+	Long: `Check a scanner directory (defaults to the current directory) for problems
+that only show up at runtime:
+
+  - the scannerSpecification.json fails validation
+  - the entry point (scanner.py, scanner.go, ...) uses a scanner name,
+    version or queue name that differs from the spec, usually after a
+    hand edit, so it listens on the wrong queues
+  - package.json, pom.xml, Scanner.csproj or go.mod carry a name or
+    version that differs from the spec
+  - the scannerImage of the source-type file is not the image 'scanner
+    build' produces (access-analyzer/<name>-scanner tagged latest or the
+    spec version), e.g. after a rename
+
+The exit status is 1 when there are errors. --fail-on-warning also fails on
+warnings, which are still labeled as warnings; --only errors leaves
+warnings out entirely.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(doctorOutputFlag, outputText, outputJSON); err != nil {
+			return err
+		}
+		if err := validateOnlyFlag(doctorOnlyFlag); err != nil {
+			return err
+		}
+
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		spec, err := LoadSpec(dir)
+		if err != nil {
+			return err
+		}
+
+		findings := append([]SpecFinding{}, validateSpec(spec)...)
+		nameFindings, err := checkEmbeddedNames(dir, spec)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, nameFindings...)
+		imageFindings, err := checkScannerImage(dir, spec)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, imageFindings...)
+		findings = filterFindings(findings, doctorOnlyFlag)
+		errors, warnings := countFindings(findings)
+
+		if doctorOutputFlag == outputJSON {
+			if err := printJSON(findings); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf(glyphs("🔍 Checking %s\n"), dir)
+			printFindings(findings)
+		}
+
+		if errors > 0 {
+			return fmt.Errorf("found %d error(s) and %d warning(s)", errors, warnings)
+		}
+		if doctorFailOnWarningFlag && warnings > 0 {
+			return fmt.Errorf("found %d warning(s) (--fail-on-warning)", warnings)
+		}
+		if doctorOutputFlag == outputText {
+			fmt.Printf(glyphs("✅ No problems found (%d warning(s))\n"), warnings)
+		}
+		return nil
+	},
+}
+
+// embeddedValue is a pattern for a name or version reference in a generated
+// file. The first group captures the assigned expression or literal.
+type embeddedValue struct {
+	what    string // "name" or "version"
+	pattern *regexp.Regexp
+}
+
+// entrypointNamePatterns are the name and version assignments in each
+// generated entry point. They read from the spec; a string literal in their
+// place is a hand edit that must match the spec.
+var entrypointNamePatterns = map[string][]embeddedValue{
+	"scanner.py": {
+		{"name", regexp.MustCompile(`self\.scanner_name\s*=\s*([^\r\n]+)`)},
+		{"version", regexp.MustCompile(`self\.scanner_version\s*=\s*([^\r\n]+)`)},
+	},
+	"scanner.js": {
+		{"name", regexp.MustCompile(`this\.scannerName\s*=\s*([^;\r\n]+)`)},
+		{"version", regexp.MustCompile(`this\.scannerVersion\s*=\s*([^;\r\n]+)`)},
+	},
+	"scanner.go": {
+		{"name", regexp.MustCompile(`\bname\s*:=\s*([^\r\n]+)`)},
+		{"version", regexp.MustCompile(`\bversion\s*:=\s*([^\r\n]+)`)},
+	},
+	"Scanner.java": {
+		{"name", regexp.MustCompile(`this\.scannerName\s*=\s*([^;\r\n]+)`)},
+		{"version", regexp.MustCompile(`this\.scannerVersion\s*=\s*([^;\r\n]+)`)},
+	},
+	"Scanner.cs": {
+		{"name", regexp.MustCompile(`_scannerName\s*=\s*([^;\r\n]+)`)},
+		{"version", regexp.MustCompile(`_scannerVersion\s*=\s*([^;\r\n]+)`)},
+	},
+}
+
+// manifestNamePatterns are the name and version written into each
+// generated package manifest. Names carry the kebab-case scanner name.
+var manifestNamePatterns = map[string][]embeddedValue{
+	"package.json": {
+		{"name", regexp.MustCompile(`"name"\s*:\s*"([^"]*)-scanner"`)},
+		{"version", regexp.MustCompile(`"version"\s*:\s*"([^"]*)"`)},
+	},
+	"pom.xml": {
+		{"name", regexp.MustCompile(`<artifactId>([^<]*)-scanner</artifactId>`)},
+		{"version", regexp.MustCompile(`<version>([^<]*)</version>`)},
+	},
+	"Scanner.csproj": {
+		{"name", regexp.MustCompile(`<AssemblyName>([^<]*)-Scanner</AssemblyName>`)},
+		{"version", regexp.MustCompile(`<AssemblyVersion>([^<]*)</AssemblyVersion>`)},
+	},
+	"go.mod": {
+		{"name", regexp.MustCompile(`(?m)^module\s+(\S+)-scanner\s*$`)},
+	},
+}
+
+var (
+	// stringLiteralPattern matches a quoted string literal expression
+	stringLiteralPattern = regexp.MustCompile(`^\s*(?:"([^"]*)"|'([^']*)')\s*,?\s*$`)
+
+	// queueLiteralPattern matches a hard-coded queue name such as
+	// "MY_SCANNER-1.0.0-scan-access"
+	queueLiteralPattern = regexp.MustCompile(`["']([A-Z][A-Z0-9_]*)-(\d+\.\d+\.\d+)-(?:scan-[a-z_]+|test)["']`)
+)
+
+// checkEmbeddedNames compares the scanner name and version referenced in the
+// generated entry point and package manifests under dir with the spec.
+// Entry point mismatches are errors because the scanner would consume the
+// wrong queues; manifest mismatches are warnings.
+func checkEmbeddedNames(dir string, spec *ScannerSpec) ([]SpecFinding, error) {
+	var findings []SpecFinding
+	expected := map[string]string{"name": spec.Name, "version": spec.Version}
+
+	for _, lf := range languageFiles {
+		content, err := readOptionalFile(filepath.Join(dir, lf.file))
+		if err != nil {
+			return nil, err
+		}
+		if content == "" {
+			continue
+		}
+
+		for _, ev := range entrypointNamePatterns[lf.file] {
+			match := ev.pattern.FindStringSubmatchIndex(content)
+			if match == nil {
+				continue
+			}
+			literal := stringLiteralPattern.FindStringSubmatch(content[match[2]:match[3]])
+			if literal == nil {
+				continue // Read from the spec or computed
+			}
+			value := literal[1] + literal[2]
+			if value != expected[ev.what] {
+				findings = append(findings, SpecFinding{severityError, fileLine(lf.file, content, match[2]),
+					fmt.Sprintf("scanner %s '%s' does not match spec %s '%s'", ev.what, value, ev.what, expected[ev.what])})
+			}
+		}
+
+		for _, match := range queueLiteralPattern.FindAllStringSubmatchIndex(content, -1) {
+			name := content[match[2]:match[3]]
+			version := content[match[4]:match[5]]
+			if name != spec.Name || version != spec.Version {
+				findings = append(findings, SpecFinding{severityError, fileLine(lf.file, content, match[0]),
+					fmt.Sprintf("queue name %s uses %s %s, spec is %s %s", content[match[0]:match[1]], name, version, spec.Name, spec.Version)})
+			}
+		}
+	}
+
+	kebabName := scannerNameFromSpec(spec.Name)
+	for _, file := range []string{"package.json", "pom.xml", "Scanner.csproj", "go.mod"} {
+		content, err := readOptionalFile(filepath.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+		if content == "" {
+			continue
+		}
+
+		for _, ev := range manifestNamePatterns[file] {
+			match := ev.pattern.FindStringSubmatchIndex(content)
+			if match == nil {
+				continue
+			}
+			value := content[match[2]:match[3]]
+			want := spec.Version
+			if ev.what == "name" {
+				want = kebabName
+			}
+			if !strings.EqualFold(value, want) {
+				findings = append(findings, SpecFinding{severityWarning, fileLine(file, content, match[2]),
+					fmt.Sprintf("%s '%s' does not match spec %s '%s'", ev.what, value, ev.what, want)})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// readOptionalFile returns the contents of path, or "" if it does not exist
+func readOptionalFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// fileLine formats file and the line holding byte offset in content
+func fileLine(file, content string, offset int) string {
+	return fmt.Sprintf("%s:%d", file, strings.Count(content[:offset], "\n")+1)
+}
+
+func init() {
+	scannerDoctorCmd.Flags().StringVarP(&doctorOutputFlag, "output", "o", outputText, "Output format (text|json)")
+	scannerDoctorCmd.Flags().BoolVar(&doctorFailOnWarningFlag, "fail-on-warning", false, "Exit with an error when there are warnings")
+	scannerDoctorCmd.Flags().StringVar(&doctorOnlyFlag, "only", onlyAll, "Findings to report (all|errors)")
+
+	scannerCmd.AddCommand(scannerDoctorCmd)
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var scannerEditCmd = &cobra.Command{
+	Use:   "edit [dir]",
+	Short: "Edit an existing scanner's metadata",
+	Long: `Edit the display name, description, version and icon of a generated
+scanner through the creation wizard, pre-filled with the current values.
+The directory defaults to the current directory.
+
+Only changed fields are written back: the source-type file is updated in
+place and a new version is applied to every file that carries it (see
+'nwx aa scanner bump-version'). Custom code and fields the wizard does not
+manage are left untouched. Use 'nwx aa scanner rename' to change the name.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		defer func() {
+			err = normalizeCancellation(err)
+		}()
+
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+
+		current, err := loadScannerDir(dir)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf(glyphs("✏️  Editing scanner '%s' in %s\n"), current.Name, dir)
+		fmt.Println()
+
+		edited := *current
+		if err := collectScannerDetails(&edited); err != nil {
+			return err
+		}
+		if !semverPattern.MatchString(edited.Version) {
+			return fmt.Errorf("version '%s' must be a semantic version (e.g. 1.0.0)", edited.Version)
+		}
+		if err := validateScannerIcon(edited.Icon); err != nil {
+			return err
+		}
+
+		sourceTypePath := filepath.Join(dir, current.Name+"-source-type.json")
+		oldSourceType, newSourceType, err := editSourceTypeFile(sourceTypePath, &edited)
+		if err != nil {
+			return err
+		}
+
+		changes, err := diffSpecJSON(oldSourceType, newSourceType)
+		if err != nil {
+			return err
+		}
+		if edited.Version != current.Version {
+			changes = append(changes, specChange{Path: "version", Kind: changeChanged, Old: current.Version, New: edited.Version})
+		}
+		if len(changes) == 0 {
+			fmt.Println(glyphs("✅ No changes"))
+			return nil
+		}
+
+		fmt.Println("Changes:")
+		printChanges(changes)
+		fmt.Println()
+
+		save, err := askConfirm(&survey.Confirm{
+			Message: "Save these changes?",
+			Default: true,
+		})
+		if err != nil {
+			return err
+		}
+		if !save {
+			fmt.Println(glyphs("❌ Changes discarded"))
+			return nil
+		}
+
+		if !bytes.Equal(oldSourceType, newSourceType) {
+			info, err := os.Stat(sourceTypePath)
+			if err != nil {
+				return err
+			}
+			if err := writeFileAtomic(sourceTypePath, newSourceType, info.Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to write %s: %w", sourceTypePath, err)
+			}
+			fmt.Printf(glyphs("  ✅ Updated %s\n"), filepath.Base(sourceTypePath))
+		}
+		if edited.Version != current.Version {
+			edits, err := bumpVersion(dir, current.Version, edited.Version)
+			if err != nil {
+				return err
+			}
+			for _, e := range edits {
+				fmt.Printf(glyphs("  ✅ Updated version in %s\n"), e.File)
+			}
+		}
+
+		fmt.Println()
+		fmt.Println(glyphs("✅ Scanner updated"))
+		return nil
+	},
+}
+
+// languageFiles maps the entry point generated for each language
+var languageFiles = []struct {
+	language string
+	file     string
+}{
+	{"python", "scanner.py"},
+	{"javascript", "scanner.js"},
+	{"go", "scanner.go"},
+	{"java", "Scanner.java"},
+	{"c#", "Scanner.cs"},
+}
+
+// loadScannerDir reads a generated scanner directory back into
+// ScannerCreationData from its specification, its source-type file and the
+// generated entry point
+func loadScannerDir(dir string) (*ScannerCreationData, error) {
+	spec, err := LoadSpec(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := &ScannerCreationData{
+		Name:      strings.ToLower(strings.ReplaceAll(spec.Name, "_", "-")),
+		Version:   spec.Version,
+		OutputDir: dir,
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, scanner.Name+"-source-type.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source type: %w", err)
+	}
+	var sourceType struct {
+		DisplayName        string   `json:"displayName"`
+		Description        string   `json:"description"`
+		Icon               string   `json:"icon"`
+		SupportedScanTypes []string `json:"supportedScanTypes"`
+	}
+	if err := json.Unmarshal(data, &sourceType); err != nil {
+		return nil, fmt.Errorf("invalid source type file: %w", err)
+	}
+	scanner.DisplayName = sourceType.DisplayName
+	scanner.Description = sourceType.Description
+	scanner.Icon = sourceType.Icon
+	scanner.SupportedScanTypes = sourceType.SupportedScanTypes
+
+	for _, lf := range languageFiles {
+		if _, err := os.Stat(filepath.Join(dir, lf.file)); err == nil {
+			scanner.Language = lf.language
+			break
+		}
+	}
+
+	return scanner, nil
+}
+
+// editSourceTypeFile returns the source-type file at path before and after
+// applying the edited display name, description and icon. Other fields and
+// the order of keys are kept as they are.
+func editSourceTypeFile(path string, scanner *ScannerCreationData) (oldData, newData []byte, err error) {
+	oldData, err = os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decoded, err := decodeOrderedJSON(oldData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid source type file: %w", err)
+	}
+	doc, ok := decoded.(*orderedObject)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid source type file: expected a JSON object")
+	}
+
+	changed := false
+	for _, field := range []struct{ key, value string }{
+		{"displayName", scanner.DisplayName},
+		{"description", scanner.Description},
+		{"icon", scanner.Icon},
+	} {
+		if current, _ := orderedValue(doc, field.key).(string); current != field.value {
+			doc.set(field.key, field.value)
+			changed = true
+		}
+	}
+	if !changed {
+		return oldData, oldData, nil
+	}
+
+	newData, err = marshalOrderedJSON(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bytes.HasSuffix(oldData, []byte("\n")) {
+		newData = append(newData, '\n')
+	}
+	return oldData, newData, nil
+}
+
+func init() {
+	scannerCmd.AddCommand(scannerEditCmd)
+}
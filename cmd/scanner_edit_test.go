@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestEditSourceTypeFileKeepsLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "my-scanner-source-type.json")
+	writeTestFile(t, path, `{
+  "typeName": "my-scanner",
+  "icon": "folder",
+  "description": "Reads <files> & folders",
+  "displayName": "My Scanner",
+  "custom": {
+    "zeta": 1,
+    "alpha": 2.50
+  }
+}
+`)
+
+	scanner := &ScannerCreationData{
+		DisplayName: "Renamed & <Improved>",
+		Description: "Reads <files> & folders",
+		Icon:        "folder",
+	}
+	oldData, newData, err := editSourceTypeFile(path, scanner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "typeName": "my-scanner",
+  "icon": "folder",
+  "description": "Reads <files> & folders",
+  "displayName": "Renamed & <Improved>",
+  "custom": {
+    "zeta": 1,
+    "alpha": 2.50
+  }
+}
+`
+	if string(newData) != want {
+		t.Errorf("edited source type:\n%s\nwant:\n%s", newData, want)
+	}
+
+	scanner.DisplayName = "My Scanner"
+	_, unchanged, err := editSourceTypeFile(path, scanner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unchanged, oldData) {
+		t.Errorf("source type without edits changed:\n%s", unchanged)
+	}
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// EnvVar is an extra runtime environment variable of a generated scanner
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// envVarNamePattern matches valid environment variable names
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// standardEnvVarPrefixes are the prefixes of the variables every generated
+// Dockerfile sets; extra variables may not override them
+var standardEnvVarPrefixes = []string{"RABBITMQ_", "APP_DB_", "COLLECTION_DB_"}
+
+// parseEnvVar parses a KEY=VALUE extra environment variable
+func parseEnvVar(arg string) (EnvVar, error) {
+	name, value, ok := strings.Cut(arg, "=")
+	if !ok {
+		return EnvVar{}, fmt.Errorf("invalid environment variable '%s' (expected KEY=VALUE)", arg)
+	}
+	if !envVarNamePattern.MatchString(name) {
+		return EnvVar{}, fmt.Errorf("invalid environment variable name '%s' (use letters, digits and '_', not starting with a digit)", name)
+	}
+	for _, prefix := range standardEnvVarPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return EnvVar{}, fmt.Errorf("environment variable '%s' is set by the generated Dockerfile (%s* is reserved)", name, prefix)
+		}
+	}
+	return EnvVar{Name: name, Value: value}, nil
+}
+
+// parseEnvVars parses the --env values; a repeated name keeps the last value
+func parseEnvVars(args []string) ([]EnvVar, error) {
+	var vars []EnvVar
+	for _, arg := range args {
+		v, err := parseEnvVar(arg)
+		if err != nil {
+			return nil, err
+		}
+		vars = setEnvVar(vars, v)
+	}
+	return vars, nil
+}
+
+// setEnvVar adds v to vars, replacing the value of a variable of that name
+func setEnvVar(vars []EnvVar, v EnvVar) []EnvVar {
+	for i := range vars {
+		if vars[i].Name == v.Name {
+			vars[i].Value = v.Value
+			return vars
+		}
+	}
+	return append(vars, v)
+}
+
+// envVarNames returns the names of vars, leaving out values that may be secrets
+func envVarNames(vars []EnvVar) []string {
+	names := make([]string, 0, len(vars))
+	for _, v := range vars {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+// collectExtraEnv asks for extra runtime environment variables until an
+// empty answer, starting from the ones given with --env
+func collectExtraEnv(scanner *ScannerCreationData) error {
+	if len(scanner.ExtraEnv) > 0 {
+		fmt.Printf("Extra environment variables: %s\n", strings.Join(envVarNames(scanner.ExtraEnv), ", "))
+	}
+	for {
+		var answer string
+		prompt := &survey.Input{
+			Message: "Extra environment variable (KEY=VALUE, empty to finish):",
+			Help:    "Runtime variables such as API keys or regions, added to the generated Dockerfile next to the RABBITMQ_* and *_DB_* defaults",
+		}
+		if err := askOne(prompt, &answer, survey.WithValidator(func(val interface{}) error {
+			if str := strings.TrimSpace(val.(string)); str != "" {
+				_, err := parseEnvVar(str)
+				return err
+			}
+			return nil
+		})); err != nil {
+			return err
+		}
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			return nil
+		}
+		v, _ := parseEnvVar(answer)
+		scanner.ExtraEnv = setEnvVar(scanner.ExtraEnv, v)
+	}
+}
+
+// addDockerfileEnv adds ENV instructions for vars to a generated
+// Dockerfile, after the standard variables and before the final CMD
+func addDockerfileEnv(dockerfile string, vars []EnvVar) string {
+	if len(vars) == 0 {
+		return dockerfile
+	}
+
+	var block strings.Builder
+	block.WriteString("# Scanner-specific environment variables\n")
+	for _, v := range vars {
+		fmt.Fprintf(&block, "ENV %s=%s\n", v.Name, dockerfileEnvValue(v.Value))
+	}
+	block.WriteString("\n")
+
+	i := strings.LastIndex(dockerfile, "\nCMD ")
+	if i < 0 {
+		return dockerfile + "\n" + block.String()
+	}
+	return dockerfile[:i+1] + block.String() + dockerfile[i+1:]
+}
+
+// plainEnvValuePattern matches values that need no quoting in a Dockerfile
+var plainEnvValuePattern = regexp.MustCompile(`^[A-Za-z0-9_./:@,+-]*$`)
+
+// dockerfileEnvValue quotes an ENV value when needed, escaping characters
+// that are special inside double quotes, including $ substitution
+func dockerfileEnvValue(value string) string {
+	if plainEnvValuePattern.MatchString(value) {
+		return value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`).Replace(value)
+	return `"` + escaped + `"`
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// forceFlag allows scanner creation to overwrite files tracked by git (--force)
+var forceFlag bool
+
+// gitTrackedFiles returns the names, relative to dir, that git tracks in
+// the repository containing dir. Nothing is reported when git is not
+// installed or dir is not inside a repository.
+func gitTrackedFiles(dir string, names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	git, err := exec.LookPath("git")
+	if err != nil {
+		return nil
+	}
+
+	args := append([]string{"-C", dir, "ls-files", "-z", "--"}, names...)
+	out, err := exec.Command(git, args...).Output()
+	if err != nil {
+		return nil
+	}
+
+	var tracked []string
+	for _, name := range strings.Split(string(bytes.TrimRight(out, "\x00")), "\x00") {
+		if name != "" {
+			tracked = append(tracked, filepath.ToSlash(name))
+		}
+	}
+	return tracked
+}
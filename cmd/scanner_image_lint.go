@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// scannerImagePattern matches the scannerImage of a source-type file
+var scannerImagePattern = regexp.MustCompile(`"scannerImage"\s*:\s*"([^"]*)"`)
+
+// checkScannerImage compares the scannerImage of the source-type files in
+// dir with the image 'scanner build' tags for the spec:
+// access-analyzer/<name>-scanner, tagged latest (as generated) or with the
+// spec version. A registry host in front of the repository is allowed.
+// Mismatches are warnings; they usually follow a rename or version bump
+// that did not update the source-type file.
+func checkScannerImage(dir string, spec *ScannerSpec) ([]SpecFinding, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*-source-type.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	kebabName := scannerNameFromSpec(spec.Name)
+	repository := "access-analyzer/" + kebabName + "-scanner"
+	expected := fmt.Sprintf("%s:latest or %s", repository, scannerImageTag(spec))
+
+	var findings []SpecFinding
+	for _, path := range files {
+		file := filepath.Base(path)
+		content, err := readOptionalFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if file != kebabName+"-source-type.json" {
+			findings = append(findings, SpecFinding{severityWarning, file,
+				fmt.Sprintf("source type file name does not match spec name %s (expected %s-source-type.json)", spec.Name, kebabName)})
+		}
+
+		match := scannerImagePattern.FindStringSubmatchIndex(content)
+		if match == nil {
+			findings = append(findings, SpecFinding{severityWarning, file,
+				fmt.Sprintf("no scannerImage (expected %s)", expected)})
+			continue
+		}
+		image := content[match[2]:match[3]]
+		if problem := scannerImageMismatch(image, repository, spec.Version); problem != "" {
+			findings = append(findings, SpecFinding{severityWarning, fileLine(file, content, match[2]),
+				fmt.Sprintf("scannerImage '%s' %s for spec %s %s (expected %s)", image, problem, spec.Name, spec.Version, expected)})
+		}
+	}
+	return findings, nil
+}
+
+// scannerImageMismatch describes how image differs from the expected
+// repository and tags, or returns "" when it matches
+func scannerImageMismatch(image, repository, version string) string {
+	ref, tag := image, "latest"
+	// A colon after the last slash separates the tag; earlier ones are a
+	// registry port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		ref, tag = image[:i], image[i+1:]
+	}
+
+	if ref != repository && !strings.HasSuffix(ref, "/"+repository) {
+		return "names a different repository"
+	}
+	if tag != "latest" && tag != version {
+		return "has a different tag"
+	}
+	return ""
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var scannerListLanguagesCmd = &cobra.Command{
+	Use:   "list-languages",
+	Short: "Print the supported scanner languages as JSON",
+	Long: `Print the languages 'nwx aa scanner create' can generate as a JSON array,
+for tools that drive scanner creation and validate their inputs first.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printJSON(languageOptions)
+	},
+}
+
+var scannerListScanTypesCmd = &cobra.Command{
+	Use:   "list-scan-types",
+	Short: "Print the supported scan types as JSON",
+	Long: `Print the scan types a scanner can support as a JSON array, for tools
+that drive scanner creation and validate their inputs first.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printJSON(scanTypeOptions)
+	},
+}
+
+func init() {
+	scannerCmd.AddCommand(scannerListLanguagesCmd)
+	scannerCmd.AddCommand(scannerListScanTypesCmd)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// namePrefixKey is the configuration key holding the default --name-prefix
+const namePrefixKey = "name-prefix"
+
+// namePrefixFlag is prepended to new scanner names (--name-prefix)
+var namePrefixFlag string
+
+// validateNamePrefix checks that a prefix keeps scanner names kebab-case
+func validateNamePrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if !strings.HasSuffix(prefix, "-") || validateScannerName(prefix+"x") != nil {
+		return fmt.Errorf("invalid %s '%s' (expected kebab-case ending in '-', e.g. 'dev-alice-')", namePrefixKey, prefix)
+	}
+	return nil
+}
+
+// setNamePrefix validates and stores the name-prefix key
+func setNamePrefix(value string) error {
+	if err := validateNamePrefix(value); err != nil {
+		return err
+	}
+	return writeConfigValue(namePrefixKey, value)
+}
+
+// scannerNamePrefix returns the prefix for new scanner names: --name-prefix
+// when given, otherwise the name-prefix key
+func scannerNamePrefix() (string, error) {
+	if namePrefixFlag != "" {
+		return namePrefixFlag, validateNamePrefix(namePrefixFlag)
+	}
+	prefix, err := readConfigValue(namePrefixKey)
+	if err != nil {
+		return "", err
+	}
+	return prefix, validateNamePrefix(prefix)
+}
+
+// applyNamePrefix prepends prefix to name unless it is already there, so
+// editing a prefixed name does not add it twice
+func applyNamePrefix(prefix, name string) string {
+	if name == "" || strings.HasPrefix(name, prefix) {
+		return name
+	}
+	return prefix + name
+}
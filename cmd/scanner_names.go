@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+)
+
+var namesOutputFlag string
+
+var scannerNamesCmd = &cobra.Command{
+	Use:     "names [dir]",
+	Aliases: []string{"logs-schema"},
+	Short:   "Show a scanner's queue and table names",
+	Long: `Print the RabbitMQ queue and ClickHouse table names a scanner uses, derived
+from the name and version in the scannerSpecification.json in dir (defaults
+to the current directory) exactly as the generated scanner code does. The
+scan types are taken from the spec's output schemas.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(namesOutputFlag, outputText, outputJSON); err != nil {
+			return err
+		}
+
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		spec, err := LoadSpec(dir)
+		if err != nil {
+			return err
+		}
+
+		names := deriveScannerNames(spec.Name, spec.Version, specScanTypes(spec))
+		if namesOutputFlag == outputJSON {
+			return printJSON(names)
+		}
+
+		fmt.Printf("%s %s\n", spec.Name, spec.Version)
+		fmt.Println()
+		printScannerNames(names)
+		return nil
+	},
+}
+
+// printScannerNames prints the queue and table names as two sections
+func printScannerNames(names ScannerNames) {
+	fmt.Println("Queues:")
+	for _, scanType := range sortedStringKeys(names.ScanQueues) {
+		fmt.Printf("  %-16s %s\n", "scan ("+scanType+")", names.ScanQueues[scanType])
+	}
+	fmt.Printf("  %-16s %s\n", "test", names.TestQueue)
+	fmt.Println()
+	fmt.Println("Tables:")
+	for _, scanType := range sortedStringKeys(names.Tables) {
+		fmt.Printf("  %-16s %s\n", scanType, names.Tables[scanType])
+	}
+}
+
+// ScannerNames are the queue and table names a scanner uses at runtime. The
+// generated scanner code derives them from the spec name and version.
+type ScannerNames struct {
+	ScanQueues map[string]string `json:"scanQueues"`
+	TestQueue  string            `json:"testQueue"`
+	Tables     map[string]string `json:"tables"`
+}
+
+// deriveScannerNames returns the queue and table names for a spec name,
+// version and set of scan types
+func deriveScannerNames(specName, version string, scanTypes []string) ScannerNames {
+	names := ScannerNames{
+		ScanQueues: make(map[string]string),
+		TestQueue:  specName + "-" + version + "-test",
+		Tables:     make(map[string]string),
+	}
+
+	versionWithUnderscores := strings.ReplaceAll(version, ".", "_")
+	for _, scanType := range scanTypes {
+		names.ScanQueues[scanType] = specName + "-" + version + "-scan-" + scanType
+		names.Tables[scanType] = strings.ToLower(specName + "_" + versionWithUnderscores + "_" + scanType)
+	}
+	return names
+}
+
+// Limits on scanner names so the derived names stay usable
+const (
+	// maxQueueNameLength is RabbitMQ's limit on queue names, in bytes
+	maxQueueNameLength = 255
+
+	// maxScannerNameLength leaves room in queue names for the version and
+	// the longest scan queue suffix
+	maxScannerNameLength = 100
+)
+
+// clickHouseIdentPattern matches table names ClickHouse accepts unquoted
+var clickHouseIdentPattern = regexp.MustCompile(`^[a-zA-Z_][0-9a-zA-Z_]*$`)
+
+// specNameProblems checks that a spec name converts back to a kebab-case
+// scanner name and that the queue and table names derived from it are
+// valid RabbitMQ queue and ClickHouse table names
+func specNameProblems(specName, version string, scanTypes []string) []string {
+	var problems []string
+	switch {
+	case strings.HasPrefix(specName, "_") || strings.HasSuffix(specName, "_"):
+		problems = append(problems, fmt.Sprintf("name '%s' starts or ends with '_', which has no kebab-case scanner name", specName))
+	case strings.Contains(specName, "__"):
+		problems = append(problems, fmt.Sprintf("name '%s' contains '__', which becomes '--' in the scanner name", specName))
+	}
+
+	names := deriveScannerNames(specName, version, scanTypes)
+	for _, scanType := range sortedStringKeys(names.Tables) {
+		if table := names.Tables[scanType]; !clickHouseIdentPattern.MatchString(table) {
+			problems = append(problems, fmt.Sprintf("table name '%s' is not a valid ClickHouse identifier", table))
+		}
+	}
+	queues := []string{names.TestQueue}
+	for _, scanType := range sortedStringKeys(names.ScanQueues) {
+		queues = append(queues, names.ScanQueues[scanType])
+	}
+	for _, queue := range queues {
+		if len(queue) > maxQueueNameLength {
+			problems = append(problems, fmt.Sprintf("queue name '%s' is longer than RabbitMQ's limit of %d bytes", queue, maxQueueNameLength))
+		}
+	}
+	return problems
+}
+
+// specScanTypes returns the scan types a spec defines output schemas for,
+// defaulting to access
+func specScanTypes(spec *ScannerSpec) []string {
+	var scanTypes []string
+	for _, key := range sortedKeys(spec.OutputSchema) {
+		if scanType, ok := outputSchemaScanTypes[key]; ok {
+			scanTypes = append(scanTypes, scanType)
+		}
+	}
+	if len(scanTypes) == 0 {
+		return []string{"access"}
+	}
+	return scanTypes
+}
+
+// all returns every derived name in a stable order
+func (n ScannerNames) all() []string {
+	var names []string
+	for _, scanType := range sortedStringKeys(n.ScanQueues) {
+		names = append(names, n.ScanQueues[scanType])
+	}
+	names = append(names, n.TestQueue)
+	for _, scanType := range sortedStringKeys(n.Tables) {
+		names = append(names, n.Tables[scanType])
+	}
+	return names
+}
+
+// nameCollision is a derived name shared with an existing scanner
+type nameCollision struct {
+	Name     string
+	Existing SourceType
+}
+
+// findNameCollisions compares the names derived for a new scanner with those
+// of existing scanners. Different type names can map to the same spec name
+// (e.g. "my-scanner" and "my_scanner"), and short versions can run into the
+// name ("app-1" 0.0 and "app" 1.0.0 share the table app_1_0_0_access).
+func findNameCollisions(scanner *ScannerCreationData, existing []SourceType) []nameCollision {
+	derived := deriveScannerNames(toSpecName(scanner.Name), scanner.Version, scanner.SupportedScanTypes)
+	wanted := make(map[string]bool)
+	for _, name := range derived.all() {
+		wanted[name] = true
+	}
+
+	var collisions []nameCollision
+	for _, st := range existing {
+		scanTypes := st.SupportedScans
+		if len(scanTypes) == 0 {
+			scanTypes = []string{"access"}
+		}
+		for _, name := range deriveScannerNames(toSpecName(st.TypeName), st.Version, scanTypes).all() {
+			if wanted[name] {
+				collisions = append(collisions, nameCollision{Name: name, Existing: st})
+			}
+		}
+	}
+	return collisions
+}
+
+// confirmNameCollisions warns about queue or table names that would be shared
+// with existing scanners and asks whether to continue anyway
+func confirmNameCollisions(scanner *ScannerCreationData, existing []SourceType) error {
+	collisions := findNameCollisions(scanner, existing)
+	if len(collisions) == 0 {
+		return nil
+	}
+
+	fmt.Println(glyphs("⚠️  The derived queue/table names collide with existing scanners:"))
+	for _, c := range collisions {
+		fmt.Printf(glyphs("  • %s (used by %s %s)\n"), c.Name, c.Existing.TypeName, c.Existing.Version)
+	}
+	fmt.Println()
+
+	proceed, err := askConfirm(&survey.Confirm{
+		Message: "Continue with these names anyway?",
+		Default: false,
+	})
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return fmt.Errorf("scanner creation cancelled: queue/table names already in use")
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// sortedStringKeys returns the keys of a string map in a stable order
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	scannerNamesCmd.Flags().StringVarP(&namesOutputFlag, "output", "o", outputText, "Output format (text|json)")
+
+	scannerCmd.AddCommand(scannerNamesCmd)
+}
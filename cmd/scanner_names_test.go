@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeriveScannerNames(t *testing.T) {
+	got := deriveScannerNames("MY_SCANNER", "1.2.0", []string{"access", "sensitive_data"})
+	want := ScannerNames{
+		ScanQueues: map[string]string{
+			"access":         "MY_SCANNER-1.2.0-scan-access",
+			"sensitive_data": "MY_SCANNER-1.2.0-scan-sensitive_data",
+		},
+		TestQueue: "MY_SCANNER-1.2.0-test",
+		Tables: map[string]string{
+			"access":         "my_scanner_1_2_0_access",
+			"sensitive_data": "my_scanner_1_2_0_sensitive_data",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("deriveScannerNames() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFindNameCollisions(t *testing.T) {
+	existing := []SourceType{
+		{TypeName: "app", Version: "1.0.0", SupportedScans: []string{"access"}},
+		{TypeName: "file-scanner", Version: "2.0.0"},
+		{TypeName: "other-scanner", Version: "1.0.0", SupportedScans: []string{"access"}},
+	}
+	tests := []struct {
+		name    string
+		scanner ScannerCreationData
+		want    []string
+	}{
+		{
+			name:    "short version runs into the name",
+			scanner: ScannerCreationData{Name: "app-1", Version: "0.0", SupportedScanTypes: []string{"access"}},
+			want:    []string{"app_1_0_0_access"},
+		},
+		{
+			name:    "same name and version, existing scan types default to access",
+			scanner: ScannerCreationData{Name: "file-scanner", Version: "2.0.0", SupportedScanTypes: []string{"access"}},
+			want:    []string{"FILE_SCANNER-2.0.0-scan-access", "FILE_SCANNER-2.0.0-test", "file_scanner_2_0_0_access"},
+		},
+		{
+			name:    "same name, other version",
+			scanner: ScannerCreationData{Name: "file-scanner", Version: "2.1.0", SupportedScanTypes: []string{"access"}},
+		},
+		{
+			name:    "unrelated scanner",
+			scanner: ScannerCreationData{Name: "new-scanner", Version: "1.0.0", SupportedScanTypes: []string{"access"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range findNameCollisions(&tt.scanner, existing) {
+				got = append(got, c.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("collisions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmNameCollisionsWithYes(t *testing.T) {
+	old := assumeYesFlag
+	assumeYesFlag = true
+	t.Cleanup(func() { assumeYesFlag = old })
+
+	scanner := &ScannerCreationData{Name: "app-1", Version: "0.0", SupportedScanTypes: []string{"access"}}
+	existing := []SourceType{{TypeName: "app", Version: "1.0.0"}}
+	if err := confirmNameCollisions(scanner, existing); err != nil {
+		t.Errorf("confirmNameCollisions() with --yes = %v, want nil", err)
+	}
+}
+
+func TestValidateScannerName(t *testing.T) {
+	longest := "a-" + strings.Repeat("b", maxScannerNameLength-2)
+	for _, name := range []string{"my-scanner", "a1-b2", "file-share-scanner", "x-9", longest} {
+		if err := validateScannerName(name); err != nil {
+			t.Errorf("validateScannerName(%q) = %v, want nil", name, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", "should be kebab-case (e.g., 'my-scanner')"},
+		{"1-scanner", "must start with a letter"},
+		{"-scanner", "can't start or end with '-'"},
+		{"scanner-", "can't start or end with '-'"},
+		{"my--scanner", "'--' would become '__'"},
+		{"my_scanner", "separate words with '-'"},
+		{"my scanner", "separate words with '-'"},
+		{"My-Scanner", "use lowercase letters only"},
+		{"scanner", "use at least two words"},
+		{"my-scanner.v2", "use only lowercase letters, digits and '-'"},
+		{"my-scannér", "use only lowercase letters, digits and '-'"},
+		{longest + "c", "is 101 characters long (at most 100"},
+	}
+	for _, tt := range tests {
+		err := validateScannerName(tt.name)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("validateScannerName(%q) = %v, want an error containing %q", tt.name, err, tt.want)
+		}
+	}
+	if err := validateScannerName(""); err == nil || strings.Contains(err.Error(), "):") {
+		t.Errorf("validateScannerName(\"\") = %v, want no hint", err)
+	}
+}
+
+func TestSpecNameProblems(t *testing.T) {
+	scanTypes := []string{"access", "sensitive_data"}
+	if problems := specNameProblems("MY_SCANNER", "1.0.0", scanTypes); len(problems) != 0 {
+		t.Errorf("specNameProblems(MY_SCANNER) = %v, want none", problems)
+	}
+
+	tests := []struct {
+		specName string
+		version  string
+		want     []string
+	}{
+		{"_MY_SCANNER", "1.0.0", []string{"starts or ends with '_'"}},
+		{"MY_SCANNER_", "1.0.0", []string{"starts or ends with '_'"}},
+		{"MY__SCANNER", "1.0.0", []string{"contains '__'"}},
+		{"MY_SCANNER", "1.0.0-beta", []string{
+			"table name 'my_scanner_1_0_0-beta_access' is not a valid ClickHouse identifier",
+			"table name 'my_scanner_1_0_0-beta_sensitive_data' is not a valid ClickHouse identifier",
+		}},
+		{"1_SCANNER", "1.0.0", []string{"table name '1_scanner_1_0_0_access'"}},
+		{strings.Repeat("S", 250), "1.0.0", []string{
+			"queue name '" + strings.Repeat("S", 250) + "-1.0.0-test' is longer than RabbitMQ's limit of 255 bytes",
+			"-1.0.0-scan-access' is longer",
+			"-1.0.0-scan-sensitive_data' is longer",
+		}},
+	}
+	for _, tt := range tests {
+		problems := specNameProblems(tt.specName, tt.version, scanTypes)
+		joined := strings.Join(problems, "\n")
+		for _, want := range tt.want {
+			if !strings.Contains(joined, want) {
+				t.Errorf("specNameProblems(%s, %s) = %q, want one containing %q", tt.specName, tt.version, problems, want)
+			}
+		}
+	}
+}
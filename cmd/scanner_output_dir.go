@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultOutputDirKey is the configuration key holding the directory new
+// scanners are generated in
+const defaultOutputDirKey = "scanner.default-output-dir"
+
+// outputDirNamePlaceholder is replaced by the scanner name in an output
+// directory template
+const outputDirNamePlaceholder = "{name}"
+
+// outputDirFlag is the directory to generate a scanner in (--output-dir)
+var outputDirFlag string
+
+// setDefaultOutputDir validates and stores the scanner.default-output-dir key
+func setDefaultOutputDir(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%s cannot be empty", defaultOutputDirKey)
+	}
+	return writeConfigValue(defaultOutputDirKey, value)
+}
+
+// defaultScannerOutputDir returns the directory to generate the scanner
+// name in: --output-dir when given, otherwise the scanner.default-output-dir
+// key, otherwise ./<name>. The scanner name replaces {name} in the
+// directory; without the placeholder it is appended as a subdirectory of
+// the configured directory.
+func defaultScannerOutputDir(name string) (string, error) {
+	if outputDirFlag != "" {
+		return expandOutputDir(outputDirFlag, name, false), nil
+	}
+	dir, err := readConfigValue(defaultOutputDirKey)
+	if err != nil {
+		return "", err
+	}
+	if dir == "" {
+		return "./" + name, nil
+	}
+	return expandOutputDir(dir, name, true), nil
+}
+
+// expandOutputDir substitutes the scanner name into an output directory
+// template and expands a leading ~ to the home directory. appendName adds
+// the name as a subdirectory when the template has no {name}.
+func expandOutputDir(dir, name string, appendName bool) string {
+	if strings.Contains(dir, outputDirNamePlaceholder) {
+		dir = strings.ReplaceAll(dir, outputDirNamePlaceholder, name)
+	} else if appendName {
+		dir = filepath.Join(dir, name)
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, dir[1:])
+		}
+	}
+	return dir
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestOutputDirPromptDefault(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	tests := []struct {
+		name      string
+		flag      string
+		config    string
+		outputDir string
+		want      string
+	}{
+		{name: "nothing configured", want: "./my-scanner"},
+		{name: "configured directory", config: "/srv/scanners", want: filepath.Join("/srv/scanners", "my-scanner")},
+		{name: "configured template", config: "/srv/{name}-src", want: "/srv/my-scanner-src"},
+		{name: "configured home directory", config: "~/scanners", want: filepath.Join(home, "scanners", "my-scanner")},
+		{name: "flag over the config", flag: "out", config: "/srv/scanners", want: "out"},
+		{name: "flag template", flag: "build/{name}", want: "build/my-scanner"},
+		{name: "directory already chosen", config: "/srv/scanners", outputDir: "./elsewhere", want: "./elsewhere"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempConfigDir(t)
+			saved := outputDirFlag
+			outputDirFlag = tt.flag
+			t.Cleanup(func() { outputDirFlag = saved })
+			if tt.config != "" {
+				if err := setDefaultOutputDir(tt.config); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			prompt, err := newOutputDirPrompt(&ScannerCreationData{Name: "my-scanner", OutputDir: tt.outputDir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if prompt.Default != tt.want {
+				t.Errorf("output directory default = %q, want %q", prompt.Default, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetDefaultOutputDirRejectsEmpty(t *testing.T) {
+	useTempConfigDir(t)
+	if err := setDefaultOutputDir("  "); err == nil {
+		t.Error("setDefaultOutputDir accepted a blank directory")
+	}
+}
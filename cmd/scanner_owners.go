@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Ownership file formats accepted by --owners-format
+const (
+	ownersCodeowners = "codeowners"
+	ownersOwners     = "owners"
+)
+
+// ownersPlaceholderTeam is written next to the owner in CODEOWNERS as a
+// reminder to add the owning team
+const ownersPlaceholderTeam = "your-org/your-team"
+
+// validateOwnersFormat checks the value of --owners-format
+func validateOwnersFormat(format string) error {
+	if format != ownersCodeowners && format != ownersOwners {
+		return fmt.Errorf("invalid owners format '%s' (expected codeowners or owners)", format)
+	}
+	return nil
+}
+
+// ownersFileName returns the ownership file name for the scanner's format
+func ownersFileName(scanner *ScannerCreationData) string {
+	if scanner.OwnersFormat == ownersOwners {
+		return "OWNERS"
+	}
+	return "CODEOWNERS"
+}
+
+// generateOwnersFile generates the ownership file for the scanner directory.
+// GitHub only reads CODEOWNERS from the repository root, so that format is
+// a snippet to copy there; OWNERS files apply to the directory they are in.
+func generateOwnersFile(scanner *ScannerCreationData) string {
+	owner := strings.TrimSpace(scanner.Owner)
+
+	if scanner.OwnersFormat == ownersOwners {
+		return fmt.Sprintf(`# Owners of the %s scanner
+approvers:
+  - %s
+  - your-team # TODO: replace with the owning team
+reviewers:
+  - your-team
+`, scanner.Name, strings.TrimPrefix(owner, "@"))
+	}
+
+	// CODEOWNERS entries are GitHub users, teams or email addresses
+	if !strings.Contains(owner, "@") {
+		owner = "@" + owner
+	}
+	dir := filepath.ToSlash(filepath.Clean(valueOr(scanner.OutputDir, scanner.Name)))
+	return fmt.Sprintf(`# Owners of the %s scanner
+# Add this entry to the repository's CODEOWNERS file, with the path
+# relative to the repository root, and replace the placeholder team.
+/%s/ %s @%s
+`, scanner.Name, strings.TrimPrefix(dir, "/"), owner, ownersPlaceholderTeam)
+}
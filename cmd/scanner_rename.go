@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var renameDirFlag bool
+
+var scannerRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new> [dir]",
+	Short: "Rename a scanner",
+	Long: `Rename a scanner's identifier everywhere it appears: the spec name, the
+source-type file and image, package/module names and class names. The
+directory defaults to ./<old> if it exists, otherwise the current directory.
+
+Only whole identifiers are replaced, so a name that is part of another
+identifier (such as ReadFile for a scanner named read-file) is kept. If any
+file cannot be written, every change is undone.`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldName, newName := args[0], args[1]
+		dir := defaultScannerDir(oldName)
+		if len(args) > 2 {
+			dir = args[2]
+		}
+
+		if err := validateScannerName(newName); err != nil {
+			return err
+		}
+		if oldName == newName {
+			return fmt.Errorf("new name is the same as the old name")
+		}
+
+		spec, err := LoadSpec(dir)
+		if err != nil {
+			return err
+		}
+		if spec.Name != toSpecName(oldName) {
+			return fmt.Errorf("spec name '%s' does not match scanner '%s' (expected '%s')", spec.Name, oldName, toSpecName(oldName))
+		}
+
+		if err := checkScannerNameAvailable(cmd.Context(), newName); err != nil {
+			return err
+		}
+
+		result, err := renameScanner(dir, oldName, newName)
+		if err != nil {
+			return err
+		}
+
+		if renameDirFlag {
+			newDir, err := renameScannerDir(dir, oldName, newName)
+			if err != nil {
+				return err
+			}
+			result.Dir = newDir
+		}
+
+		printRenameSummary(result, oldName, newName)
+		return nil
+	},
+}
+
+// renameResult describes the changes made by renameScanner
+type renameResult struct {
+	Dir          string
+	Replacements map[string]int    // file -> number of replaced occurrences
+	RenamedFiles map[string]string // old file -> new file
+}
+
+// scannerNameForm is a variant of a scanner name and the affixes the
+// templates put around it. An occurrence is only replaced when it stands
+// alone or with one of these affixes, so a name inside an unrelated
+// identifier (ReadFile in ioutil.ReadFile for a scanner named read-file) is
+// left untouched.
+type scannerNameForm struct {
+	name      string
+	prefixes  []string // optional, e.g. New in NewMyScannerScanner
+	suffixes  []string // one is required; "" allows the bare name
+	wordChars string   // characters besides letters and digits that continue an identifier
+}
+
+// scannerNameForms returns the variants derived from a scanner name, in
+// the order they are replaced
+func scannerNameForms(name string) []scannerNameForm {
+	return []scannerNameForm{
+		{name: toSpecName(name), suffixes: []string{""}, wordChars: "_"},
+		{name: strings.ToLower(toSpecName(name)), suffixes: []string{""}, wordChars: "_"},
+		// The PascalCase form only occurs in class names
+		{name: toPascalCase(name), prefixes: []string{"New"}, suffixes: []string{"Scanner"}, wordChars: "_"},
+		{name: name, suffixes: []string{"", "-scanner", "-Scanner"}, wordChars: "_-"},
+	}
+}
+
+// isWordByte reports whether b continues an identifier of the form
+func (f scannerNameForm) isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || strings.IndexByte(f.wordChars, b) >= 0
+}
+
+// boundaryAt reports whether the identifier ends before content[i]
+func (f scannerNameForm) boundaryAt(content []byte, i int) bool {
+	return i < 0 || i >= len(content) || !f.isWordByte(content[i])
+}
+
+// matchesAt reports whether the name at content[start:end] is a whole
+// identifier, allowing for the form's prefixes and suffixes
+func (f scannerNameForm) matchesAt(content []byte, start, end int) bool {
+	before := f.boundaryAt(content, start-1)
+	for _, prefix := range f.prefixes {
+		if !before && bytes.HasSuffix(content[:start], []byte(prefix)) {
+			before = f.boundaryAt(content, start-len(prefix)-1)
+		}
+	}
+	if !before {
+		return false
+	}
+	for _, suffix := range f.suffixes {
+		if bytes.HasPrefix(content[end:], []byte(suffix)) && f.boundaryAt(content, end+len(suffix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceNameForm replaces every whole occurrence of from with to and
+// returns the new content and the number of replacements
+func replaceNameForm(content []byte, from, to scannerNameForm) ([]byte, int) {
+	var out []byte
+	count, last := 0, 0
+	for i := 0; i < len(content); {
+		at := bytes.Index(content[i:], []byte(from.name))
+		if at < 0 {
+			break
+		}
+		start, end := i+at, i+at+len(from.name)
+		if !from.matchesAt(content, start, end) {
+			i = start + 1
+			continue
+		}
+		out = append(append(out, content[last:start]...), to.name...)
+		last, i = end, end
+		count++
+	}
+	if count == 0 {
+		return content, 0
+	}
+	return append(out, content[last:]...), count
+}
+
+// skipRenameDirs are directories that never contain scanner sources
+var skipRenameDirs = map[string]bool{
+	".git": true, "node_modules": true, "target": true, "bin": true,
+	"obj": true, "__pycache__": true, ".venv": true, "venv": true,
+}
+
+// renameScanner rewrites every occurrence of oldName (in all of its derived
+// forms) under dir and renames the source-type file. All new contents are
+// computed and checked before anything is written, and the rename is all or
+// nothing: if a file cannot be written, every file is restored.
+func renameScanner(dir, oldName, newName string) (*renameResult, error) {
+	result := &renameResult{
+		Dir:          dir,
+		Replacements: make(map[string]int),
+		RenamedFiles: make(map[string]string),
+	}
+
+	oldForms := scannerNameForms(oldName)
+	newForms := scannerNameForms(newName)
+	updated := make(map[string][]byte) // relative path -> new content
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && skipRenameDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(data, 0) >= 0 {
+			return nil // Skip binary files
+		}
+
+		content := data
+		count := 0
+		for i := range oldForms {
+			var n int
+			content, n = replaceNameForm(content, oldForms[i], newForms[i])
+			count += n
+		}
+		if count == 0 {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+		if strings.HasSuffix(path, ".json") && !json.Valid(content) {
+			return fmt.Errorf("renaming would produce invalid JSON in %s", rel)
+		}
+		updated[rel] = content
+		result.Replacements[rel] = count
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The renamed spec must still parse and keep a valid name
+	if content, ok := updated[specFileName]; ok {
+		spec, err := parseSpec(content)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Name != toSpecName(newName) {
+			return nil, fmt.Errorf("renamed spec has unexpected name '%s'", spec.Name)
+		}
+	}
+
+	// The source-type file is renamed first, so its new content is written
+	// under the new name and the rename is undone with the rest
+	oldSourceType := oldName + "-source-type.json"
+	newSourceType := newName + "-source-type.json"
+	renamedSourceType := false
+	if _, err := os.Stat(filepath.Join(dir, oldSourceType)); err == nil {
+		if _, err := os.Stat(filepath.Join(dir, newSourceType)); err == nil {
+			return nil, fmt.Errorf("cannot rename source-type file: %s already exists", newSourceType)
+		}
+		if err := os.Rename(filepath.Join(dir, oldSourceType), filepath.Join(dir, newSourceType)); err != nil {
+			return nil, fmt.Errorf("failed to rename source-type file: %w", err)
+		}
+		renamedSourceType = true
+		if content, ok := updated[oldSourceType]; ok {
+			delete(updated, oldSourceType)
+			updated[newSourceType] = content
+		}
+		result.RenamedFiles[oldSourceType] = newSourceType
+	}
+
+	files := make([]GeneratedFile, 0, len(updated))
+	for rel, content := range updated {
+		files = append(files, GeneratedFile{Name: rel, Bytes: content})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	if err := writeFilesWithRollback(dir, files, func(GeneratedFile, time.Duration) {}); err != nil {
+		if renamedSourceType {
+			if renameErr := os.Rename(filepath.Join(dir, newSourceType), filepath.Join(dir, oldSourceType)); renameErr != nil {
+				fmt.Fprintf(os.Stderr, glyphs("⚠️  Could not rename %s back to %s: %v\n"), newSourceType, oldSourceType, renameErr)
+			}
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// renameScannerDir renames dir when its base name matches the old scanner name
+func renameScannerDir(dir, oldName, newName string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if filepath.Base(absDir) != oldName {
+		fmt.Printf(glyphs("⚠️  Directory '%s' is not named '%s', leaving it unchanged\n"), dir, oldName)
+		return dir, nil
+	}
+
+	newDir := filepath.Join(filepath.Dir(absDir), newName)
+	if _, err := os.Stat(newDir); err == nil {
+		return "", fmt.Errorf("cannot rename directory: %s already exists", newDir)
+	}
+	if err := os.Rename(absDir, newDir); err != nil {
+		return "", fmt.Errorf("failed to rename directory: %w", err)
+	}
+	return newDir, nil
+}
+
+// checkScannerNameAvailable verifies that no registered source type already
+// uses name. If the API is not reachable the check is skipped with a warning.
+func checkScannerNameAvailable(ctx context.Context, name string) error {
+	client, err := getAPIClient()
+	if err != nil {
+		fmt.Printf(glyphs("⚠️  Skipping registered name check: %v\n"), err)
+		return nil
+	}
+
+	sourceTypes, err := client.GetAllSourceTypes(ctx)
+	if err != nil {
+		fmt.Printf(glyphs("⚠️  Skipping registered name check: %v\n"), err)
+		return nil
+	}
+
+	for _, st := range sourceTypes {
+		if st.TypeName == name {
+			return fmt.Errorf("scanner name '%s' already exists", name)
+		}
+	}
+	return nil
+}
+
+// defaultScannerDir returns ./<name> if it exists, otherwise the current directory
+func defaultScannerDir(name string) string {
+	if info, err := os.Stat(name); err == nil && info.IsDir() {
+		return name
+	}
+	return "."
+}
+
+// printRenameSummary prints the files changed by a rename
+func printRenameSummary(result *renameResult, oldName, newName string) {
+	fmt.Printf(glyphs("✅ Renamed scanner '%s' to '%s'\n"), oldName, newName)
+	fmt.Println()
+	printRenameChanges(result)
+
+	fmt.Println()
+	fmt.Println(glyphs("⚠️  Queue and table names are derived from the scanner name. Any deployed"))
+	fmt.Printf("   instance of '%s' must be re-registered as '%s'.\n", oldName, newName)
+}
+
+// printRenameChanges lists the rewritten and renamed files of a rename
+func printRenameChanges(result *renameResult) {
+	files := make([]string, 0, len(result.Replacements))
+	for file := range result.Replacements {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		fmt.Printf(glyphs("  ✏️  %s (%d occurrence(s))\n"), file, result.Replacements[file])
+	}
+	for oldFile, newFile := range result.RenamedFiles {
+		fmt.Printf(glyphs("  📄 %s → %s\n"), oldFile, newFile)
+	}
+	fmt.Printf(glyphs("  📁 Directory: %s\n"), result.Dir)
+}
+
+func init() {
+	scannerRenameCmd.Flags().BoolVar(&renameDirFlag, "rename-dir", false, "Also rename the scanner directory when it is named after the old scanner")
+
+	scannerCmd.AddCommand(scannerRenameCmd)
+}
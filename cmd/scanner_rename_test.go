@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+// writeTestScanner generates a scanner into a temporary directory and
+// returns the directory
+func writeTestScanner(t *testing.T, name, language string) string {
+	t.Helper()
+	result, err := generateScanner(&ScannerCreationData{
+		Name:               name,
+		DisplayName:        "Test Scanner",
+		Version:            "1.0.0",
+		Language:           language,
+		SupportedScanTypes: []string{"access"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), name)
+	for _, file := range result.Files {
+		writeTestFile(t, filepath.Join(dir, file.Name), string(file.Bytes))
+	}
+	return dir
+}
+
+// readTestTree returns the contents of every file under dir by relative path
+func readTestTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files[rel] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// unrelatedIdentifiers contain the name read-file in its derived forms
+// without being the scanner's name
+const unrelatedIdentifiers = `data, err := ioutil.ReadFile(path)
+func ReadFiles() {}
+readFileScanner := MyReadFileScanner{}
+thread-file-scanner, read-files, read-file-scanners
+READ_FILES, SPREAD_FILE, read_file_1
+`
+
+func TestRenameScannerLeavesUnrelatedIdentifiers(t *testing.T) {
+	dir := writeTestScanner(t, "read-file", "go")
+	writeTestFile(t, filepath.Join(dir, "helpers.go"), unrelatedIdentifiers+"// read-file read-file\n")
+
+	result, err := renameScanner(dir, "read-file", "other-scanner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := readTestTree(t, dir)
+
+	if got := files["helpers.go"]; got != unrelatedIdentifiers+"// other-scanner other-scanner\n" {
+		t.Errorf("helpers.go after the rename:\n%s", got)
+	}
+	if !strings.Contains(files["scanner.go"], `ioutil.ReadFile("scannerSpecification.json")`) {
+		t.Error("scanner.go: ioutil.ReadFile was renamed")
+	}
+	for file, want := range map[string][]string{
+		"scanner.go":                     {"type OtherScannerScanner struct", "func NewOtherScannerScanner("},
+		"go.mod":                         {"module other-scanner-scanner"},
+		specFileName:                     {`"name": "OTHER_SCANNER"`},
+		"other-scanner-source-type.json": {"access-analyzer/other-scanner-scanner:latest"},
+	} {
+		for _, w := range want {
+			if !strings.Contains(files[file], w) {
+				t.Errorf("%s does not contain %q", file, w)
+			}
+		}
+	}
+	for file, content := range files {
+		if file != "helpers.go" && (strings.Contains(content, "ReadFileScanner") || strings.Contains(content, "read-file-scanner")) {
+			t.Errorf("%s still names the old scanner", file)
+		}
+	}
+	if _, ok := files["read-file-source-type.json"]; ok {
+		t.Error("the old source-type file still exists")
+	}
+	if result.RenamedFiles["read-file-source-type.json"] != "other-scanner-source-type.json" {
+		t.Errorf("renamed files = %v", result.RenamedFiles)
+	}
+}
+
+func TestRenameScannerIsAllOrNothing(t *testing.T) {
+	dir := writeTestScanner(t, "read-file", "python")
+	// Written last, and too large for the file size limit below
+	writeTestFile(t, filepath.Join(dir, "zz-notes.md"), "read-file\n"+strings.Repeat("x", testFileSizeLimit))
+	before := readTestTree(t, dir)
+
+	var err error
+	withFileSizeLimit(t, func() {
+		_, err = renameScanner(dir, "read-file", "other-scanner")
+	})
+	if !errors.Is(err, syscall.EFBIG) {
+		t.Fatalf("renameScanner() = %v, want the failed write", err)
+	}
+
+	after := readTestTree(t, dir)
+	for file, content := range before {
+		if after[file] != content {
+			t.Errorf("%s was not restored", file)
+		}
+	}
+	for file := range after {
+		if _, ok := before[file]; !ok {
+			t.Errorf("failed rename left %s behind", file)
+		}
+	}
+}
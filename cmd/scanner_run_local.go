@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	runLocalConfigFlag      string
+	runLocalInstallDepsFlag bool
+)
+
+// localServiceHostVars are the service host variables that the generated
+// Dockerfile points at compose service names; run-local points them at
+// localhost instead
+var localServiceHostVars = []string{"RABBITMQ_HOST", "APP_DB_HOST", "COLLECTION_DB_HOST"}
+
+// localRuntime is how a scanner language is run without Docker
+type localRuntime struct {
+	interpreters []string // tried in order
+	installHint  string
+	depsFile     string
+	installDeps  func(interpreter string) []string
+}
+
+// localRuntimes are the languages run-local supports, by language
+var localRuntimes = map[string]localRuntime{
+	"python": {
+		interpreters: []string{"python3", "python"},
+		installHint:  "install Python 3 from https://www.python.org/downloads/",
+		depsFile:     "requirements.txt",
+		installDeps: func(interpreter string) []string {
+			return []string{interpreter, "-m", "pip", "install", "-r", "requirements.txt"}
+		},
+	},
+	"javascript": {
+		interpreters: []string{"node"},
+		installHint:  "install Node.js from https://nodejs.org/",
+		depsFile:     "package.json",
+		installDeps: func(string) []string {
+			return []string{"npm", "install"}
+		},
+	},
+}
+
+var scannerRunLocalCmd = &cobra.Command{
+	Use:   "run-local [dir]",
+	Short: "Run a Python or Node.js scanner without Docker",
+	Long: `Run the scanner in dir (defaults to the current directory) directly with
+its interpreter ('python scanner.py' or 'node scanner.js'), streaming its
+output, for a faster edit-run loop than 'nwx aa scanner build'.
+
+The scanner gets the environment variables of its Dockerfile, with the
+RabbitMQ, app database and collection database hosts pointed at localhost.
+Variables already set in your environment are kept, so services elsewhere
+can be used by exporting e.g. APP_DB_HOST.
+
+--config-file is checked against the specification (see 'nwx aa scanner
+test-connection') and its absolute path is passed to the scanner in
+$SCANNER_CONFIG. The generated Python and JavaScript scanners then run one
+scan with that config, expanding ${VAR} references such as those in
+config/config.env.json, instead of waiting for jobs on RabbitMQ. With
+--install-deps, requirements.txt or package.json is installed first.
+
+  nwx aa scanner run-local ./my-scanner --config-file config/config.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		scanner, err := loadScannerDir(dir)
+		if err != nil {
+			return err
+		}
+		runtime, ok := localRuntimes[scanner.Language]
+		if !ok {
+			if scanner.Language == "" {
+				return fmt.Errorf("no scanner entry point found in %s", dir)
+			}
+			return fmt.Errorf("run-local supports Python and JavaScript scanners, not %s; use 'nwx aa scanner build' instead", scanner.Language)
+		}
+
+		interpreter, err := findInterpreter(runtime)
+		if err != nil {
+			return err
+		}
+
+		env := os.Environ()
+		if runLocalConfigFlag != "" {
+			configFile, err := checkRunLocalConfig(dir, runLocalConfigFlag)
+			if err != nil {
+				return err
+			}
+			env = append(env, "SCANNER_CONFIG="+configFile)
+		}
+		dockerEnv, err := readDockerfileEnv(filepath.Join(dir, "Dockerfile"))
+		if err != nil {
+			return err
+		}
+		env = append(env, localScannerEnv(dockerEnv)...)
+
+		if runLocalInstallDepsFlag {
+			if _, err := os.Stat(filepath.Join(dir, runtime.depsFile)); err == nil {
+				install := runtime.installDeps(interpreter)
+				fmt.Printf(glyphs("🔧 Installing dependencies: %s\n"), strings.Join(install, " "))
+				if err := runInDir(cmd.Context(), dir, env, install...); err != nil {
+					return fmt.Errorf("installing dependencies failed: %w", err)
+				}
+			}
+		}
+
+		entryPoint := scannerEntryPoint(scanner.Language)
+		fmt.Printf(glyphs("🚀 Running %s %s in %s\n"), filepath.Base(interpreter), entryPoint, dir)
+		if err := runInDir(cmd.Context(), dir, env, interpreter, entryPoint); err != nil {
+			return fmt.Errorf("scanner exited: %w", err)
+		}
+		return nil
+	},
+}
+
+// findInterpreter returns the path of the first interpreter of runtime
+// found on PATH
+func findInterpreter(runtime localRuntime) (string, error) {
+	for _, name := range runtime.interpreters {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not installed or not on PATH; %s", strings.Join(runtime.interpreters, " or "), runtime.installHint)
+}
+
+// scannerEntryPoint returns the entry point file generated for a language
+func scannerEntryPoint(language string) string {
+	for _, lf := range languageFiles {
+		if lf.language == language {
+			return lf.file
+		}
+	}
+	return ""
+}
+
+// checkRunLocalConfig checks a scanner config against the specification in
+// dir and returns its absolute path
+func checkRunLocalConfig(dir, configFile string) (string, error) {
+	spec, err := LoadSpec(dir)
+	if err != nil {
+		return "", err
+	}
+	config, err := readScannerConfig(configFile)
+	if err != nil {
+		return "", err
+	}
+	findings := validateScannerConfig(spec, config)
+	if errors, _ := countFindings(findings); errors > 0 {
+		printFindings(findings)
+		return "", fmt.Errorf("%s does not match the specification", configFile)
+	}
+	return filepath.Abs(configFile)
+}
+
+// readDockerfileEnv returns the ENV NAME=VALUE lines of a Dockerfile in
+// order. A missing Dockerfile has no variables.
+func readDockerfileEnv(path string) ([]EnvVar, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var vars []EnvVar
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		rest, ok := strings.CutPrefix(line, "ENV ")
+		if !ok {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimSpace(rest), "=")
+		if !ok || !envVarNamePattern.MatchString(name) {
+			continue
+		}
+		vars = setEnvVar(vars, EnvVar{Name: name, Value: strings.Trim(value, `"`)})
+	}
+	return vars, scanner.Err()
+}
+
+// localScannerEnv returns the Dockerfile variables to add to the
+// environment: the ones not already set, with service hosts on localhost
+func localScannerEnv(vars []EnvVar) []string {
+	var env []string
+	for _, v := range vars {
+		if _, set := os.LookupEnv(v.Name); set {
+			continue
+		}
+		if contains(localServiceHostVars, v.Name) {
+			v.Value = "localhost"
+		}
+		env = append(env, v.Name+"="+v.Value)
+	}
+	return env
+}
+
+// runInDir runs a command in dir with env, streaming its output
+func runInDir(ctx context.Context, dir string, env []string, args ...string) error {
+	c := exec.CommandContext(ctx, args[0], args[1:]...)
+	c.Dir = dir
+	c.Env = env
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+func init() {
+	scannerRunLocalCmd.Flags().StringVar(&runLocalConfigFlag, "config-file", "", "Scanner configuration file, passed to the scanner in $SCANNER_CONFIG")
+	scannerRunLocalCmd.Flags().BoolVar(&runLocalInstallDepsFlag, "install-deps", false, "Install requirements.txt or package.json before running")
+
+	scannerCmd.AddCommand(scannerRunLocalCmd)
+}
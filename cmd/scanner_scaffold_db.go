@@ -0,0 +1,560 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var scannerScaffoldDBCmd = &cobra.Command{
+	Use:   "scaffold-db [dir]",
+	Short: "Generate the code that saves scan results to ClickHouse",
+	Long: `Replace the empty database save method of a generated scanner in dir
+(defaults to the current directory) with a working implementation: results
+are converted to the column types of the spec's outputSchema and inserted
+into the scan's table in batches of 1000, using the ClickHouse client the
+scanner already depends on.
+
+A scan_id column missing from a result is filled with the scan's ID, and
+a missing timestamp column with the time the results are saved.
+
+Only the untouched generated stub is replaced, so the command refuses to
+run on a save method that has already been written. Rerun it on a fresh
+copy of the method after changing the outputSchema.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+
+		spec, err := LoadSpec(dir)
+		if err != nil {
+			return err
+		}
+		tables := dbScaffoldTables(spec)
+		if len(tables) == 0 {
+			return fmt.Errorf("the specification has no outputSchema to generate inserts from")
+		}
+
+		file, language := "", ""
+		for _, lf := range languageFiles {
+			if _, err := os.Stat(filepath.Join(dir, lf.file)); err == nil {
+				file, language = lf.file, lf.language
+				break
+			}
+		}
+		if file == "" {
+			return fmt.Errorf("no generated scanner entry point found in %s", dir)
+		}
+
+		path := filepath.Join(dir, file)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		httpProtocol := language == "python" && pythonUsesHTTPClient(dir)
+		updated, err := scaffoldDBSave(string(data), language, tables, httpProtocol)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := writeFileAtomic(path, []byte(updated), info.Mode().Perm()); err != nil {
+			return err
+		}
+
+		fmt.Printf(glyphs("✅ Generated the database save code in %s\n"), file)
+		for _, t := range tables {
+			fmt.Printf("  %-16s %d column(s)\n", t.ScanType, len(t.Columns))
+		}
+		return nil
+	},
+}
+
+// dbScaffoldTable is the column list inserted for one scan type
+type dbScaffoldTable struct {
+	ScanType string
+	Columns  []SpecColumn
+}
+
+// dbScaffoldTables returns the output columns of each scan type in the spec
+func dbScaffoldTables(spec *ScannerSpec) []dbScaffoldTable {
+	var tables []dbScaffoldTable
+	for _, key := range sortedKeys(spec.OutputSchema) {
+		if scanType, ok := outputSchemaScanTypes[key]; ok && len(spec.OutputSchema[key].Columns) > 0 {
+			tables = append(tables, dbScaffoldTable{scanType, spec.OutputSchema[key].Columns})
+		}
+	}
+	return tables
+}
+
+// pythonUsesHTTPClient reports whether a Python scanner was generated for
+// the ClickHouse HTTP protocol (clickhouse-connect)
+func pythonUsesHTTPClient(dir string) bool {
+	requirements, _ := readOptionalFile(filepath.Join(dir, "requirements.txt"))
+	return strings.Contains(requirements, "clickhouse-connect")
+}
+
+// dbSaveStubPattern matches the TODO body of the generated save method, with
+// the trailing 'pass' of the Python template or 'return nil' of the Go one
+var dbSaveStubPattern = regexp.MustCompile(`(?m)^([ \t]*)(?:#|//) TODO: Implement database saving logic\n[ \t]*(?:#|//) See scanner framework documentation for examples\n(?:[ \t]*(?:pass|return nil)\n)?`)
+
+// scaffoldDBSave replaces the save method stub in the content of a generated
+// entry point with an implementation for language, adding the imports the
+// implementation needs
+func scaffoldDBSave(content, language string, tables []dbScaffoldTable, httpProtocol bool) (string, error) {
+	match := dbSaveStubPattern.FindStringSubmatchIndex(content)
+	if match == nil {
+		return "", fmt.Errorf("the database save method is not the generated stub; it may already be implemented")
+	}
+	indent := content[match[2]:match[3]]
+
+	var body string
+	switch language {
+	case "python":
+		body = pythonDBSave(tables, httpProtocol)
+	case "javascript":
+		body = javaScriptDBSave(tables)
+	case "go":
+		body = goDBSave(tables)
+		content = addGoImports(content, `"context"`, `"github.com/ClickHouse/clickhouse-go/v2"`)
+	case "java":
+		body = javaDBSave(tables)
+	case "c#":
+		body = cSharpDBSave(tables)
+		content = addCSharpUsings(content, "ClickHouse.Client.ADO", "ClickHouse.Client.Copy")
+	default:
+		return "", fmt.Errorf("unsupported language '%s'", language)
+	}
+
+	// Imports may have moved the stub
+	match = dbSaveStubPattern.FindStringIndex(content)
+	return content[:match[0]] + indentLines(body, indent) + content[match[1]:], nil
+}
+
+// indentLines prefixes every non-empty line of text with indent
+func indentLines(text, indent string) string {
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// addGoImports adds imports missing from the import block of a Go file,
+// before the first import that sorts after them
+func addGoImports(content string, imports ...string) string {
+	start := strings.Index(content, "import (\n")
+	if start < 0 {
+		return content
+	}
+	start += len("import (\n")
+	end := start + strings.Index(content[start:], ")")
+
+	lines := strings.SplitAfter(content[start:end], "\n")
+	for _, imp := range imports {
+		if strings.Contains(content[start:end], "\t"+imp+"\n") {
+			continue
+		}
+		i := 0
+		for i < len(lines) && strings.TrimSpace(lines[i]) != "" && strings.TrimSpace(lines[i]) < imp {
+			i++
+		}
+		lines = append(lines[:i], append([]string{"\t" + imp + "\n"}, lines[i:]...)...)
+	}
+	return content[:start] + strings.Join(lines, "") + content[end:]
+}
+
+// addCSharpUsings adds using directives missing from a C# file
+func addCSharpUsings(content string, namespaces ...string) string {
+	for i := len(namespaces) - 1; i >= 0; i-- {
+		directive := "using " + namespaces[i] + ";\n"
+		if !strings.Contains(content, directive) {
+			content = directive + content
+		}
+	}
+	return content
+}
+
+// dbScaffoldHeader is the comment opening every generated implementation
+const dbScaffoldHeader = "Generated from the outputSchema by 'nwx aa scanner scaffold-db'"
+
+// columnList formats the columns of each table with format, which receives
+// the scan type and the joined column entries
+func columnList(tables []dbScaffoldTable, entry func(SpecColumn) string, table func(scanType, entries string) string, sep string) string {
+	var parts []string
+	for _, t := range tables {
+		var entries []string
+		for _, col := range t.Columns {
+			entries = append(entries, entry(col))
+		}
+		parts = append(parts, table(t.ScanType, strings.Join(entries, ", ")))
+	}
+	return strings.Join(parts, sep)
+}
+
+func pythonDBSave(tables []dbScaffoldTable, httpProtocol bool) string {
+	columns := columnList(tables,
+		func(c SpecColumn) string { return fmt.Sprintf("('%s', '%s')", c.Name, c.Type) },
+		func(scanType, entries string) string { return fmt.Sprintf("    '%s': [%s],", scanType, entries) },
+		"\n")
+
+	connect := `client = Client(host=db_config['host'], port=int(db_config['port']),
+                database=db_config['database'], user=db_config['user'],
+                password=db_config['password'])`
+	insert := `client.execute(f"INSERT INTO {table} ({', '.join(names)}) VALUES", batch)`
+	if httpProtocol {
+		connect = `client = clickhouse_connect.get_client(host=db_config['host'], port=int(db_config['port']),
+                                       database=db_config['database'], username=db_config['user'],
+                                       password=db_config['password'])`
+		insert = `client.insert(table, batch, column_names=names)`
+	}
+
+	return fmt.Sprintf(`# %s
+columns_by_scan_type = {
+%s
+}
+table = self.scan_table_name
+columns = next((cols for scan_type, cols in columns_by_scan_type.items()
+                if table.endswith('_' + scan_type)), next(iter(columns_by_scan_type.values())))
+
+def to_db(value, kind):
+    if value is None:
+        return None
+    if kind == 'integer':
+        return int(value)
+    if kind == 'number':
+        return float(value)
+    if kind == 'boolean':
+        return bool(value)
+    if kind == 'timestamp' and isinstance(value, str):
+        return datetime.fromisoformat(value.replace('Z', '+00:00'))
+    if kind == 'json' and not isinstance(value, str):
+        return json.dumps(value)
+    if kind == 'string':
+        return str(value)
+    return value
+
+names = [name for name, _ in columns]
+now = datetime.utcnow()
+rows = []
+for result in self.results:
+    row = dict(result)
+    row.setdefault('scan_id', self.scan_id)
+    rows.append([to_db(row.get(name, now if kind == 'timestamp' else None), kind) for name, kind in columns])
+
+%s
+batch_size = 1000
+for start in range(0, len(rows), batch_size):
+    batch = rows[start:start + batch_size]
+    %s
+logger.info(f"Saved {len(rows)} results to {table}")
+`, dbScaffoldHeader, columns, connect, insert)
+}
+
+func javaScriptDBSave(tables []dbScaffoldTable) string {
+	columns := columnList(tables,
+		func(c SpecColumn) string { return fmt.Sprintf("['%s', '%s']", c.Name, c.Type) },
+		func(scanType, entries string) string { return fmt.Sprintf("    %s: [%s],", scanType, entries) },
+		"\n")
+
+	return fmt.Sprintf(`// %s
+const columnsByScanType = {
+%s
+};
+const table = this.scanTableName;
+const scanType = Object.keys(columnsByScanType).find((type) => table.endsWith('_' + type));
+const columns = columnsByScanType[scanType] || Object.values(columnsByScanType)[0];
+
+const toDb = (value, kind) => {
+    if (value === undefined || value === null) return null;
+    switch (kind) {
+        case 'integer': return Math.trunc(Number(value));
+        case 'number': return Number(value);
+        case 'boolean': return Boolean(value);
+        case 'timestamp': return new Date(value).toISOString();
+        case 'json': return typeof value === 'string' ? value : JSON.stringify(value);
+        default: return String(value);
+    }
+};
+
+const now = new Date().toISOString();
+const rows = this.results.map((result) => {
+    const row = { scan_id: this.scanId, ...result };
+    const values = {};
+    for (const [name, kind] of columns) {
+        values[name] = toDb(name in row ? row[name] : (kind === 'timestamp' ? now : null), kind);
+    }
+    return values;
+});
+
+const client = createClient({
+    host: 'http://' + dbConfig.host + ':' + dbConfig.port,
+    database: dbConfig.database,
+    username: dbConfig.username,
+    password: dbConfig.password,
+    clickhouse_settings: { date_time_input_format: 'best_effort' },
+});
+try {
+    const batchSize = 1000;
+    for (let start = 0; start < rows.length; start += batchSize) {
+        await client.insert({ table, values: rows.slice(start, start + batchSize), format: 'JSONEachRow' });
+    }
+} finally {
+    await client.close();
+}
+console.log('Saved', rows.length, 'results to', table);
+`, dbScaffoldHeader, columns)
+}
+
+func goDBSave(tables []dbScaffoldTable) string {
+	columns := columnList(tables,
+		func(c SpecColumn) string { return fmt.Sprintf("{%q, %q}", c.Name, c.Type) },
+		func(scanType, entries string) string { return fmt.Sprintf("\t%q: {%s},", scanType, entries) },
+		"\n")
+
+	return fmt.Sprintf(`// %s
+columnsByScanType := map[string][][2]string{
+%s
+}
+table := s.scanTableName
+columns := columnsByScanType[%q]
+for scanType, cols := range columnsByScanType {
+	if strings.HasSuffix(table, "_"+scanType) {
+		columns = cols
+	}
+}
+
+toDB := func(value interface{}, kind string) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch kind {
+	case "integer":
+		if n, ok := value.(float64); ok {
+			return int64(n), nil
+		}
+	case "number":
+		if n, ok := value.(int); ok {
+			return float64(n), nil
+		}
+	case "timestamp":
+		if text, ok := value.(string); ok {
+			return time.Parse(time.RFC3339Nano, text)
+		}
+	case "json":
+		if _, ok := value.(string); !ok {
+			data, err := json.Marshal(value)
+			return string(data), err
+		}
+	case "string":
+		return fmt.Sprint(value), nil
+	}
+	return value, nil
+}
+
+options := &clickhouse.Options{
+	Addr: []string{fmt.Sprintf("%%v:%%v", dbConfig["host"], dbConfig["port"])},
+	Auth: clickhouse.Auth{
+		Database: fmt.Sprint(dbConfig["database"]),
+		Username: fmt.Sprint(dbConfig["username"]),
+		Password: fmt.Sprint(dbConfig["password"]),
+	},
+}
+if fmt.Sprint(dbConfig["port"]) == "8123" {
+	options.Protocol = clickhouse.HTTP
+}
+conn, err := clickhouse.Open(options)
+if err != nil {
+	return err
+}
+defer conn.Close()
+
+names := make([]string, len(columns))
+for i, column := range columns {
+	names[i] = column[0]
+}
+insert := "INSERT INTO " + table + " (" + strings.Join(names, ", ") + ")"
+
+ctx := context.Background()
+now := time.Now()
+const batchSize = 1000
+for start := 0; start < len(s.results); start += batchSize {
+	batch, err := conn.PrepareBatch(ctx, insert)
+	if err != nil {
+		return err
+	}
+	for _, result := range s.results[start:min(start+batchSize, len(s.results))] {
+		values := make([]interface{}, len(columns))
+		for i, column := range columns {
+			value, ok := result[column[0]]
+			switch {
+			case ok:
+			case column[0] == "scan_id":
+				value = s.scanID
+			case column[1] == "timestamp":
+				value = now
+			}
+			if values[i], err = toDB(value, column[1]); err != nil {
+				return fmt.Errorf("column %%s: %%w", column[0], err)
+			}
+		}
+		if err := batch.Append(values...); err != nil {
+			return err
+		}
+	}
+	if err := batch.Send(); err != nil {
+		return err
+	}
+}
+fmt.Printf("Saved %%d results to %%s\n", len(s.results), table)
+return nil
+`, dbScaffoldHeader, columns, tables[0].ScanType)
+}
+
+func javaDBSave(tables []dbScaffoldTable) string {
+	columns := columnList(tables,
+		func(c SpecColumn) string { return fmt.Sprintf("{%q, %q}", c.Name, c.Type) },
+		func(scanType, entries string) string {
+			return fmt.Sprintf("columnsByScanType.put(%q, new String[][] {%s});", scanType, entries)
+		},
+		"\n")
+
+	return fmt.Sprintf(`// %s
+Map<String, String[][]> columnsByScanType = new LinkedHashMap<>();
+%s
+String table = scanTableName;
+String[][] columns = columnsByScanType.values().iterator().next();
+for (Map.Entry<String, String[][]> entry : columnsByScanType.entrySet()) {
+    if (table.endsWith("_" + entry.getKey())) {
+        columns = entry.getValue();
+    }
+}
+
+StringJoiner names = new StringJoiner(", ");
+StringJoiner placeholders = new StringJoiner(", ");
+for (String[] column : columns) {
+    names.add(column[0]);
+    placeholders.add("?");
+}
+String sql = "INSERT INTO " + table + " (" + names + ") VALUES (" + placeholders + ")";
+String url = "jdbc:clickhouse://" + dbConfig.get("host") + ":" + dbConfig.get("port") + "/" + dbConfig.get("database");
+ObjectMapper mapper = new ObjectMapper();
+Timestamp now = new Timestamp(System.currentTimeMillis());
+
+try (java.sql.Connection conn = DriverManager.getConnection(url, (String) dbConfig.get("username"), (String) dbConfig.get("password"));
+     PreparedStatement ps = conn.prepareStatement(sql)) {
+    int pending = 0;
+    for (Map<String, Object> result : results) {
+        for (int i = 0; i < columns.length; i++) {
+            String name = columns[i][0];
+            Object value = result.containsKey(name) ? result.get(name)
+                    : name.equals("scan_id") ? scanId
+                    : columns[i][1].equals("timestamp") ? now : null;
+            if (value == null) {
+                ps.setObject(i + 1, null);
+                continue;
+            }
+            switch (columns[i][1]) {
+                case "integer" -> ps.setLong(i + 1, ((Number) value).longValue());
+                case "number" -> ps.setDouble(i + 1, ((Number) value).doubleValue());
+                case "boolean" -> ps.setBoolean(i + 1, (Boolean) value);
+                case "timestamp" -> ps.setTimestamp(i + 1, value instanceof String text
+                        ? Timestamp.from(java.time.Instant.parse(text)) : (Timestamp) value);
+                case "json" -> ps.setString(i + 1, value instanceof String text ? text : mapper.writeValueAsString(value));
+                default -> ps.setString(i + 1, value.toString());
+            }
+        }
+        ps.addBatch();
+        if (++pending == 1000) {
+            ps.executeBatch();
+            pending = 0;
+        }
+    }
+    if (pending > 0) {
+        ps.executeBatch();
+    }
+}
+System.out.println("Saved " + results.size() + " results to " + table);
+`, dbScaffoldHeader, columns)
+}
+
+func cSharpDBSave(tables []dbScaffoldTable) string {
+	columns := columnList(tables,
+		func(c SpecColumn) string { return fmt.Sprintf("(%q, %q)", c.Name, c.Type) },
+		func(scanType, entries string) string {
+			return fmt.Sprintf("    [%q] = new[] { %s },", scanType, entries)
+		},
+		"\n")
+
+	return fmt.Sprintf(`// %s
+var columnsByScanType = new Dictionary<string, (string Name, string Kind)[]>
+{
+%s
+};
+var table = ScanTableName;
+var columns = columnsByScanType.Values.First();
+foreach (var entry in columnsByScanType)
+{
+    if (table.EndsWith("_" + entry.Key))
+    {
+        columns = entry.Value;
+    }
+}
+
+object? ToDb(object? value, string kind) => value switch
+{
+    null => null,
+    _ when kind == "integer" => Convert.ToInt64(value),
+    _ when kind == "number" => Convert.ToDouble(value),
+    _ when kind == "boolean" => Convert.ToBoolean(value),
+    string text when kind == "timestamp" => DateTime.Parse(text, null, System.Globalization.DateTimeStyles.RoundtripKind),
+    string text when kind == "json" => text,
+    _ when kind == "json" => JsonConvert.SerializeObject(value),
+    _ when kind == "string" => value.ToString(),
+    _ => value,
+};
+
+var now = DateTime.UtcNow;
+var rows = new List<object?[]>();
+foreach (var result in _results)
+{
+    var row = new object?[columns.Length];
+    for (var i = 0; i < columns.Length; i++)
+    {
+        if (!result.TryGetValue(columns[i].Name, out var value))
+        {
+            value = columns[i].Name == "scan_id" ? ScanId : columns[i].Kind == "timestamp" ? now : null;
+        }
+        row[i] = ToDb(value, columns[i].Kind);
+    }
+    rows.Add(row);
+}
+
+var connectionString = $"Host={dbConfig["host"]};Port={dbConfig["port"]};Database={dbConfig["database"]};Username={dbConfig["username"]};Password={dbConfig["password"]}";
+using var connection = new ClickHouseConnection(connectionString);
+using var bulkCopy = new ClickHouseBulkCopy(connection)
+{
+    DestinationTableName = table,
+    ColumnNames = columns.Select(c => c.Name).ToArray(),
+    BatchSize = 1000,
+};
+await bulkCopy.InitAsync();
+await bulkCopy.WriteToServerAsync(rows);
+Console.WriteLine($"Saved {rows.Count} results to {table}");
+`, dbScaffoldHeader, columns)
+}
+
+func init() {
+	scannerCmd.AddCommand(scannerScaffoldDBCmd)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var scannerSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of scannerSpecification.json",
+	Long: `Print the JSON Schema that 'nwx aa scanner validate' checks
+scannerSpecification.json against, for use with editors and other tooling.
+The schema is written for draft 2020-12; nwx itself checks only the
+keywords the schema uses and is not a general JSON Schema validator.
+
+  nwx aa scanner schema > scannerSpecification.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, err := os.Stdout.Write(specSchemaJSON)
+		return err
+	},
+}
+
+func init() {
+	scannerCmd.AddCommand(scannerSchemaCmd)
+}
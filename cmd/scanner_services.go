@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// serviceEnvFlag is a creation flag overriding one of the service
+// variables in the generated Dockerfile
+type serviceEnvFlag struct {
+	flag  string // e.g. "rabbitmq-host"
+	env   string // e.g. "RABBITMQ_HOST"
+	port  bool
+	value string
+}
+
+// serviceEnvFlags are the --rabbitmq-host, --app-db-port, ... flags. When
+// unset the Dockerfile keeps its defaults (rabbitmq, postgres-app,
+// clickhouse and their standard ports).
+var serviceEnvFlags = []*serviceEnvFlag{
+	{flag: "rabbitmq-host", env: "RABBITMQ_HOST"},
+	{flag: "rabbitmq-port", env: "RABBITMQ_PORT", port: true},
+	{flag: "app-db-host", env: "APP_DB_HOST"},
+	{flag: "app-db-port", env: "APP_DB_PORT", port: true},
+	{flag: "collection-db-host", env: "COLLECTION_DB_HOST"},
+	{flag: "collection-db-port", env: "COLLECTION_DB_PORT", port: true},
+}
+
+// serviceHostPattern matches a host name or IP address
+var serviceHostPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._-]*[A-Za-z0-9])?$|^\[[0-9A-Fa-f:.]+\]$`)
+
+// addServiceEnvFlags registers the service override flags on c
+func addServiceEnvFlags(c *cobra.Command) {
+	for _, f := range serviceEnvFlags {
+		usage := "Host name for " + f.env + " in the generated Dockerfile"
+		if f.port {
+			usage = "Port for " + f.env + " in the generated Dockerfile"
+		}
+		c.Flags().StringVar(&f.value, f.flag, "", usage)
+	}
+}
+
+// parseServiceEnv validates the service override flags and returns the
+// variables they set, in flag order
+func parseServiceEnv() ([]EnvVar, error) {
+	var vars []EnvVar
+	for _, f := range serviceEnvFlags {
+		if f.value == "" {
+			continue
+		}
+		if f.port {
+			if port, err := strconv.Atoi(f.value); err != nil || port < 1 || port > 65535 {
+				return nil, fmt.Errorf("invalid --%s '%s' (expected a port number from 1 to 65535)", f.flag, f.value)
+			}
+		} else if !serviceHostPattern.MatchString(f.value) {
+			return nil, fmt.Errorf("invalid --%s '%s' (expected a host name or IP address)", f.flag, f.value)
+		}
+		vars = append(vars, EnvVar{Name: f.env, Value: f.value})
+	}
+	return vars, nil
+}
+
+// overrideDockerfileEnv replaces the value of each ENV line of dockerfile
+// that sets one of vars
+func overrideDockerfileEnv(dockerfile string, vars []EnvVar) string {
+	if len(vars) == 0 {
+		return dockerfile
+	}
+	lines := strings.Split(dockerfile, "\n")
+	for i, line := range lines {
+		for _, v := range vars {
+			if strings.HasPrefix(line, "ENV "+v.Name+"=") {
+				lines[i] = "ENV " + v.Name + "=" + v.Value
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// serviceEnvValues returns the service overrides by variable name
+func serviceEnvValues(vars []EnvVar) map[string]string {
+	if len(vars) == 0 {
+		return nil
+	}
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		values[v.Name] = v.Value
+	}
+	return values
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// sourceKindFlag is the well-known kind of data source (--source-kind)
+var sourceKindFlag string
+
+// sourceKind is a well-known kind of data source. Choosing one during
+// creation seeds the default host and port of the connection config and
+// the suggested authentication methods; they remain defaults the user can
+// change.
+type sourceKind struct {
+	Key         string
+	Label       string
+	Host        string
+	Port        int
+	AuthMethods []string
+}
+
+// sourceKinds are the kinds offered during creation
+var sourceKinds = []sourceKind{
+	{"smb", "SMB / CIFS file share", "fileserver.example.com", 445, []string{"Windows Authentication", "Username/Password"}},
+	{"ldap", "LDAP / Active Directory", "dc.example.com", 389, []string{"Windows Authentication", "Username/Password"}},
+	{"ldaps", "LDAP over TLS", "dc.example.com", 636, []string{"Username/Password", "Certificate"}},
+	{"sqlserver", "Microsoft SQL Server", "sql.example.com", 1433, []string{"Username/Password", "Windows Authentication"}},
+	{"postgresql", "PostgreSQL", "db.example.com", 5432, []string{"Username/Password", "Certificate"}},
+	{"mysql", "MySQL / MariaDB", "db.example.com", 3306, []string{"Username/Password"}},
+	{"oracle", "Oracle Database", "db.example.com", 1521, []string{"Username/Password"}},
+	{"sharepoint", "SharePoint / Microsoft 365", "contoso.sharepoint.com", 443, []string{"OAuth2", "Certificate"}},
+	{"rest", "REST API", "api.example.com", 443, []string{"API Key", "OAuth2"}},
+}
+
+// sourceKindOther is the prompt option for a source without defaults
+const sourceKindOther = "Other (no defaults)"
+
+// findSourceKind returns the source kind with the given key
+func findSourceKind(key string) (sourceKind, bool) {
+	for _, kind := range sourceKinds {
+		if kind.Key == key {
+			return kind, true
+		}
+	}
+	return sourceKind{}, false
+}
+
+// validateSourceKind checks a --source-kind value; empty means none
+func validateSourceKind(key string) error {
+	if key == "" {
+		return nil
+	}
+	if _, ok := findSourceKind(key); !ok {
+		keys := make([]string, 0, len(sourceKinds))
+		for _, kind := range sourceKinds {
+			keys = append(keys, kind.Key)
+		}
+		return fmt.Errorf("invalid source kind '%s' (expected one of: %s)", key, strings.Join(keys, ", "))
+	}
+	return nil
+}
+
+// collectSourceKind asks which kind of data source the scanner reads, if
+// it was not given with --source-kind. The answer is optional.
+func collectSourceKind(scanner *ScannerCreationData) error {
+	if scanner.SourceKind != "" {
+		return nil
+	}
+
+	options := []string{sourceKindOther}
+	for _, kind := range sourceKinds {
+		options = append(options, kind.Label)
+	}
+	var answer string
+	if err := askOne(&survey.Select{
+		Message: "Kind of data source (optional):",
+		Options: options,
+		Default: sourceKindOther,
+		Help:    "Seeds the default host, port and authentication methods; you can change them later",
+	}, &answer); err != nil {
+		return err
+	}
+
+	for _, kind := range sourceKinds {
+		if kind.Label == answer {
+			scanner.SourceKind = kind.Key
+		}
+	}
+	return nil
+}
+
+// scannerSourceKind returns the source kind chosen for scanner, if any
+func scannerSourceKind(scanner *ScannerCreationData) (sourceKind, bool) {
+	return findSourceKind(scanner.SourceKind)
+}
+
+// connectionConfigItems returns the connection config fields of the
+// generated specification: the host, the port when a source kind with a
+// default port was chosen, and the fields of each authentication method
+func connectionConfigItems(scanner *ScannerCreationData) []map[string]interface{} {
+	items := hostPortConfigItems(scanner)
+	seen := make(map[string]bool)
+	for _, item := range items {
+		seen[item["key"].(string)] = true
+	}
+	for _, method := range scanner.AuthMethods {
+		for _, item := range authConfigItems[method] {
+			if seen[item.Key] {
+				continue
+			}
+			seen[item.Key] = true
+			items = append(items, specConfigItemMap(item))
+		}
+	}
+	return items
+}
+
+// hostPortConfigItems returns the host field, plus the port when a source
+// kind with a default port was chosen
+func hostPortConfigItems(scanner *ScannerCreationData) []map[string]interface{} {
+	host := map[string]interface{}{
+		"key":         "host",
+		"label":       "Host",
+		"type":        "text",
+		"required":    true,
+		"placeholder": "example.com",
+		"description": "Host to connect to",
+	}
+	kind, ok := scannerSourceKind(scanner)
+	if !ok {
+		return []map[string]interface{}{host}
+	}
+	host["placeholder"] = kind.Host
+	return []map[string]interface{}{
+		host,
+		{
+			"key":         "port",
+			"label":       "Port",
+			"type":        "number",
+			"required":    false,
+			"default":     kind.Port,
+			"min":         1,
+			"max":         65535,
+			"description": fmt.Sprintf("Port to connect to (%s default %d)", kind.Label, kind.Port),
+		},
+	}
+}
+
+// specConfigItemMap converts a config item to the generic form used by
+// generateScannerSpecification, leaving out empty optional fields
+func specConfigItemMap(item SpecConfigItem) map[string]interface{} {
+	m := map[string]interface{}{
+		"key":      item.Key,
+		"label":    item.Label,
+		"type":     item.Type,
+		"required": item.Required,
+	}
+	if item.Placeholder != "" {
+		m["placeholder"] = item.Placeholder
+	}
+	if item.Description != "" {
+		m["description"] = item.Description
+	}
+	if item.Default != nil {
+		m["default"] = item.Default
+	}
+	if item.Min != nil {
+		m["min"] = *item.Min
+	}
+	if item.Max != nil {
+		m["max"] = *item.Max
+	}
+	if len(item.Options) > 0 {
+		m["options"] = item.Options
+	}
+	return m
+}
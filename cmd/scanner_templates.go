@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// templateSet describes the conventions of one version of the generated
+// scaffold, so scanners can be regenerated to match an older deployed
+// scanner framework
+type templateSet struct {
+	Version     string
+	Description string
+
+	// SpecVersion is written to scannerSpecification.json; 0 leaves the
+	// field out for frameworks that predate it
+	SpecVersion int
+}
+
+// templateSets are the bundled template versions, oldest first
+var templateSets = []templateSet{
+	{
+		Version:     "v1",
+		Description: "frameworks that read scannerSpecification.json without a specVersion field",
+		SpecVersion: 0,
+	},
+	{
+		Version:     "v2",
+		Description: "current framework; the spec declares specVersion",
+		SpecVersion: currentSpecVersion,
+	},
+}
+
+// latestTemplateVersion is the default --template-version
+var latestTemplateVersion = templateSets[len(templateSets)-1].Version
+
+// templateVersionFlag selects the template set used for generation (--template-version)
+var templateVersionFlag string
+
+// findTemplateSet returns the template set of a --template-version value
+func findTemplateSet(version string) (templateSet, error) {
+	versions := make([]string, 0, len(templateSets))
+	for _, set := range templateSets {
+		if set.Version == version {
+			return set, nil
+		}
+		versions = append(versions, set.Version)
+	}
+	return templateSet{}, fmt.Errorf("unknown template version '%s' (available: %s)", version, strings.Join(versions, ", "))
+}
+
+// scannerTemplateSet returns the template set chosen for scanner, falling
+// back to the latest
+func scannerTemplateSet(scanner *ScannerCreationData) templateSet {
+	set, err := findTemplateSet(valueOr(scanner.TemplateVersion, latestTemplateVersion))
+	if err != nil {
+		return templateSets[len(templateSets)-1]
+	}
+	return set
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplateVersionsDiffer(t *testing.T) {
+	newScanner := func(version string) *ScannerCreationData {
+		return &ScannerCreationData{
+			Name:               "my-scanner",
+			DisplayName:        "My Scanner",
+			Version:            "1.0.0",
+			Language:           "python",
+			SupportedScanTypes: []string{"access"},
+			TemplateVersion:    version,
+		}
+	}
+
+	v1, err := parseSpec([]byte(generateScannerSpecification(newScanner("v1"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v1.SpecVersion != 0 {
+		t.Errorf("v1 spec has specVersion %d, want none", v1.SpecVersion)
+	}
+	if strings.Contains(generateScannerSpecification(newScanner("v1")), "specVersion") {
+		t.Error("v1 spec declares specVersion")
+	}
+
+	for _, version := range []string{"v2", ""} {
+		spec, err := parseSpec([]byte(generateScannerSpecification(newScanner(version))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if spec.SpecVersion != currentSpecVersion {
+			t.Errorf("template %q spec has specVersion %d, want %d", version, spec.SpecVersion, currentSpecVersion)
+		}
+	}
+
+	for _, version := range []string{"v1", "v2"} {
+		readme := generateReadme(newScanner(version))
+		if !strings.Contains(readme, "--template-version "+version) {
+			t.Errorf("README for %s does not name the template version", version)
+		}
+	}
+}
+
+func TestFindTemplateSet(t *testing.T) {
+	if latestTemplateVersion != "v2" {
+		t.Errorf("latest template version = %s, want v2", latestTemplateVersion)
+	}
+	for _, version := range []string{"v1", "v2"} {
+		if set, err := findTemplateSet(version); err != nil || set.Version != version {
+			t.Errorf("findTemplateSet(%s) = %+v, %v", version, set, err)
+		}
+	}
+	_, err := findTemplateSet("v3")
+	if err == nil || !strings.Contains(err.Error(), "available: v1, v2") {
+		t.Errorf("findTemplateSet(v3) = %v, want the available versions", err)
+	}
+
+	// An unknown version stored in the answers falls back to the latest
+	if got := scannerTemplateSet(&ScannerCreationData{TemplateVersion: "v9"}); got.Version != latestTemplateVersion {
+		t.Errorf("scannerTemplateSet(v9) = %s, want %s", got.Version, latestTemplateVersion)
+	}
+}
+
+func TestTemplatesReadScannerConfigInLocalMode(t *testing.T) {
+	scanner := &ScannerCreationData{Name: "my-scanner", DisplayName: "My Scanner", Version: "1.0.0"}
+	templates := map[string]struct {
+		source string
+		want   []string
+	}{
+		"python": {generateScannerPython(scanner), []string{
+			"os.environ.get('SCANNER_CONFIG')",
+			"MyScannerScanner(load_local_config(config_path))",
+			"os.path.expandvars",
+		}},
+		"javascript": {generateScannerJavaScript(scanner), []string{
+			"process.env.SCANNER_CONFIG",
+			"new MyScannerScanner(loadLocalConfig(configPath))",
+			"new QueueScanner()",
+		}},
+	}
+	for language, tt := range templates {
+		for _, want := range tt.want {
+			if !strings.Contains(tt.source, want) {
+				t.Errorf("%s template does not contain %q", language, want)
+			}
+		}
+	}
+}
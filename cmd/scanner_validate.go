@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateFixFlag          bool
+	validateOutput           string
+	validateWarningsAsErrors bool
+	validateOnly             string
+)
+
+// errSpecInvalid makes 'scanner validate -o json' exit 1 without printing
+// an error, since the result has already been written
+var errSpecInvalid = errors.New("specification is invalid")
+
+// specValidation is the result of 'scanner validate -o json'
+type specValidation struct {
+	Valid    bool          `json:"valid"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+	Findings []SpecFinding `json:"findings"`
+	Fixes    []specFix     `json:"fixes,omitempty"`
+}
+
+var scannerValidateCmd = &cobra.Command{
+	Use:   "validate [dir]",
+	Short: "Validate a scanner specification",
+	Long: `Validate the scannerSpecification.json in a scanner directory (defaults to
+the current directory) against the JSON Schema built into nwx (see
+'nwx aa scanner schema') and the rules a schema cannot express, such as
+unique keys and primary key columns.
+
+With --fix, issues that have a single safe correction (a missing
+specVersion, nullable primary key columns, config items without a label)
+are fixed in place before validating, keeping the file's key order and
+formatting of values. The original file is kept as
+scannerSpecification.json.bak, or .bak.1, .bak.2 and so on when an earlier
+backup exists.
+
+With -o json the result is printed as {"valid", "errors", "warnings",
+"findings": [{"severity", "field", "message"}]} for CI. The exit status
+is 1 when there are errors, or warnings with --fail-on-warning (alias
+--warnings-as-errors), and 0 otherwise. Warnings are still labeled as
+warnings. --only errors leaves warnings out of the output and the counts.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(validateOutput, outputText, outputJSON); err != nil {
+			return err
+		}
+		if err := validateOnlyFlag(validateOnly); err != nil {
+			return err
+		}
+		jsonOutput := validateOutput == outputJSON
+
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+
+		var fixes []specFix
+		if validateFixFlag {
+			var backup string
+			var err error
+			if fixes, backup, err = fixSpecFile(dir); err != nil {
+				return err
+			}
+			if len(fixes) > 0 && !jsonOutput {
+				fmt.Printf(glyphs("🔧 Fixed %d issue(s) in %s (original saved as %s)\n"), len(fixes), filepath.Join(dir, specFileName), filepath.Base(backup))
+				for _, f := range fixes {
+					fmt.Printf(glyphs("  ✏️  %s: %s\n"), f.Field, f.Message)
+				}
+				fmt.Println()
+			}
+		}
+
+		if !jsonOutput {
+			fmt.Printf(glyphs("🔍 Validating %s\n"), filepath.Join(dir, specFileName))
+		}
+
+		data, err := readSpecFile(dir)
+		if err != nil {
+			return err
+		}
+		// A value of the wrong type stops parsing; the schema reports every
+		// such value at once, so only syntax errors end validation here
+		spec, parseErr := parseSpec(data)
+		var typeErr *json.UnmarshalTypeError
+		if parseErr != nil && !errors.As(parseErr, &typeErr) {
+			return parseErr
+		}
+		schemaFindings, err := validateSpecSchema(data)
+		if err != nil {
+			return err
+		}
+
+		findings := schemaFindings
+		if spec != nil {
+			findings = mergeSchemaFindings(validateSpec(spec), schemaFindings)
+		} else if len(findings) == 0 {
+			return parseErr
+		}
+		findings = filterFindings(findings, validateOnly)
+		errorCount, warnings := countFindings(findings)
+		failed := errorCount > 0 || (validateWarningsAsErrors && warnings > 0)
+
+		if jsonOutput {
+			if err := printJSON(specValidation{
+				Valid:    !failed,
+				Errors:   errorCount,
+				Warnings: warnings,
+				Findings: append([]SpecFinding{}, findings...),
+				Fixes:    fixes,
+			}); err != nil {
+				return err
+			}
+			if failed {
+				return errSpecInvalid
+			}
+			return nil
+		}
+
+		printFindings(findings)
+		if errorCount > 0 {
+			return fmt.Errorf("specification has %d error(s) and %d warning(s)", errorCount, warnings)
+		}
+		if failed {
+			return fmt.Errorf("specification has %d warning(s) (--fail-on-warning)", warnings)
+		}
+
+		fmt.Printf(glyphs("✅ Specification is valid (%d warning(s))\n"), warnings)
+		return nil
+	},
+}
+
+func init() {
+	scannerValidateCmd.Flags().BoolVar(&validateFixFlag, "fix", false, "Apply safe corrections to the specification before validating")
+	scannerValidateCmd.Flags().StringVarP(&validateOutput, "output", "o", outputText, "Output format (text|json)")
+	scannerValidateCmd.Flags().BoolVar(&validateWarningsAsErrors, "fail-on-warning", false, "Fail validation when there are warnings")
+	scannerValidateCmd.Flags().BoolVar(&validateWarningsAsErrors, "warnings-as-errors", false, "Alias for --fail-on-warning")
+	scannerValidateCmd.Flags().StringVar(&validateOnly, "only", onlyAll, "Findings to report (all|errors)")
+
+	scannerCmd.AddCommand(scannerValidateCmd)
+}
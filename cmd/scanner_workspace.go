@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// workspaceManifestName is the default workspace manifest file
+const workspaceManifestName = "scanners.yaml"
+
+var (
+	workspaceManifestFlag string
+	workspaceOutputFlag   string
+)
+
+var scannerWorkspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Run scanner commands across a workspace",
+	Long: `Run scanner commands across every scanner listed in a workspace manifest
+(scanners.yaml by default):
+
+  scanners:
+    - scanners/file-scanner
+    - scanners/sql-scanner
+
+Directories are relative to the manifest.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("Scanner Workspace")
+		fmt.Println("Available commands:")
+		fmt.Println("  nwx aa scanner workspace validate - Validate every scanner in the workspace")
+		fmt.Println()
+		fmt.Println("Use 'nwx aa scanner workspace <command> --help' for more information.")
+	},
+}
+
+var scannerWorkspaceValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate every scanner in the workspace",
+	Long: `Validate the scannerSpecification.json of every scanner in the workspace
+manifest and report the results together. Exits non-zero if any scanner
+fails validation.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(workspaceOutputFlag, outputText, outputJSON); err != nil {
+			return err
+		}
+
+		dirs, err := readWorkspaceManifest(workspaceManifestFlag)
+		if err != nil {
+			return err
+		}
+
+		results := make([]workspaceResult, 0, len(dirs))
+		failed := 0
+		for _, dir := range dirs {
+			result := validateWorkspaceScanner(dir)
+			if !result.Passed {
+				failed++
+			}
+			results = append(results, result)
+		}
+
+		if workspaceOutputFlag == outputJSON {
+			if err := printJSON(results); err != nil {
+				return err
+			}
+		} else {
+			printWorkspaceResults(results)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d scanner(s) failed validation", failed, len(results))
+		}
+		return nil
+	},
+}
+
+// workspaceManifest is the structure of scanners.yaml
+type workspaceManifest struct {
+	Scanners []string `yaml:"scanners"`
+}
+
+// workspaceResult is the validation outcome for one scanner directory
+type workspaceResult struct {
+	Dir      string        `json:"dir"`
+	Passed   bool          `json:"passed"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+	Findings []SpecFinding `json:"findings,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// readWorkspaceManifest reads a workspace manifest and returns the scanner
+// directories it lists, resolved relative to the manifest
+func readWorkspaceManifest(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest workspaceManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid workspace manifest %s: %w", path, err)
+	}
+	if len(manifest.Scanners) == 0 {
+		return nil, fmt.Errorf("workspace manifest %s lists no scanners", path)
+	}
+
+	base := filepath.Dir(path)
+	dirs := make([]string, 0, len(manifest.Scanners))
+	for _, dir := range manifest.Scanners {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(base, dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+// validateWorkspaceScanner validates the specification in one scanner directory
+func validateWorkspaceScanner(dir string) workspaceResult {
+	result := workspaceResult{Dir: dir}
+
+	// Parsed without LoadSpec's name and version checks so a missing name
+	// is reported with the other findings
+	data, err := readSpecFile(dir)
+	if err != nil {
+		result.Error = err.Error()
+		result.Errors = 1
+		return result
+	}
+	spec, err := parseSpec(data)
+	if err != nil {
+		result.Error = err.Error()
+		result.Errors = 1
+		return result
+	}
+
+	result.Findings = validateSpec(spec)
+	result.Errors, result.Warnings = countFindings(result.Findings)
+	result.Passed = result.Errors == 0
+	return result
+}
+
+// printWorkspaceResults prints one line per scanner, its findings and a total
+func printWorkspaceResults(results []workspaceResult) {
+	passed := 0
+	for _, r := range results {
+		switch {
+		case r.Error != "":
+			fmt.Printf(glyphs("❌ %s: %s\n"), r.Dir, r.Error)
+		case r.Passed:
+			passed++
+			fmt.Printf(glyphs("✅ %s (%d warning(s))\n"), r.Dir, r.Warnings)
+		default:
+			fmt.Printf(glyphs("❌ %s (%d error(s), %d warning(s))\n"), r.Dir, r.Errors, r.Warnings)
+		}
+		printFindings(r.Findings)
+	}
+
+	fmt.Println()
+	fmt.Printf("%d passed, %d failed\n", passed, len(results)-passed)
+}
+
+func init() {
+	scannerWorkspaceCmd.PersistentFlags().StringVar(&workspaceManifestFlag, "manifest", workspaceManifestName, "Workspace manifest listing scanner directories")
+	scannerWorkspaceValidateCmd.Flags().StringVarP(&workspaceOutputFlag, "output", "o", outputText, "Output format (text|json)")
+
+	scannerWorkspaceCmd.AddCommand(scannerWorkspaceValidateCmd)
+	scannerCmd.AddCommand(scannerWorkspaceCmd)
+}
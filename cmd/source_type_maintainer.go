@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// maintainerFlag is the owner contact stored in the source type (--maintainer)
+var maintainerFlag string
+
+var (
+	// maintainerEmailPattern matches a plain email address
+	maintainerEmailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+	// maintainerHandlePattern matches a user or team handle such as @alice
+	// or @netwrix/scanners
+	maintainerHandlePattern = regexp.MustCompile(`^@[A-Za-z0-9][A-Za-z0-9_-]*(/[A-Za-z0-9][A-Za-z0-9._-]*)?$`)
+)
+
+// validateMaintainer checks that a maintainer is an email address or a
+// handle; an empty maintainer is allowed
+func validateMaintainer(maintainer string) error {
+	if maintainer == "" || maintainerEmailPattern.MatchString(maintainer) || maintainerHandlePattern.MatchString(maintainer) {
+		return nil
+	}
+	return fmt.Errorf("invalid maintainer '%s' (expected an email address or a handle such as @alice or @org/team)", maintainer)
+}
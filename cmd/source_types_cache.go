@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// sourceTypesCacheFileName holds the last fetched source types in the config directory
+const sourceTypesCacheFileName = "source-types-cache.json"
+
+// sourceTypesCacheKey is the configuration key that opts in to caching the
+// source types used for duplicate name checks
+const sourceTypesCacheKey = "source-types-cache"
+
+// refreshCacheFlag writes the source types cache on this run even when
+// caching is not turned on (--refresh-cache)
+var refreshCacheFlag bool
+
+// sourceTypesCache is the structure of the cache file. The endpoint is kept
+// so a cache from another Access Analyzer instance is never used.
+type sourceTypesCache struct {
+	Endpoint    string       `json:"endpoint"`
+	FetchedAt   time.Time    `json:"fetchedAt"`
+	SourceTypes []SourceType `json:"sourceTypes"`
+}
+
+// sourceTypesCacheEnabled reports whether source-types-cache is turned on
+func sourceTypesCacheEnabled() bool {
+	value, err := readConfigValue(sourceTypesCacheKey)
+	if err != nil || value == "" {
+		return false
+	}
+	enabled, err := strconv.ParseBool(value)
+	return err == nil && enabled
+}
+
+// setSourceTypesCache validates and stores the source-types-cache key
+func setSourceTypesCache(value string) error {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid %s '%s' (expected true or false)", sourceTypesCacheKey, value)
+	}
+	return writeConfigValue(sourceTypesCacheKey, strconv.FormatBool(enabled))
+}
+
+// saveSourceTypesCache records the source types fetched from endpoint when
+// caching is turned on or --refresh-cache is given
+func saveSourceTypesCache(endpoint string, sourceTypes []SourceType) error {
+	if !refreshCacheFlag && !sourceTypesCacheEnabled() {
+		return nil
+	}
+	configDir, err := getConfigDir()
+	if err != nil {
+		return err
+	}
+
+	// The embedded specifications are not needed for name checks
+	entries := make([]SourceType, len(sourceTypes))
+	for i, st := range sourceTypes {
+		st.ScannerSpecification = nil
+		entries[i] = st
+	}
+	data, err := json.MarshalIndent(sourceTypesCache{
+		Endpoint:    endpoint,
+		FetchedAt:   time.Now().UTC(),
+		SourceTypes: entries,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeConfigFile(configDir, sourceTypesCacheFileName, append(data, '\n'))
+}
+
+// loadSourceTypesCache returns the cached source types for endpoint, or nil
+// when there is no cache for it
+func loadSourceTypesCache(endpoint string) (*sourceTypesCache, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, sourceTypesCacheFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cache sourceTypesCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("invalid source types cache: %w", err)
+	}
+	if cache.Endpoint != endpoint {
+		return nil, nil
+	}
+	return &cache, nil
+}
+
+// cachedExistingScanners returns the cached scanners for endpoint when the
+// API can't be reached, noting how old they are
+func cachedExistingScanners(endpoint string) ([]SourceType, bool) {
+	cache, err := loadSourceTypesCache(endpoint)
+	if err != nil {
+		fmt.Printf(glyphs("⚠️  Could not read cached scanners: %v\n"), err)
+		return nil, false
+	}
+	if cache == nil {
+		return nil, false
+	}
+
+	fmt.Printf(glyphs("ℹ️  Using %d cached scanners fetched %s ago; scanners registered since won't be detected as duplicates\n"),
+		len(cache.SourceTypes), formatAge(time.Since(cache.FetchedAt)))
+	fmt.Println()
+	return cache.SourceTypes, true
+}
+
+// formatAge formats a cache age in the largest whole unit
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "less than a minute"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
@@ -0,0 +1,459 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// specFileName is the scanner specification file generated in every scanner directory
+const specFileName = "scannerSpecification.json"
+
+// currentSpecVersion is the newest specification format this CLI understands.
+// Specs without a specVersion are treated as version 1.
+const currentSpecVersion = 1
+
+// ScannerSpec mirrors the structure of scannerSpecification.json
+type ScannerSpec struct {
+	SpecVersion             int                        `json:"specVersion,omitempty"`
+	Name                    string                     `json:"name"`
+	Version                 string                     `json:"version"`
+	ConnectionConfig        *SpecConfigSection         `json:"connectionConfig,omitempty"`
+	AccessScanConfig        *SpecConfigSection         `json:"accessScanConfig,omitempty"`
+	SensitiveDataScanConfig *SpecConfigSection         `json:"sensitiveDataScanConfig,omitempty"`
+	OutputSchema            map[string]SpecOutputTable `json:"outputSchema,omitempty"`
+}
+
+// SpecConfigSection is a list of configuration fields shown in the UI
+type SpecConfigSection struct {
+	Items []SpecConfigItem `json:"items"`
+}
+
+// SpecConfigItem describes a single configuration field
+type SpecConfigItem struct {
+	Key         string      `json:"key"`
+	Label       string      `json:"label"`
+	Type        string      `json:"type"`
+	Required    bool        `json:"required"`
+	Placeholder string      `json:"placeholder,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Min         *float64    `json:"min,omitempty"`
+	Max         *float64    `json:"max,omitempty"`
+	Options     []string    `json:"options,omitempty"`
+}
+
+// SpecOutputTable describes the columns a scan type writes to the collection database
+type SpecOutputTable struct {
+	Columns []SpecColumn `json:"columns"`
+}
+
+// SpecColumn describes a single output column
+type SpecColumn struct {
+	Name         string      `json:"name"`
+	Type         string      `json:"type"`
+	MaxLength    int         `json:"maxLength,omitempty"`
+	Nullable     bool        `json:"nullable"`
+	PrimaryKey   bool        `json:"primaryKey,omitempty"`
+	DefaultValue interface{} `json:"defaultValue,omitempty"`
+	Description  string      `json:"description,omitempty"`
+}
+
+// SpecFinding is a single validation result for a scanner specification
+type SpecFinding struct {
+	Severity string `json:"severity"`
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+}
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+var (
+	specNamePattern   = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	semverPattern     = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+	columnNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+	configFieldTypes = []string{"text", "password", "number", "boolean", "select", "textarea"}
+	columnTypes      = []string{"string", "integer", "number", "boolean", "timestamp", "json"}
+
+	// outputSchemaScanTypes maps outputSchema keys to the scan type they belong to
+	outputSchemaScanTypes = map[string]string{
+		"access":        "access",
+		"sensitiveData": "sensitive_data",
+	}
+)
+
+// parseSpec parses the contents of a scannerSpecification.json file
+func parseSpec(data []byte) (*ScannerSpec, error) {
+	var spec ScannerSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		if syntaxErr := newSpecSyntaxError(data, err); syntaxErr != nil {
+			return nil, syntaxErr
+		}
+		return nil, fmt.Errorf("invalid scanner specification: %w", err)
+	}
+	return &spec, nil
+}
+
+// SpecSyntaxError is a specification that is not valid JSON or has a value
+// of the wrong type, located by line and column
+type SpecSyntaxError struct {
+	Line    int
+	Column  int
+	Snippet string // the offending line with a caret under the column
+	Err     error
+}
+
+func (e *SpecSyntaxError) Error() string {
+	return fmt.Sprintf("invalid scanner specification at line %d, column %d: %v\n%s", e.Line, e.Column, e.Err, e.Snippet)
+}
+
+func (e *SpecSyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// newSpecSyntaxError locates a JSON syntax or type error in data, returning
+// nil for errors without a position
+func newSpecSyntaxError(data []byte, err error) *SpecSyntaxError {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return nil
+	}
+
+	// Offset counts the bytes read up to and including the offending one;
+	// truncated input is reported at the end. An offending last byte, such
+	// as the '}' after a trailing comma, is not truncation.
+	pos := int(offset) - 1
+	if pos < 0 {
+		pos = 0
+	}
+	if syntaxErr != nil && int(offset) >= len(data) && strings.Contains(syntaxErr.Error(), "unexpected end of JSON input") {
+		pos = len(data)
+	}
+	if pos > len(data) {
+		pos = len(data)
+	}
+
+	lineStart := bytes.LastIndexByte(data[:pos], '\n') + 1
+	lineEnd := bytes.IndexByte(data[lineStart:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(data)
+	} else {
+		lineEnd += lineStart
+	}
+	line := strings.TrimRight(string(data[lineStart:lineEnd]), "\r")
+	prefix := string(data[lineStart:pos])
+
+	// Keep tabs in the caret line so it lines up under the offending byte
+	caret := strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return r
+		}
+		return ' '
+	}, prefix) + "^"
+
+	lineNumber := bytes.Count(data[:lineStart], []byte("\n")) + 1
+	gutter := fmt.Sprintf("%5d | ", lineNumber)
+	return &SpecSyntaxError{
+		Line:    lineNumber,
+		Column:  utf8.RuneCountInString(prefix) + 1,
+		Snippet: gutter + line + "\n" + strings.Repeat(" ", len(gutter)-2) + "| " + caret,
+		Err:     err,
+	}
+}
+
+// ErrSpecNotFound matches a *SpecNotFoundError with errors.Is
+var ErrSpecNotFound = errors.New("scanner specification not found")
+
+// SpecNotFoundError is returned when a directory has no scanner specification
+type SpecNotFoundError struct {
+	Dir  string
+	Path string // the path that was searched
+}
+
+func (e *SpecNotFoundError) Error() string {
+	return fmt.Sprintf("no %s found in %s; run the command in a scanner directory or pass its path", specFileName, e.Dir)
+}
+
+func (e *SpecNotFoundError) Is(target error) bool {
+	return target == ErrSpecNotFound
+}
+
+// readSpecFile reads the scanner specification in dir without parsing it,
+// returning a *SpecNotFoundError when there is none
+func readSpecFile(dir string) ([]byte, error) {
+	path := filepath.Join(dir, specFileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, &SpecNotFoundError{Dir: dir, Path: path}
+	}
+	return data, err
+}
+
+// LoadSpec reads and parses the scanner specification in dir. Besides a
+// *SpecNotFoundError for a missing file, it rejects a specification without
+// a name or version, which every command relies on; the full checks are
+// left to validateSpec.
+func LoadSpec(dir string) (*ScannerSpec, error) {
+	data, err := readSpecFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := parseSpec(data)
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, specFileName)
+	if spec.Name == "" {
+		return nil, fmt.Errorf("%s has no name", path)
+	}
+	if spec.Version == "" {
+		return nil, fmt.Errorf("%s has no version", path)
+	}
+	return spec, nil
+}
+
+// embeddedSpecJSON returns the scanner specification document embedded in a
+// source type. The API may return the specification either as an object or
+// as a JSON string.
+func (st *SourceType) embeddedSpecJSON() (json.RawMessage, error) {
+	raw := st.ScannerSpecification
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, fmt.Errorf("source type '%s' has no embedded scanner specification", st.TypeName)
+	}
+
+	if raw[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return nil, fmt.Errorf("invalid scanner specification: %w", err)
+		}
+		raw = json.RawMessage(encoded)
+	}
+
+	return raw, nil
+}
+
+// embeddedSpec returns the parsed scanner specification embedded in a source type
+func (st *SourceType) embeddedSpec() (*ScannerSpec, error) {
+	raw, err := st.embeddedSpecJSON()
+	if err != nil {
+		return nil, err
+	}
+	return parseSpec(raw)
+}
+
+// validateSpec checks a scanner specification against the current rules
+func validateSpec(spec *ScannerSpec) []SpecFinding {
+	var findings []SpecFinding
+	add := func(severity, field, format string, args ...interface{}) {
+		findings = append(findings, SpecFinding{
+			Severity: severity,
+			Field:    field,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Format version
+	switch {
+	case spec.SpecVersion == 0:
+		add(severityWarning, "specVersion", "specVersion is missing; assuming version 1")
+	case spec.SpecVersion < 0:
+		add(severityError, "specVersion", "specVersion must be a positive integer")
+	case spec.SpecVersion > currentSpecVersion:
+		add(severityWarning, "specVersion", "spec format version %d is newer than this CLI supports (%d); update nwx to validate it fully", spec.SpecVersion, currentSpecVersion)
+	}
+
+	// Identity
+	if spec.Name == "" {
+		add(severityError, "name", "name is required")
+	} else if !specNamePattern.MatchString(spec.Name) {
+		add(severityError, "name", "name '%s' must be upper snake case (e.g. MY_SCANNER)", spec.Name)
+	}
+
+	if spec.Version == "" {
+		add(severityError, "version", "version is required")
+	} else if !semverPattern.MatchString(spec.Version) {
+		add(severityError, "version", "version '%s' must be a semantic version (e.g. 1.0.0)", spec.Version)
+	}
+	if specNamePattern.MatchString(spec.Name) && semverPattern.MatchString(spec.Version) {
+		for _, problem := range specNameProblems(spec.Name, spec.Version, specScanTypes(spec)) {
+			add(severityWarning, "name", "%s", problem)
+		}
+	}
+
+	// Configuration sections
+	if spec.ConnectionConfig == nil || len(spec.ConnectionConfig.Items) == 0 {
+		add(severityError, "connectionConfig", "at least one connection config item is required")
+	} else {
+		findings = append(findings, validateConfigSection("connectionConfig", spec.ConnectionConfig)...)
+	}
+	if spec.AccessScanConfig != nil {
+		findings = append(findings, validateConfigSection("accessScanConfig", spec.AccessScanConfig)...)
+	}
+	if spec.SensitiveDataScanConfig != nil {
+		findings = append(findings, validateConfigSection("sensitiveDataScanConfig", spec.SensitiveDataScanConfig)...)
+	}
+
+	// Output schema
+	if len(spec.OutputSchema) == 0 {
+		add(severityError, "outputSchema", "at least one output schema is required")
+	}
+	for _, key := range sortedKeys(spec.OutputSchema) {
+		field := "outputSchema." + key
+		if _, ok := outputSchemaScanTypes[key]; !ok {
+			add(severityError, field, "unknown output schema '%s' (expected one of: access, sensitiveData)", key)
+			continue
+		}
+		findings = append(findings, validateOutputTable(field, spec.OutputSchema[key])...)
+	}
+
+	if spec.AccessScanConfig != nil {
+		if _, ok := spec.OutputSchema["access"]; !ok {
+			add(severityWarning, "accessScanConfig", "access scan config is defined but outputSchema.access is missing")
+		}
+	}
+	if spec.SensitiveDataScanConfig != nil {
+		if _, ok := spec.OutputSchema["sensitiveData"]; !ok {
+			add(severityWarning, "sensitiveDataScanConfig", "sensitive data scan config is defined but outputSchema.sensitiveData is missing")
+		}
+	}
+
+	return findings
+}
+
+// validateConfigSection checks the items of a configuration section
+func validateConfigSection(section string, config *SpecConfigSection) []SpecFinding {
+	var findings []SpecFinding
+	seen := make(map[string]bool)
+
+	for i, item := range config.Items {
+		field := fmt.Sprintf("%s.items[%d]", section, i)
+		if item.Key == "" {
+			findings = append(findings, SpecFinding{severityError, field, "key is required"})
+		} else {
+			field = fmt.Sprintf("%s.items[%s]", section, item.Key)
+			if seen[item.Key] {
+				findings = append(findings, SpecFinding{severityError, field, fmt.Sprintf("duplicate key '%s'", item.Key)})
+			}
+			seen[item.Key] = true
+		}
+
+		if item.Label == "" {
+			findings = append(findings, SpecFinding{severityWarning, field, "label is missing"})
+		}
+
+		if item.Type == "" {
+			findings = append(findings, SpecFinding{severityError, field, "type is required"})
+		} else if !contains(configFieldTypes, item.Type) {
+			findings = append(findings, SpecFinding{severityError, field,
+				fmt.Sprintf("unknown type '%s' (expected one of: %s)", item.Type, strings.Join(configFieldTypes, ", "))})
+		}
+
+		if item.Type == "select" && len(item.Options) == 0 {
+			findings = append(findings, SpecFinding{severityError, field, "select fields must define options"})
+		}
+
+		if item.Min != nil && item.Max != nil && *item.Min > *item.Max {
+			findings = append(findings, SpecFinding{severityError, field, "min must not be greater than max"})
+		}
+	}
+
+	return findings
+}
+
+// validateOutputTable checks the columns of an output schema table
+func validateOutputTable(field string, table SpecOutputTable) []SpecFinding {
+	var findings []SpecFinding
+	if len(table.Columns) == 0 {
+		return append(findings, SpecFinding{severityError, field, "at least one column is required"})
+	}
+
+	seen := make(map[string]bool)
+	hasPrimaryKey := false
+
+	for i, col := range table.Columns {
+		colField := fmt.Sprintf("%s.columns[%d]", field, i)
+		if col.Name == "" {
+			findings = append(findings, SpecFinding{severityError, colField, "column name is required"})
+		} else {
+			colField = fmt.Sprintf("%s.columns[%s]", field, col.Name)
+			if !columnNamePattern.MatchString(col.Name) {
+				findings = append(findings, SpecFinding{severityError, colField, "column name must be lower snake case"})
+			}
+			if seen[col.Name] {
+				findings = append(findings, SpecFinding{severityError, colField, fmt.Sprintf("duplicate column '%s'", col.Name)})
+			}
+			seen[col.Name] = true
+		}
+
+		if col.Type == "" {
+			findings = append(findings, SpecFinding{severityError, colField, "column type is required"})
+		} else if !contains(columnTypes, col.Type) {
+			findings = append(findings, SpecFinding{severityError, colField,
+				fmt.Sprintf("unknown column type '%s' (expected one of: %s)", col.Type, strings.Join(columnTypes, ", "))})
+		}
+
+		if col.PrimaryKey {
+			hasPrimaryKey = true
+			if col.Nullable {
+				findings = append(findings, SpecFinding{severityError, colField, "primary key column must not be nullable"})
+			}
+		}
+	}
+
+	if !hasPrimaryKey {
+		findings = append(findings, SpecFinding{severityWarning, field, "no primary key column defined"})
+	}
+
+	return findings
+}
+
+// countFindings returns the number of errors and warnings in findings
+func countFindings(findings []SpecFinding) (errors, warnings int) {
+	for _, f := range findings {
+		if f.Severity == severityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+// printFindings prints validation findings in human-readable form
+func printFindings(findings []SpecFinding) {
+	for _, f := range findings {
+		if f.Severity == severityError {
+			fmt.Printf(glyphs("  ❌ %s: %s\n"), f.Field, f.Message)
+		} else {
+			fmt.Printf(glyphs("  ⚠️  %s: %s\n"), f.Field, f.Message)
+		}
+	}
+}
+
+// sortedKeys returns the keys of an output schema map in a stable order
+func sortedKeys(m map[string]SpecOutputTable) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
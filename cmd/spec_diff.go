@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var diffOutputFlag string
+
+var scannerDiffCmd = &cobra.Command{
+	Use:   "diff [dir]",
+	Short: "Compare a local specification with the registered one",
+	Long: `Show the field-level differences between the scannerSpecification.json in
+dir (defaults to the current directory) and the specification registered in
+Access Analyzer for the same scanner. Additions are shown in green, removals
+in red and changed values in yellow.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutputFormat(diffOutputFlag, outputText, outputJSON); err != nil {
+			return err
+		}
+
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+
+		local, err := readSpecFile(dir)
+		if err != nil {
+			return err
+		}
+		spec, err := parseSpec(local)
+		if err != nil {
+			return err
+		}
+
+		client, err := getAPIClient()
+		if err != nil {
+			return err
+		}
+		name := strings.ToLower(strings.ReplaceAll(spec.Name, "_", "-"))
+		sourceType, err := client.FindSourceType(cmd.Context(), name)
+		if err != nil {
+			return err
+		}
+		registered, err := sourceType.embeddedSpecJSON()
+		if err != nil {
+			return err
+		}
+
+		changes, err := diffSpecJSON(registered, local)
+		if err != nil {
+			return err
+		}
+
+		if diffOutputFlag == outputJSON {
+			if changes == nil {
+				changes = []specChange{}
+			}
+			return printJSON(changes)
+		}
+
+		if len(changes) == 0 {
+			fmt.Printf(glyphs("✅ %s matches the registered specification for '%s'\n"), specFileName, name)
+			return nil
+		}
+		return runPaged(func() error {
+			fmt.Printf("Changes from registered '%s' (%s) to %s:\n", name, sourceType.Version, filepath.Join(dir, specFileName))
+			printChanges(changes)
+			return nil
+		})
+	},
+}
+
+// Kinds of specChange
+const (
+	changeAdded   = "added"
+	changeRemoved = "removed"
+	changeChanged = "changed"
+)
+
+// specChange is a single field-level difference between two specifications
+type specChange struct {
+	Path string      `json:"path"`
+	Kind string      `json:"kind"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// diffSpecJSON compares two specification documents field by field
+func diffSpecJSON(oldData, newData []byte) ([]specChange, error) {
+	oldDoc, err := decodeJSONDocument(oldData)
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := decodeJSONDocument(newData)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []specChange
+	diffJSONValues("", oldDoc, newDoc, &changes)
+	return changes, nil
+}
+
+// decodeJSONDocument decodes JSON keeping numbers exactly as written
+func decodeJSONDocument(data []byte) (interface{}, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid scanner specification: %w", err)
+	}
+	return doc, nil
+}
+
+// diffJSONValues records the differences between two decoded JSON values.
+// Arrays of objects with a "key" or "name" field (config items, columns)
+// are matched by that field so reordering does not show up as changes.
+func diffJSONValues(path string, oldValue, newValue interface{}, changes *[]specChange) {
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := make(map[string]bool)
+		for k := range oldMap {
+			keys[k] = true
+		}
+		for k := range newMap {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			childPath := joinSpecPath(path, k)
+			o, inOld := oldMap[k]
+			n, inNew := newMap[k]
+			switch {
+			case !inOld:
+				*changes = append(*changes, specChange{Path: childPath, Kind: changeAdded, New: n})
+			case !inNew:
+				*changes = append(*changes, specChange{Path: childPath, Kind: changeRemoved, Old: o})
+			default:
+				diffJSONValues(childPath, o, n, changes)
+			}
+		}
+		return
+	}
+
+	oldList, oldIsList := oldValue.([]interface{})
+	newList, newIsList := newValue.([]interface{})
+	if oldIsList && newIsList {
+		diffJSONArrays(path, oldList, newList, changes)
+		return
+	}
+
+	if !jsonEqual(oldValue, newValue) {
+		*changes = append(*changes, specChange{Path: path, Kind: changeChanged, Old: oldValue, New: newValue})
+	}
+}
+
+// diffJSONArrays compares arrays, by identity field when every element has
+// one and by index otherwise
+func diffJSONArrays(path string, oldList, newList []interface{}, changes *[]specChange) {
+	oldByID, oldOK := indexByIdentity(oldList)
+	newByID, newOK := indexByIdentity(newList)
+	if !oldOK || !newOK {
+		for i := 0; i < len(oldList) || i < len(newList); i++ {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(oldList):
+				*changes = append(*changes, specChange{Path: childPath, Kind: changeAdded, New: newList[i]})
+			case i >= len(newList):
+				*changes = append(*changes, specChange{Path: childPath, Kind: changeRemoved, Old: oldList[i]})
+			default:
+				diffJSONValues(childPath, oldList[i], newList[i], changes)
+			}
+		}
+		return
+	}
+
+	for _, id := range identityOrder(oldList) {
+		childPath := fmt.Sprintf("%s[%s]", path, id)
+		if n, ok := newByID[id]; ok {
+			diffJSONValues(childPath, oldByID[id], n, changes)
+		} else {
+			*changes = append(*changes, specChange{Path: childPath, Kind: changeRemoved, Old: oldByID[id]})
+		}
+	}
+	for _, id := range identityOrder(newList) {
+		if _, ok := oldByID[id]; !ok {
+			*changes = append(*changes, specChange{Path: fmt.Sprintf("%s[%s]", path, id), Kind: changeAdded, New: newByID[id]})
+		}
+	}
+}
+
+// elementIdentity returns the "key" or "name" of an array element
+func elementIdentity(v interface{}) (string, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	if key, ok := m["key"].(string); ok && key != "" {
+		return key, true
+	}
+	if name, ok := m["name"].(string); ok && name != "" {
+		return name, true
+	}
+	return "", false
+}
+
+// indexByIdentity maps array elements by identity; ok is false when an
+// element has no identity or identities repeat
+func indexByIdentity(list []interface{}) (map[string]interface{}, bool) {
+	index := make(map[string]interface{}, len(list))
+	for _, v := range list {
+		id, ok := elementIdentity(v)
+		if !ok {
+			return nil, false
+		}
+		if _, dup := index[id]; dup {
+			return nil, false
+		}
+		index[id] = v
+	}
+	return index, true
+}
+
+// identityOrder returns element identities in array order
+func identityOrder(list []interface{}) []string {
+	ids := make([]string, 0, len(list))
+	for _, v := range list {
+		id, _ := elementIdentity(v)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// joinSpecPath appends a field name to a dotted path
+func joinSpecPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// jsonEqual compares two decoded JSON values
+func jsonEqual(a, b interface{}) bool {
+	return formatJSONValue(a) == formatJSONValue(b)
+}
+
+// formatJSONValue renders a decoded JSON value compactly for display
+func formatJSONValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// printChanges prints changes as colored +/-/~ lines
+func printChanges(changes []specChange) {
+	for _, c := range changes {
+		switch c.Kind {
+		case changeAdded:
+			fmt.Println(diffAddStyle.Render(fmt.Sprintf("+ %s: %s", c.Path, formatJSONValue(c.New))))
+		case changeRemoved:
+			fmt.Println(diffRemoveStyle.Render(fmt.Sprintf("- %s: %s", c.Path, formatJSONValue(c.Old))))
+		default:
+			fmt.Println(diffChangeStyle.Render(fmt.Sprintf(glyphs("~ %s: %s → %s"), c.Path, formatJSONValue(c.Old), formatJSONValue(c.New))))
+		}
+	}
+}
+
+func init() {
+	scannerDiffCmd.Flags().StringVarP(&diffOutputFlag, "output", "o", outputText, "Output format (text|json)")
+
+	scannerCmd.AddCommand(scannerDiffCmd)
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// specFix describes a single correction applied by fixSpec
+type specFix struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// fixSpecFile applies the safe corrections of fixSpec to the specification
+// in dir and returns them with the path of the backup of the original,
+// which is only made when anything changes. The file is edited in place:
+// key order, numbers and fields the CLI does not know about are kept, and
+// an existing backup is never overwritten.
+func fixSpecFile(dir string) ([]specFix, string, error) {
+	path := filepath.Join(dir, specFileName)
+	data, err := readSpecFile(dir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	decoded, err := decodeOrderedJSON(data)
+	if err != nil {
+		if syntaxErr := newSpecSyntaxError(data, err); syntaxErr != nil {
+			return nil, "", syntaxErr
+		}
+		return nil, "", fmt.Errorf("invalid scanner specification: %w", err)
+	}
+	doc, ok := decoded.(*orderedObject)
+	if !ok {
+		return nil, "", fmt.Errorf("invalid scanner specification: expected a JSON object")
+	}
+
+	fixes := fixSpec(doc)
+	if len(fixes) == 0 {
+		return nil, "", nil
+	}
+
+	out, err := marshalOrderedJSON(doc)
+	if err != nil {
+		return nil, "", err
+	}
+	if bytes.HasSuffix(data, []byte("\n")) {
+		out = append(out, '\n')
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, "", err
+	}
+	backup, err := backupFile(path, data, info.Mode().Perm())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := writeFileAtomic(path, out, info.Mode().Perm()); err != nil {
+		return nil, "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return fixes, backup, nil
+}
+
+// backupFile writes data to path.bak, or to path.bak.1, path.bak.2 and so
+// on when earlier backups exist, and returns the name it used
+func backupFile(path string, data []byte, perm os.FileMode) (string, error) {
+	for i := 0; ; i++ {
+		name := path + ".bak"
+		if i > 0 {
+			name = fmt.Sprintf("%s.%d", name, i)
+		}
+		f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(name)
+			return "", err
+		}
+		return name, nil
+	}
+}
+
+// fixSpec corrects the validation issues that have exactly one sensible
+// fix: a missing specVersion, primary key columns marked nullable and
+// config items without a label. doc is a decoded scannerSpecification.json.
+func fixSpec(doc *orderedObject) []specFix {
+	var fixes []specFix
+
+	if _, ok := doc.get("specVersion"); !ok {
+		doc.setFirst("specVersion", currentSpecVersion)
+		fixes = append(fixes, specFix{"specVersion", fmt.Sprintf("set to %d", currentSpecVersion)})
+	}
+
+	for _, section := range []string{"connectionConfig", "accessScanConfig", "sensitiveDataScanConfig"} {
+		config := orderedField(doc, section)
+		items, _ := orderedValue(config, "items").([]interface{})
+		for _, raw := range items {
+			item, ok := raw.(*orderedObject)
+			if !ok {
+				continue
+			}
+			key, _ := orderedValue(item, "key").(string)
+			label, _ := orderedValue(item, "label").(string)
+			if key == "" || label != "" {
+				continue
+			}
+			label = labelFromKey(key)
+			item.set("label", label)
+			fixes = append(fixes, specFix{
+				fmt.Sprintf("%s.items[%s]", section, key),
+				fmt.Sprintf("label set to '%s'", label),
+			})
+		}
+	}
+
+	if schema := orderedField(doc, "outputSchema"); schema != nil {
+		for _, name := range schema.keys {
+			columns, _ := orderedValue(orderedField(schema, name), "columns").([]interface{})
+			for i, raw := range columns {
+				column, ok := raw.(*orderedObject)
+				if !ok {
+					continue
+				}
+				if orderedValue(column, "primaryKey") != true || orderedValue(column, "nullable") != true {
+					continue
+				}
+				column.set("nullable", false)
+				field := fmt.Sprintf("outputSchema.%s.columns[%d]", name, i)
+				if colName, _ := orderedValue(column, "name").(string); colName != "" {
+					field = fmt.Sprintf("outputSchema.%s.columns[%s]", name, colName)
+				}
+				fixes = append(fixes, specFix{field, "primary key column marked not nullable"})
+			}
+		}
+	}
+
+	return fixes
+}
+
+// orderedValue returns the value of key in o, or nil when o is nil or has
+// no such key
+func orderedValue(o *orderedObject, key string) interface{} {
+	if o == nil {
+		return nil
+	}
+	value, _ := o.get(key)
+	return value
+}
+
+// orderedField returns the object value of key in o, or nil
+func orderedField(o *orderedObject, key string) *orderedObject {
+	object, _ := orderedValue(o, key).(*orderedObject)
+	return object
+}
+
+// labelFromKey derives a display label from a camelCase or snake_case
+// config key, e.g. "scanDepth" becomes "Scan Depth"
+func labelFromKey(key string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			word[0] = unicode.ToUpper(word[0])
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	for _, r := range key {
+		switch {
+		case r == '_' || r == '-' || r == ' ':
+			flush()
+		case unicode.IsUpper(r) && len(word) > 0 && !unicode.IsUpper(word[len(word)-1]):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFixSpec(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   string
+		want   string
+		fields []string
+	}{
+		{
+			name: "nothing to fix",
+			spec: `{"specVersion":1,"connectionConfig":{"items":[{"key":"host","label":"Host"}]}}`,
+			want: `{"specVersion":1,"connectionConfig":{"items":[{"key":"host","label":"Host"}]}}`,
+		},
+		{
+			name:   "missing specVersion goes first",
+			spec:   `{"name":"MY_SCANNER","version":"1.0.0"}`,
+			want:   `{"specVersion":1,"name":"MY_SCANNER","version":"1.0.0"}`,
+			fields: []string{"specVersion"},
+		},
+		{
+			name:   "missing and empty labels",
+			spec:   `{"specVersion":1,"connectionConfig":{"items":[{"key":"scanDepth"},{"key":"api_key","label":""},{"label":"No key"}]},"accessScanConfig":{"items":[{"key":"max-items","type":"number"}]}}`,
+			want:   `{"specVersion":1,"connectionConfig":{"items":[{"key":"scanDepth","label":"Scan Depth"},{"key":"api_key","label":"Api Key"},{"label":"No key"}]},"accessScanConfig":{"items":[{"key":"max-items","type":"number","label":"Max Items"}]}}`,
+			fields: []string{"connectionConfig.items[scanDepth]", "connectionConfig.items[api_key]", "accessScanConfig.items[max-items]"},
+		},
+		{
+			name:   "nullable primary keys",
+			spec:   `{"specVersion":1,"outputSchema":{"users":{"columns":[{"name":"id","primaryKey":true,"nullable":true},{"name":"email","nullable":true},{"primaryKey":true,"nullable":true}]}}}`,
+			want:   `{"specVersion":1,"outputSchema":{"users":{"columns":[{"name":"id","primaryKey":true,"nullable":false},{"name":"email","nullable":true},{"primaryKey":true,"nullable":false}]}}}`,
+			fields: []string{"outputSchema.users.columns[id]", "outputSchema.users.columns[2]"},
+		},
+		{
+			name: "unexpected types are left alone",
+			spec: `{"specVersion":1,"connectionConfig":[],"outputSchema":{"users":"columns"}}`,
+			want: `{"specVersion":1,"connectionConfig":[],"outputSchema":{"users":"columns"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := decodeOrderedJSON([]byte(tt.spec))
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc := decoded.(*orderedObject)
+			var fields []string
+			for _, fix := range fixSpec(doc) {
+				fields = append(fields, fix.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("fixed fields = %v, want %v", fields, tt.fields)
+			}
+			got, err := marshalNoEscape(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("fixed spec\n got %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixSpecFileKeepsLayout(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, specFileName)
+	original := `{
+  "name": "MY_SCANNER",
+  "version": "1.0.0",
+  "description": "Reads <shares> & folders",
+  "connectionConfig": {
+    "items": [
+      {
+        "key": "host",
+        "type": "text",
+        "default": 1.50
+      }
+    ]
+  }
+}
+`
+	want := `{
+  "specVersion": 1,
+  "name": "MY_SCANNER",
+  "version": "1.0.0",
+  "description": "Reads <shares> & folders",
+  "connectionConfig": {
+    "items": [
+      {
+        "key": "host",
+        "type": "text",
+        "default": 1.50,
+        "label": "Host"
+      }
+    ]
+  }
+}
+`
+	if err := os.WriteFile(path, []byte(original), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	fixes, backup, err := fixSpecFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixes) != 2 {
+		t.Errorf("fixes = %v, want specVersion and the label", fixes)
+	}
+	if backup != path+".bak" {
+		t.Errorf("backup = %s, want %s.bak", backup, path)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("fixed file\n got %s\nwant %s", got, want)
+	}
+	if saved, _ := os.ReadFile(backup); string(saved) != original {
+		t.Errorf("backup = %s, want the original", saved)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0640 {
+		t.Errorf("fixed file mode = %v, %v, want 0640", info, err)
+	}
+
+	// Nothing left to fix: no new backup
+	fixes, backup, err = fixSpecFile(dir)
+	if err != nil || len(fixes) != 0 || backup != "" {
+		t.Errorf("second fix = %v, %q, %v, want nothing", fixes, backup, err)
+	}
+}
+
+func TestFixSpecFileKeepsEarlierBackups(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, specFileName)
+	writeTestFile(t, path+".bak", "first backup")
+	writeTestFile(t, path+".bak.1", "second backup")
+	writeTestFile(t, path, `{"name":"MY_SCANNER"}`)
+
+	_, backup, err := fixSpecFile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if backup != path+".bak.2" {
+		t.Errorf("backup = %s, want %s.bak.2", backup, path)
+	}
+	for name, want := range map[string]string{
+		path + ".bak":   "first backup",
+		path + ".bak.1": "second backup",
+		path + ".bak.2": `{"name":"MY_SCANNER"}`,
+		path: `{
+  "specVersion": 1,
+  "name": "MY_SCANNER"
+}`,
+	} {
+		if got, _ := os.ReadFile(name); string(got) != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(name), got, want)
+		}
+	}
+}
+
+func TestFixSpecFileSyntaxError(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, specFileName), "{\n  \"name\": \"MY_SCANNER\",\n}")
+	if _, _, err := fixSpecFile(dir); err == nil {
+		t.Fatal("fixSpecFile accepted a malformed spec")
+	}
+	if _, err := os.Stat(filepath.Join(dir, specFileName+".bak")); !os.IsNotExist(err) {
+		t.Errorf("a backup was written for a malformed spec: %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles for the JSON preview
+var (
+	jsonKeyStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#7C3AED"))
+	jsonStringStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981"))
+	jsonLiteralStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F59E0B"))
+)
+
+// Actions offered after the spec preview
+const (
+	previewProceed = "Generate files"
+	previewEdit    = "Edit fields"
+	previewCancel  = "Cancel"
+)
+
+// Steps that can be revisited from the spec preview
+var editableSteps = []string{
+	"Basic information",
+	"Programming language",
+	"Scan types",
+	"Authentication methods",
+	"File generation",
+}
+
+var (
+	jsonKeyLinePattern = regexp.MustCompile(`^(\s*)("(?:[^"\\]|\\.)*")(:\s*)(.*)$`)
+	jsonValuePattern   = regexp.MustCompile(`^(\s*)(.*?)(,?)$`)
+)
+
+// colorizeJSON applies light syntax coloring to indented JSON
+func colorizeJSON(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if m := jsonKeyLinePattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + jsonKeyStyle.Render(m[2]) + m[3] + colorizeJSONValue(m[4])
+		} else {
+			lines[i] = colorizeJSONValue(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// colorizeJSONValue colors a scalar JSON value, leaving brackets untouched
+func colorizeJSONValue(s string) string {
+	m := jsonValuePattern.FindStringSubmatch(s)
+	if m == nil || m[2] == "" {
+		return s
+	}
+
+	value := m[2]
+	switch {
+	case strings.HasPrefix(value, `"`):
+		value = jsonStringStyle.Render(value)
+	case strings.ContainsAny(value[:1], "{}[]"):
+		// Structural characters stay uncolored
+	default:
+		value = jsonLiteralStyle.Render(value)
+	}
+	return m[1] + value + m[3]
+}
+
+// specPreviewModel is a scrollable view of the generated specification
+type specPreviewModel struct {
+	title  string
+	lines  []string
+	offset int
+	height int
+}
+
+// Init initializes the model
+func (m specPreviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles scrolling
+func (m specPreviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Leave room for the title and help lines
+		m.height = msg.Height - 4
+		if m.height < 1 {
+			m.height = 1
+		}
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc", "enter":
+			return m, tea.Quit
+		case "up", "k":
+			m.offset--
+		case "down", "j":
+			m.offset++
+		case "pgup", "b":
+			m.offset -= m.height
+		case "pgdown", "f", " ":
+			m.offset += m.height
+		case "home", "g":
+			m.offset = 0
+		case "end", "G":
+			m.offset = len(m.lines)
+		}
+	}
+
+	maxOffset := len(m.lines) - m.height
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+	if m.offset > maxOffset {
+		m.offset = maxOffset
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+
+	return m, nil
+}
+
+// View renders the visible part of the specification
+func (m specPreviewModel) View() string {
+	var s strings.Builder
+
+	s.WriteString(titleStyle.Render(m.title))
+	s.WriteString("\n")
+
+	end := m.offset + m.height
+	if end > len(m.lines) {
+		end = len(m.lines)
+	}
+	for _, line := range m.lines[m.offset:end] {
+		s.WriteString(line)
+		s.WriteString("\n")
+	}
+
+	s.WriteString(helpStyle.Render(fmt.Sprintf(glyphs("Lines %d-%d of %d • ↑/↓ scroll • pgup/pgdn page • enter/q continue"),
+		m.offset+1, end, len(m.lines))))
+
+	return s.String()
+}
+
+// showSpecPreview displays the specification in a scrollable view, falling
+// back to printing it when no terminal is available or with --no-pager
+func showSpecPreview(title, spec string) {
+	colored := colorizeJSON(spec)
+	if noPagerFlag {
+		fmt.Println(colored)
+		return
+	}
+
+	model := specPreviewModel{
+		title:  title,
+		lines:  strings.Split(colored, "\n"),
+		height: 20,
+	}
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Println(colored)
+	}
+}
+
+// previewSpecification shows the scanner specification that will be written
+// and asks whether to proceed. It returns previewProceed or previewEdit.
+// With --yes the specification is printed and the files are generated.
+func previewSpecification(scanner *ScannerCreationData) (string, error) {
+	fmt.Println(glyphs("🔎 Step 7: Specification Preview"))
+	fmt.Println()
+
+	if assumeYesFlag {
+		fmt.Println(colorizeJSON(generateScannerSpecification(scanner)))
+		fmt.Printf("? Proceed with this specification? %s (--yes)\n", previewProceed)
+		fmt.Println()
+		return previewProceed, nil
+	}
+
+	showSpecPreview(specFileName, generateScannerSpecification(scanner))
+
+	actionPrompt := &survey.Select{
+		Message: "Proceed with this specification?",
+		Options: []string{previewProceed, previewEdit, previewCancel},
+		Default: previewProceed,
+	}
+
+	var action string
+	if err := askOne(actionPrompt, &action); err != nil {
+		return "", err
+	}
+
+	if action == previewCancel {
+		return "", fmt.Errorf("scanner creation cancelled")
+	}
+
+	fmt.Println()
+	return action, nil
+}
+
+// editScannerStep asks which step to revisit and runs it again with the
+// current values as defaults
+func editScannerStep(scanner *ScannerCreationData, existing []SourceType) error {
+	stepPrompt := &survey.Select{
+		Message: "Which step do you want to edit?",
+		Options: editableSteps,
+	}
+
+	var step string
+	if err := askOne(stepPrompt, &step); err != nil {
+		return err
+	}
+	fmt.Println()
+
+	switch step {
+	case "Basic information":
+		return collectBasicInfo(scanner, existing)
+	case "Programming language":
+		return collectLanguage(scanner)
+	case "Scan types":
+		return collectScanTypes(scanner)
+	case "Authentication methods":
+		return collectAuthMethods(scanner)
+	case "File generation":
+		return collectFileGeneration(scanner)
+	}
+
+	return nil
+}
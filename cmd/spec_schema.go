@@ -0,0 +1,343 @@
+package cmd
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// specSchemaJSON is the JSON Schema of scannerSpecification.json. It is the
+// reference for the structure of a spec; validateSpec adds the checks a
+// schema cannot express, such as duplicate keys and primary key rules.
+//
+//go:embed schema/scannerSpecification.schema.json
+var specSchemaJSON []byte
+
+// jsonSchema is the part of JSON Schema that the embedded spec schema uses.
+// It is not a general JSON Schema validator: only the keywords below are
+// understood, $ref only points into the root $defs, additionalProperties is
+// a boolean and patterns use Go regexp syntax. parseJSONSchema rejects a
+// schema using anything else, so a keyword is never silently ignored.
+type jsonSchema struct {
+	// Annotations, which do not affect validation
+	Schema      string      `json:"$schema"`
+	ID          string      `json:"$id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Default     interface{} `json:"default"`
+
+	Ref                  string                 `json:"$ref"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+	Type                 string                 `json:"type"`
+	Enum                 []interface{}          `json:"enum"`
+	Pattern              string                 `json:"pattern"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinLength            *int                   `json:"minLength"`
+	MinItems             *int                   `json:"minItems"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+
+	pattern *regexp.Regexp
+	ref     *jsonSchema
+}
+
+// jsonSchemaTypes are the values of the type keyword
+var jsonSchemaTypes = []string{"null", "boolean", "integer", "number", "string", "array", "object"}
+
+var (
+	specSchemaOnce sync.Once
+	specSchema     *jsonSchema
+	specSchemaErr  error
+)
+
+// loadSpecSchema parses the embedded schema on first use
+func loadSpecSchema() (*jsonSchema, error) {
+	specSchemaOnce.Do(func() {
+		specSchema, specSchemaErr = parseJSONSchema(specSchemaJSON)
+		if specSchemaErr != nil {
+			specSchemaErr = fmt.Errorf("embedded scanner specification schema: %w", specSchemaErr)
+		}
+	})
+	return specSchema, specSchemaErr
+}
+
+// parseJSONSchema parses a schema, compiles its patterns and resolves its
+// references. It fails on a keyword, $ref or type that jsonSchema cannot
+// check rather than accept a schema it would only partly enforce.
+func parseJSONSchema(data []byte) (*jsonSchema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var schema jsonSchema
+	if err := decoder.Decode(&schema); err != nil {
+		if name := strings.TrimPrefix(err.Error(), "json: unknown field "); name != err.Error() {
+			return nil, fmt.Errorf("unsupported keyword %s", name)
+		}
+		return nil, err
+	}
+	if err := schema.compile(&schema, "#"); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// compile prepares s, found at location in root, for validation
+func (s *jsonSchema) compile(root *jsonSchema, location string) error {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		if s.Type != "" || s.Enum != nil || s.Pattern != "" || s.Minimum != nil || s.Maximum != nil ||
+			s.MinLength != nil || s.MinItems != nil || s.Required != nil || s.Properties != nil ||
+			s.AdditionalProperties != nil || s.Items != nil {
+			return fmt.Errorf("%s: keywords next to $ref are not supported", location)
+		}
+		ref, err := root.resolve(s.Ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", location, err)
+		}
+		s.ref = ref
+	}
+	if s.Type != "" && !contains(jsonSchemaTypes, s.Type) {
+		return fmt.Errorf("%s: unknown type '%s'", location, s.Type)
+	}
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("%s: invalid pattern: %w", location, err)
+		}
+		s.pattern = pattern
+	}
+	for _, name := range sortedSchemaKeys(s.Defs) {
+		if err := s.Defs[name].compile(root, location+"/$defs/"+name); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedSchemaKeys(s.Properties) {
+		if err := s.Properties[name].compile(root, location+"/properties/"+name); err != nil {
+			return err
+		}
+	}
+	return s.Items.compile(root, location+"/items")
+}
+
+// resolve follows a "#/$defs/<name>" reference, and any reference that
+// definition is itself, to the schema it names
+func (s *jsonSchema) resolve(ref string) (*jsonSchema, error) {
+	seen := map[string]bool{}
+	for {
+		name := strings.TrimPrefix(ref, "#/$defs/")
+		if name == ref || name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("unsupported $ref %s: only #/$defs/<name> references are supported", ref)
+		}
+		def, ok := s.Defs[name]
+		if !ok || def == nil {
+			return nil, fmt.Errorf("unresolved $ref %s", ref)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("circular $ref %s", ref)
+		}
+		seen[name] = true
+		if def.Ref == "" {
+			return def, nil
+		}
+		ref = def.Ref
+	}
+}
+
+func sortedSchemaKeys(schemas map[string]*jsonSchema) []string {
+	keys := make([]string, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateSpecSchema checks a scannerSpecification.json document against
+// the embedded schema. Findings are errors named by the same field paths as
+// validateSpec, e.g. connectionConfig.items[host].type.
+func validateSpecSchema(data []byte) ([]SpecFinding, error) {
+	schema, err := loadSpecSchema()
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid scanner specification: %w", err)
+	}
+	v := &schemaValidator{}
+	v.validate(schema, doc, "")
+	return v.findings, nil
+}
+
+// unknownPropertyMessage starts the finding for a property the schema does
+// not define, usually a misspelling that validateSpec silently ignores
+const unknownPropertyMessage = "unknown property"
+
+type schemaValidator struct {
+	findings []SpecFinding
+}
+
+func (v *schemaValidator) add(field, format string, args ...interface{}) {
+	v.findings = append(v.findings, SpecFinding{severityError, valueOr(field, "(root)"), fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) validate(schema *jsonSchema, value interface{}, path string) {
+	if schema.ref != nil {
+		schema = schema.ref
+	}
+
+	if schema.Type != "" && !jsonSchemaTypeMatches(schema.Type, value) {
+		v.add(path, "must be of type %s, got %s", schema.Type, jsonSchemaTypeOf(value))
+		return
+	}
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		v.add(path, "must be one of: %s", formatEnum(schema.Enum))
+		return
+	}
+
+	switch value := value.(type) {
+	case string:
+		if schema.MinLength != nil && len([]rune(value)) < *schema.MinLength {
+			v.add(path, "must be at least %d character(s) long", *schema.MinLength)
+		}
+		if schema.pattern != nil && !schema.pattern.MatchString(value) {
+			v.add(path, "'%s' does not match pattern %s", value, schema.Pattern)
+		}
+	case float64:
+		if schema.Minimum != nil && value < *schema.Minimum {
+			v.add(path, "must be at least %v", *schema.Minimum)
+		}
+		if schema.Maximum != nil && value > *schema.Maximum {
+			v.add(path, "must be at most %v", *schema.Maximum)
+		}
+	case []interface{}:
+		if schema.MinItems != nil && len(value) < *schema.MinItems {
+			v.add(path, "must have at least %d item(s)", *schema.MinItems)
+		}
+		if schema.Items != nil {
+			for i, item := range value {
+				v.validate(schema.Items, item, path+schemaItemIndex(item, i))
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range schema.Required {
+			if _, ok := value[name]; !ok {
+				v.add(joinFieldPath(path, name), "is required")
+			}
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := schema.Properties[key]; ok {
+				v.validate(property, value[key], joinFieldPath(path, key))
+			} else if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+				v.add(joinFieldPath(path, key), unknownPropertyMessage+" '%s'", key)
+			}
+		}
+	}
+}
+
+// schemaItemIndex formats the index of an array item like validateSpec:
+// by its key or name when it has one, otherwise by position
+func schemaItemIndex(item interface{}, i int) string {
+	if obj, ok := item.(map[string]interface{}); ok {
+		for _, field := range []string{"key", "name"} {
+			if name, ok := obj[field].(string); ok && name != "" {
+				return "[" + name + "]"
+			}
+		}
+	}
+	return fmt.Sprintf("[%d]", i)
+}
+
+// joinFieldPath appends a property name to a field path
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonSchemaTypeMatches reports whether a decoded JSON value has a JSON
+// Schema type
+func jsonSchemaTypeMatches(schemaType string, value interface{}) bool {
+	if schemaType == "integer" {
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	}
+	return jsonSchemaTypeOf(value) == schemaType
+}
+
+// jsonSchemaTypeOf returns the JSON Schema type name of a decoded JSON value
+func jsonSchemaTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func enumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	values := make([]string, 0, len(enum))
+	for _, allowed := range enum {
+		values = append(values, fmt.Sprint(allowed))
+	}
+	return strings.Join(values, ", ")
+}
+
+// mergeSchemaFindings adds the schema findings not already covered by an
+// error of validateSpec on the same field or one of its parents, which
+// carry the more specific message. Unknown properties are always kept
+// since validateSpec cannot see them.
+func mergeSchemaFindings(findings, schemaFindings []SpecFinding) []SpecFinding {
+	merged := findings
+	for _, sf := range schemaFindings {
+		if strings.HasPrefix(sf.Message, unknownPropertyMessage) || !coveredByFinding(sf, findings) {
+			merged = append(merged, sf)
+		}
+	}
+	return merged
+}
+
+// coveredByFinding reports whether an error in findings is on the field of
+// sf or one of its parents
+func coveredByFinding(sf SpecFinding, findings []SpecFinding) bool {
+	for _, f := range findings {
+		if f.Severity == severityError && (sf.Field == f.Field || strings.HasPrefix(sf.Field, f.Field+".")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedSpecSchemaParses(t *testing.T) {
+	if _, err := loadSpecSchema(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseJSONSchemaRejectsWhatItCannotCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"unsupported keyword", `{"type": "object", "oneOf": [{"required": ["a"]}]}`, `unsupported keyword "oneOf"`},
+		{"nested unsupported keyword", `{"properties": {"a": {"type": "string", "format": "uri"}}}`, `unsupported keyword "format"`},
+		{"unresolved $ref", `{"properties": {"a": {"$ref": "#/$defs/missing"}}}`, "#/properties/a: unresolved $ref #/$defs/missing"},
+		{"remote $ref", `{"$ref": "https://example.com/other.json"}`, "only #/$defs/<name> references are supported"},
+		{"circular $ref", `{"$ref": "#/$defs/a", "$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"$ref": "#/$defs/a"}}}`, "circular $ref"},
+		{"keywords next to $ref", `{"$ref": "#/$defs/a", "type": "object", "$defs": {"a": {}}}`, "keywords next to $ref"},
+		{"schema-valued additionalProperties", `{"additionalProperties": {"type": "string"}}`, "cannot unmarshal object"},
+		{"unknown type", `{"type": "text"}`, "unknown type 'text'"},
+		{"invalid pattern", `{"pattern": "(unclosed"}`, "invalid pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseJSONSchema([]byte(tt.schema))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseJSONSchema() = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSpecSchemaGoodSpecs(t *testing.T) {
+	for _, version := range []string{"v1", "v2"} {
+		spec := generateScannerSpecification(&ScannerCreationData{
+			Name:               "my-scanner",
+			DisplayName:        "My Scanner",
+			Version:            "1.0.0",
+			Language:           "python",
+			SupportedScanTypes: []string{"access", "sensitive_data"},
+			TemplateVersion:    version,
+		})
+		findings, err := validateSpecSchema([]byte(spec))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(findings) != 0 {
+			t.Errorf("%s template spec has schema findings: %+v", version, findings)
+		}
+	}
+}
+
+func TestValidateSpecSchemaBadSpecs(t *testing.T) {
+	spec := `{
+  "specVersion": 1.5,
+  "name": "my-scanner",
+  "version": "1.0",
+  "connectionConfig": {
+    "items": [
+      {"key": "host", "type": "url", "lable": "Host"},
+      {"key": "", "type": "text"}
+    ]
+  },
+  "accessScanConfig": {},
+  "outputSchema": {
+    "access": {"columns": []},
+    "sensitiveData": {"columns": [{"name": "Path", "type": "string", "maxLength": 0}]}
+  },
+  "extra": true
+}`
+	findings, err := validateSpecSchema([]byte(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"specVersion":                                        "must be of type integer, got number",
+		"name":                                               "does not match pattern",
+		"version":                                            "does not match pattern",
+		"connectionConfig.items[host].type":                  "must be one of: text, password",
+		"connectionConfig.items[host].lable":                 "unknown property 'lable'",
+		"connectionConfig.items[1].key":                      "must be at least 1 character(s) long",
+		"accessScanConfig.items":                             "is required",
+		"outputSchema.access.columns":                        "must have at least 1 item(s)",
+		"outputSchema.sensitiveData.columns[Path].name":      "does not match pattern",
+		"outputSchema.sensitiveData.columns[Path].maxLength": "must be at least 1",
+		"extra": "unknown property 'extra'",
+	}
+	got := map[string]string{}
+	for _, f := range findings {
+		if f.Severity != severityError {
+			t.Errorf("%s: severity %s, want error", f.Field, f.Severity)
+		}
+		got[f.Field] = f.Message
+	}
+	for field, message := range want {
+		if !strings.Contains(got[field], message) {
+			t.Errorf("%s: finding %q, want one containing %q", field, got[field], message)
+		}
+	}
+	if len(findings) != len(want) {
+		t.Errorf("%d findings, want %d: %+v", len(findings), len(want), findings)
+	}
+
+	if _, err := validateSpecSchema([]byte(`{"name": `)); err == nil {
+		t.Error("validateSpecSchema() accepted malformed JSON")
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSpecSyntaxErrorPosition(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		line    int
+		column  int
+		snippet string
+	}{
+		{
+			name:    "trailing comma",
+			spec:    "{\n  \"name\": \"X\",\n}",
+			line:    3,
+			column:  1,
+			snippet: "    3 | }\n      | ^",
+		},
+		{
+			name:    "missing comma",
+			spec:    "{\n  \"name\": \"X\"\n  \"version\": \"1.0.0\"\n}",
+			line:    3,
+			column:  3,
+			snippet: "    3 |   \"version\": \"1.0.0\"\n      |   ^",
+		},
+		{
+			name:    "truncated",
+			spec:    "{\n  \"name\": \"X\",",
+			line:    2,
+			column:  15,
+			snippet: "    2 |   \"name\": \"X\",\n      |               ^",
+		},
+		{
+			name:    "wrong type",
+			spec:    "{\n  \"name\": 42\n}",
+			line:    2,
+			column:  12,
+			snippet: "    2 |   \"name\": 42\n      |            ^",
+		},
+		{
+			name:    "tabs, CRLF and multibyte characters",
+			spec:    "{\r\n\t\"name\": \"é\" x\r\n}",
+			line:    2,
+			column:  14,
+			snippet: "    2 | \t\"name\": \"é\" x\n      | \t            ^",
+		},
+		{
+			name:    "empty file",
+			spec:    "",
+			line:    1,
+			column:  1,
+			snippet: "    1 | \n      | ^",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSpec([]byte(tt.spec))
+			var syntaxErr *SpecSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("parseSpec() = %v, want a *SpecSyntaxError", err)
+			}
+			if syntaxErr.Line != tt.line || syntaxErr.Column != tt.column {
+				t.Errorf("position = %d:%d, want %d:%d", syntaxErr.Line, syntaxErr.Column, tt.line, tt.column)
+			}
+			if syntaxErr.Snippet != tt.snippet {
+				t.Errorf("snippet\n%s\nwant\n%s", syntaxErr.Snippet, tt.snippet)
+			}
+		})
+	}
+}
+
+func TestMalformedSpecIsLocatedEverywhere(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, specFileName), "{\n  \"name\": \"MY_SCANNER\",\n  \"version\": \"1.0.0\",\n}\n")
+
+	_, loadErr := LoadSpec(dir)
+	_, _, fixErr := fixSpecFile(dir)
+	for name, err := range map[string]error{"LoadSpec": loadErr, "fixSpecFile": fixErr} {
+		var syntaxErr *SpecSyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("%s() = %v, want a *SpecSyntaxError", name, err)
+			continue
+		}
+		if syntaxErr.Line != 4 || syntaxErr.Column != 1 {
+			t.Errorf("%s() located the error at %d:%d, want 4:1", name, syntaxErr.Line, syntaxErr.Column)
+		}
+		if !strings.Contains(err.Error(), "line 4, column 1") {
+			t.Errorf("%s() message = %v", name, err)
+		}
+	}
+}
+
+func TestReadSpecFileNotFound(t *testing.T) {
+	dir := t.TempDir()
+	_, err := readSpecFile(dir)
+	if !errors.Is(err, ErrSpecNotFound) {
+		t.Errorf("readSpecFile(empty dir) = %v, want ErrSpecNotFound", err)
+	}
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errInputCancelled is returned when the user leaves a text input with esc or ctrl+c
+var errInputCancelled = errors.New("input cancelled")
+
+// lineInput configures a single line of text input
+type lineInput struct {
+	Message     string
+	Default     string
+	Placeholder string
+	Secret      bool               // mask the typed value
+	Validate    func(string) error // called on enter; an error keeps the input open
+}
+
+// textInputModel is a bubbletea model for one line of validated input
+type textInputModel struct {
+	config    lineInput
+	input     textinput.Model
+	err       error
+	done      bool
+	cancelled bool
+}
+
+// newTextInputModel creates a focused text input for config
+func newTextInputModel(config lineInput) textInputModel {
+	input := textinput.New()
+	input.Placeholder = config.Placeholder
+	input.SetValue(config.Default)
+	input.CharLimit = 0
+	input.Width = 60
+	if config.Secret {
+		input.EchoMode = textinput.EchoPassword
+	}
+	input.Focus()
+
+	return textInputModel{config: config, input: input}
+}
+
+// Init starts the cursor blinking
+func (m textInputModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles submission and cancellation and passes other keys to the input
+func (m textInputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			m.cancelled = true
+			return m, tea.Quit
+		case tea.KeyEnter:
+			value := strings.TrimSpace(m.input.Value())
+			if m.config.Validate != nil {
+				if err := m.config.Validate(value); err != nil {
+					m.err = err
+					return m, nil
+				}
+			}
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.err = nil
+	return m, cmd
+}
+
+// View renders the prompt, the input and any validation error
+func (m textInputModel) View() string {
+	if m.done || m.cancelled {
+		return ""
+	}
+
+	var s strings.Builder
+	s.WriteString(m.config.Message)
+	s.WriteString("\n")
+	s.WriteString(m.input.View())
+	s.WriteString("\n")
+	if m.err != nil {
+		s.WriteString(errorStyle.Render(glyphs("✗ ") + m.err.Error()))
+		s.WriteString("\n")
+	}
+	s.WriteString(helpStyle.Render(glyphs("enter to confirm • esc to cancel")))
+	return s.String()
+}
+
+// promptLine reads one line of input. On a terminal it uses the text input
+// component; otherwise it reads a plain line from stdin and validates it once.
+func promptLine(config lineInput) (string, error) {
+	if !isInteractiveTerminal() {
+		fmt.Print(config.Message + " ")
+		value, err := readLine()
+		if err != nil {
+			return "", err
+		}
+		if value == "" {
+			value = config.Default
+		}
+		if config.Validate != nil {
+			if err := config.Validate(value); err != nil {
+				return "", err
+			}
+		}
+		return value, nil
+	}
+
+	result, err := tea.NewProgram(newTextInputModel(config)).Run()
+	if err != nil {
+		return "", err
+	}
+	model := result.(textInputModel)
+	if model.cancelled {
+		return "", errInputCancelled
+	}
+	value := strings.TrimSpace(model.input.Value())
+	fmt.Printf("%s %s\n", config.Message, maskValue(value, config.Secret))
+	return value, nil
+}
+
+// maskValue hides a secret value when echoing the answer
+func maskValue(value string, secret bool) string {
+	if secret {
+		return strings.Repeat("*", len([]rune(value)))
+	}
+	return value
+}
+
+// stdinReader is shared so buffered input is not lost between reads
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// readLine reads a whole line from stdin, including spaces
+func readLine() (string, error) {
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// waitForEnter blocks until the user presses enter
+func waitForEnter() {
+	readLine()
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Configuration keys for outbound TLS, stored as files in the config directory
+const (
+	tlsMinVersionKey = "tls-min-version"
+	tlsCiphersKey    = "tls-ciphers"
+)
+
+// defaultTLSMinVersion is used when tls-min-version is not configured
+const defaultTLSMinVersion = tls.VersionTLS12
+
+// tlsVersions maps tls-min-version values to protocol versions
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// parseTLSMinVersion parses a tls-min-version value such as "1.2"
+func parseTLSMinVersion(value string) (uint16, error) {
+	version, ok := tlsVersions[strings.TrimPrefix(strings.TrimSpace(value), "TLS")]
+	if !ok {
+		return 0, fmt.Errorf("invalid %s '%s' (expected 1.0, 1.1, 1.2 or 1.3)", tlsMinVersionKey, value)
+	}
+	return version, nil
+}
+
+// parseTLSCiphers parses a comma-separated list of cipher suite names as
+// printed by Go (e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256). Insecure
+// suites are rejected. TLS 1.3 suites are not configurable and always on.
+func parseTLSCiphers(value string) ([]uint16, error) {
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+
+	var ids []uint16
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure cipher suite '%s' in %s", name, tlsCiphersKey)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// tlsVersionName formats a protocol version for messages
+func tlsVersionName(version uint16) string {
+	for name, v := range tlsVersions {
+		if v == version {
+			return "TLS " + name
+		}
+	}
+	return fmt.Sprintf("TLS 0x%04x", version)
+}
+
+// readConfigValue reads a single configuration key, returning "" when it is not set
+func readConfigValue(key string) (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir, key))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeConfigValue stores a single configuration key
+func writeConfigValue(key, value string) error {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return err
+	}
+	return writeConfigFile(configDir, key, []byte(value))
+}
+
+// setTLSValue validates and stores tls-min-version or tls-ciphers
+func setTLSValue(key, value string) error {
+	var err error
+	if key == tlsMinVersionKey {
+		_, err = parseTLSMinVersion(value)
+	} else {
+		_, err = parseTLSCiphers(value)
+	}
+	if err != nil {
+		return err
+	}
+	return writeConfigValue(key, value)
+}
+
+// tlsDefaultDescription describes the value used when a TLS key is not set
+func tlsDefaultDescription(key string) string {
+	switch key {
+	case tlsMinVersionKey:
+		return "<not configured, default 1.2>"
+	case pinSHA256Key:
+		return "<not configured, no pinning>"
+	}
+	return "<not configured, Go defaults>"
+}
+
+// loadTLSConfig builds the TLS configuration for API requests from the
+// tls-min-version, tls-ciphers and pin-sha256 keys
+func loadTLSConfig() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: defaultTLSMinVersion}
+
+	minVersion, err := readConfigValue(tlsMinVersionKey)
+	if err != nil {
+		return nil, err
+	}
+	if minVersion != "" {
+		if config.MinVersion, err = parseTLSMinVersion(minVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	ciphers, err := readConfigValue(tlsCiphersKey)
+	if err != nil {
+		return nil, err
+	}
+	if ciphers != "" {
+		if config.CipherSuites, err = parseTLSCiphers(ciphers); err != nil {
+			return nil, err
+		}
+	}
+
+	pins, err := readConfigValue(pinSHA256Key)
+	if err != nil {
+		return nil, err
+	}
+	if pins != "" {
+		parsed, err := parseCertPins(pins)
+		if err != nil {
+			return nil, err
+		}
+		config.VerifyPeerCertificate = verifyCertPins(parsed)
+	}
+
+	return config, nil
+}
+
+// newTransport returns a copy of the default transport using tlsConfig
+func newTransport(tlsConfig *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport
+}
+
+// explainTLSError rewrites a handshake failure caused by the server
+// offering an older protocol than tls-min-version allows, and reports a
+// pin-sha256 mismatch on its own rather than as a generic TLS failure
+func explainTLSError(err error, config *tls.Config) error {
+	var pinErr *certPinError
+	if errors.As(err, &pinErr) {
+		return pinErr
+	}
+	var alert tls.AlertError
+	const alertProtocolVersion = 70
+	versionMismatch := (errors.As(err, &alert) && alert == alertProtocolVersion) ||
+		strings.Contains(err.Error(), "unsupported protocol version") ||
+		strings.Contains(err.Error(), "protocol version not supported")
+	if !versionMismatch || config == nil {
+		return err
+	}
+	return fmt.Errorf("server does not support %s or newer (%s): %w", tlsVersionName(config.MinVersion), tlsMinVersionKey, err)
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCertPins(t *testing.T) {
+	spki := "sha256/" + base64.StdEncoding.EncodeToString(make([]byte, 32))
+	fingerprint := strings.Repeat("AB:", 31) + "AB"
+	tests := []struct {
+		value   string
+		want    []bool // spki flag of each parsed pin
+		wantErr bool
+	}{
+		{value: "", want: nil},
+		{value: spki, want: []bool{true}},
+		{value: strings.TrimPrefix(spki, "sha256/"), want: []bool{true}},
+		{value: fingerprint, want: []bool{false}},
+		{value: strings.ToLower(strings.ReplaceAll(fingerprint, ":", "")), want: []bool{false}},
+		{value: " " + spki + " , " + fingerprint + ",", want: []bool{true, false}},
+		{value: "sha256/tooshort", wantErr: true},
+		{value: strings.Repeat("AB:", 20) + "AB", wantErr: true},
+		{value: "sha256/" + base64.StdEncoding.EncodeToString(make([]byte, 20)), wantErr: true},
+		{value: " , ", wantErr: true},
+	}
+	for _, tt := range tests {
+		pins, err := parseCertPins(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseCertPins(%q) = %v, want an error", tt.value, pins)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseCertPins(%q) error: %v", tt.value, err)
+			continue
+		}
+		if len(pins) != len(tt.want) {
+			t.Errorf("parseCertPins(%q) = %d pins, want %d", tt.value, len(pins), len(tt.want))
+			continue
+		}
+		for i, pin := range pins {
+			if pin.spki != tt.want[i] || len(pin.hash) != sha256.Size {
+				t.Errorf("parseCertPins(%q)[%d] = %+v", tt.value, i, pin)
+			}
+		}
+	}
+}
+
+func TestCertPinning(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[],"pagination":{"totalPages":1}}`))
+	}))
+	defer server.Close()
+	cert := server.Certificate()
+	certHash := sha256.Sum256(cert.Raw)
+	otherHash := sha256.Sum256([]byte("another certificate"))
+
+	tests := []struct {
+		name  string
+		pins  string
+		match bool
+	}{
+		{"SPKI pin", spkiPin(cert), true},
+		{"certificate fingerprint", strings.ToUpper(hex.EncodeToString(certHash[:])), true},
+		{"one of several pins", "sha256/" + base64.StdEncoding.EncodeToString(otherHash[:]) + "," + spkiPin(cert), true},
+		{"other SPKI pin", "sha256/" + base64.StdEncoding.EncodeToString(otherHash[:]), false},
+		{"other fingerprint", hex.EncodeToString(otherHash[:]), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := useTempConfigDir(t)
+			t.Setenv(tokenEnvVar, "")
+			writeTestFile(t, filepath.Join(dir, pinSHA256Key), tt.pins)
+
+			config, err := loadTLSConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Trust the test server so only the pin decides
+			config.RootCAs = x509.NewCertPool()
+			config.RootCAs.AddCert(cert)
+			client := NewAPIClient(server.URL)
+			client.SetTLSConfig(config)
+
+			_, err = client.GetSourceTypes(context.Background())
+			if tt.match {
+				if err != nil {
+					t.Errorf("request with a matching pin failed: %v", err)
+				}
+				return
+			}
+			var pinErr *certPinError
+			if !errors.As(err, &pinErr) {
+				t.Fatalf("request with a non-matching pin = %v, want a *certPinError", err)
+			}
+			if pinErr.SPKI != spkiPin(cert) {
+				t.Errorf("reported SPKI = %s, want %s", pinErr.SPKI, spkiPin(cert))
+			}
+		})
+	}
+}